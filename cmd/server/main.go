@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"eagle-bank.com/internal/adapter/auth"
+	"eagle-bank.com/internal/adapter/event"
 	"eagle-bank.com/internal/adapter/handler/http"
+	"eagle-bank.com/internal/adapter/mail"
+	"eagle-bank.com/internal/adapter/storage/memory"
 	"eagle-bank.com/internal/adapter/storage/postgres"
 	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/port"
 	"eagle-bank.com/internal/core/service"
 
 	"github.com/sethvargo/go-envconfig"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -21,6 +28,9 @@ func main() {
 
 	ctx := context.Background()
 
+	// monetary amounts are JSON numbers in openapi.yaml, not strings
+	decimal.MarshalJSONWithoutQuotes = true
+
 	// TODO: trial new logging framework
 	zapLogger, err := zap.NewDevelopment()
 	if err != nil {
@@ -47,30 +57,167 @@ func main() {
 		}
 	}()
 
+	migrations, err := postgres.Migrations()
+	if err != nil {
+		logger.Fatalw("failed to load migrations", "error", err)
+	}
+	migrator := postgres.NewMigrator(dbContext, migrations)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, logger, migrator, os.Args[2:]); err != nil {
+			logger.Fatalw("migration failed", "error", err)
+		}
+		return
+	}
+	if dbCfg.MigrateOnStart {
+		if err := migrateUp(ctx, logger, migrator); err != nil {
+			logger.Fatalw("migration failed", "error", err)
+		}
+	}
+	if dbCfg.SeedDemoData {
+		if err := migrator.SeedDemoData(ctx); err != nil {
+			logger.Fatalw("failed to seed demo data", "error", err)
+		}
+	}
+
 	// wire up the auth service
 	authCfg := auth.Config{}
 	if err := envconfig.Process(ctx, &authCfg); err != nil {
 		logger.Fatalw("failed to load auth config", "error", err)
 	}
 
-	authService, err := auth.NewService(authCfg)
+	var tokenStore port.TokenStore
+	switch authCfg.TokenStore {
+	case "memory":
+		tokenStore = memory.NewTokenStore()
+	case "postgres":
+		tokenStore = repository.NewTokenStore(dbContext)
+	default:
+		logger.Fatalw("unsupported token store", "tokenStore", authCfg.TokenStore)
+	}
+
+	refreshTokenRepo := repository.NewRefreshTokenRepository(dbContext)
+	authService, err := auth.NewService(authCfg, refreshTokenRepo, tokenStore)
 	if err != nil {
 		logger.Fatalw("failed to initialise auth service", "error", err)
 	}
 
+	// wire up outbound email
+	mailCfg := mail.Config{}
+	if err := envconfig.Process(ctx, &mailCfg); err != nil {
+		logger.Fatalw("failed to load mail config", "error", err)
+	}
+	emailCfg := service.EmailConfig{}
+	if err := envconfig.Process(ctx, &emailCfg); err != nil {
+		logger.Fatalw("failed to load email config", "error", err)
+	}
+
+	var deliveryMailer port.Mailer
+	switch mailCfg.Mailer {
+	case "smtp":
+		deliveryMailer = mail.NewSMTPMailer(mailCfg)
+	case "log":
+		deliveryMailer = mail.NewLogMailer(logger, mailCfg)
+	default:
+		logger.Fatalw("unsupported mailer", "mailer", mailCfg.Mailer)
+	}
+	mailer := mail.NewAsyncMailer(logger, deliveryMailer)
+	defer mailer.Close()
+
+	passwordPolicyCfg := service.PasswordPolicyConfig{}
+	if err := envconfig.Process(ctx, &passwordPolicyCfg); err != nil {
+		logger.Fatalw("failed to load password policy config", "error", err)
+	}
+	passwordPolicy, err := service.NewPasswordPolicy(passwordPolicyCfg)
+	if err != nil {
+		logger.Fatalw("failed to load password policy", "error", err)
+	}
+
+	loginProtectionCfg := service.LoginProtectionConfig{}
+	if err := envconfig.Process(ctx, &loginProtectionCfg); err != nil {
+		logger.Fatalw("failed to load login protection config", "error", err)
+	}
+
 	userRepo := repository.NewUserRepository(dbContext)
-	userService := service.NewUserService(userRepo)
-	userHandler := http.NewUserHandler(logger, authService, userService)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(dbContext)
+	emailSendRepo := repository.NewEmailSendRepository(dbContext)
+	userService := service.NewUserService(userRepo, loginAttemptRepo, emailSendRepo, mailer, emailCfg, passwordPolicy, loginProtectionCfg)
+	mfaCfg := service.MFAConfig{}
+	if err := envconfig.Process(ctx, &mfaCfg); err != nil {
+		logger.Fatalw("failed to load MFA config", "error", err)
+	}
+	mfaRepo := repository.NewMFARepository(dbContext)
+	mfaService, err := service.NewMFAService(mfaRepo, userRepo, loginAttemptRepo, mfaCfg, loginProtectionCfg)
+	if err != nil {
+		logger.Fatalw("failed to create MFA service", "error", err)
+	}
+	mfaHandler := http.NewMFAHandler(logger, authService, mfaService)
+
+	userHandler := http.NewUserHandler(logger, authService, userService, mfaService)
 
 	accountRepo := repository.NewAccountRepository(dbContext)
-	accountService := service.NewAccountService(accountRepo)
+	accountNumberAllocator := repository.NewAccountNumberAllocator(dbContext)
+	accountService := service.NewAccountService(accountRepo, accountNumberAllocator)
 	accountHandler := http.NewAccountHandler(logger, authService, userService, accountService)
 
-	router, err := http.NewRouter(authService, userHandler, accountHandler)
+	transactionRepo := repository.NewTransactionRepository(dbContext)
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo)
+	transactionHandler := http.NewTransactionHandler(logger, authService, transactionService)
+
+	idempotencyRepo := repository.NewIdempotencyRepository(dbContext)
+
+	keyHandler := http.NewKeyHandler(logger, authService)
+
+	adminRepo := repository.NewAdminRepository(dbContext)
+	auditRepo := repository.NewAuditRepository(dbContext)
+	adminService := service.NewAdminService(adminRepo, userRepo, accountRepo, transactionRepo, auditRepo, loginAttemptRepo, mailer, emailCfg, loginProtectionCfg)
+	adminHandler := http.NewAdminHandler(logger, authService, userService, adminService)
+
+	requestTimeout, err := time.ParseDuration(dbCfg.RequestTimeout)
+	if err != nil {
+		logger.Fatalw("invalid database request timeout", "error", err)
+	}
+	httpCfg := http.Config{}
+	if err := envconfig.Process(ctx, &httpCfg); err != nil {
+		logger.Fatalw("failed to load http config", "error", err)
+	}
+	router, err := http.NewRouter(authService, idempotencyRepo, requestTimeout, httpCfg.TrustedProxies, userHandler, accountHandler, transactionHandler, keyHandler, mfaHandler, adminHandler)
 	if err != nil {
 		logger.Fatalw("error initializing router", "error", err)
 	}
 
+	// wire up the outbox dispatcher
+	eventCfg := event.Config{}
+	if err := envconfig.Process(ctx, &eventCfg); err != nil {
+		logger.Fatalw("failed to load event config", "error", err)
+	}
+	publishTimeout, err := time.ParseDuration(eventCfg.Timeout)
+	if err != nil {
+		logger.Fatalw("invalid event publish timeout", "error", err)
+	}
+
+	var publisher port.Publisher
+	switch eventCfg.Publisher {
+	case "log":
+		publisher = event.NewLogPublisher(logger)
+	case "webhook":
+		publisher = event.NewWebhookPublisher(eventCfg.WebhookURL, eventCfg.WebhookSecret, publishTimeout)
+	case "nats":
+		natsPublisher, err := event.NewNATSPublisher(eventCfg.NATSURL, eventCfg.NATSSubjectPrefix, publishTimeout)
+		if err != nil {
+			logger.Fatalw("failed to initialise NATS publisher", "error", err)
+		}
+		defer natsPublisher.Close()
+		publisher = natsPublisher
+	default:
+		logger.Fatalw("unsupported event publisher", "publisher", eventCfg.Publisher)
+	}
+
+	outboxRepo := repository.NewOutboxRepository(dbContext)
+	dispatcher := service.NewOutboxDispatcher(logger, outboxRepo, publisher)
+	dispatchCtx, stopDispatcher := context.WithCancel(ctx)
+	defer stopDispatcher()
+	go dispatcher.Run(dispatchCtx)
+
 	logger.Infow("server starting", "port", 8080)
 	err = router.Serve(":8080")
 	if err != nil {
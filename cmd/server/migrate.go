@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"go.uber.org/zap"
+)
+
+const migrateUsage = "usage: server migrate up | down [steps] | status | seed"
+
+// runMigrate handles the migrate subcommand, e.g. `server migrate down 1`.
+func runMigrate(ctx context.Context, logger *zap.SugaredLogger, migrator *postgres.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrateUp(ctx, logger, migrator)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("steps must be a positive number: %s", migrateUsage)
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			logger.Infow("reverted migration", "version", migration.Version, "name", migration.Name)
+		}
+		return err
+	case "status":
+		applied, err := migrator.Applied(ctx)
+		if err != nil {
+			return err
+		}
+		for _, migration := range applied {
+			logger.Infow("applied migration", "version", migration.Version, "name", migration.Name)
+		}
+		return nil
+	case "seed":
+		return migrator.SeedDemoData(ctx)
+	default:
+		return fmt.Errorf("unknown migrate command %q: %s", args[0], migrateUsage)
+	}
+}
+
+func migrateUp(ctx context.Context, logger *zap.SugaredLogger, migrator *postgres.Migrator) error {
+	applied, err := migrator.Up(ctx)
+	for _, migration := range applied {
+		logger.Infow("applied migration", "version", migration.Version, "name", migration.Name)
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// verificationKey is a public key accepted when validating tokens, identified
+// by the kid header of the tokens it signed.
+type verificationKey struct {
+	kid    string
+	method jwt.SigningMethod
+	public crypto.PublicKey
+}
+
+// signingKey is the private key new tokens are signed with
+type signingKey struct {
+	verificationKey
+	private crypto.Signer
+}
+
+// loadSigningKey reads a PKCS#8 or PKCS#1 PEM encoded RSA or Ed25519 private key
+func loadSigningKey(path string) (*signingKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	var private crypto.Signer
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.Errorf("unsupported private key type in %s", path)
+		}
+		private = signer
+	} else if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		private = key
+	} else {
+		return nil, errors.Errorf("failed to parse private key in %s", path)
+	}
+
+	key, err := newVerificationKey(private.Public())
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return &signingKey{
+		verificationKey: *key,
+		private:         private,
+	}, nil
+}
+
+// loadVerificationKey reads a PKIX PEM encoded RSA or Ed25519 public key
+func loadVerificationKey(path string) (*verificationKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse public key in %s", path)
+	}
+	key, err := newVerificationKey(public)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key file")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+// newVerificationKey derives the signing method from the key type and the kid
+// from a hash of the public key, so the same key always gets the same kid.
+func newVerificationKey(public crypto.PublicKey) (*verificationKey, error) {
+	var method jwt.SigningMethod
+	switch public.(type) {
+	case *rsa.PublicKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("unsupported key type, expected RSA or Ed25519")
+	}
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(der)
+	return &verificationKey{
+		kid:    base64.RawURLEncoding.EncodeToString(sum[:12]),
+		method: method,
+		public: public,
+	}, nil
+}
+
+func (k verificationKey) jwk() model.JSONWebKey {
+	key := model.JSONWebKey{
+		Kid: k.kid,
+		Use: "sig",
+		Alg: k.method.Alg(),
+	}
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		key.Kty = "RSA"
+		key.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		key.Kty = "OKP"
+		key.Crv = "Ed25519"
+		key.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return key
+}
@@ -0,0 +1,100 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/auth"
+	"eagle-bank.com/internal/adapter/storage/memory"
+	"eagle-bank.com/internal/core/port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes PEM encoded private and public keys to dir and returns their paths
+func writeKeyPair(t *testing.T, dir string, name string, private crypto.Signer) (string, string) {
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(dir, name+".pem")
+	publicPath := filepath.Join(dir, name+".pub.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+	return privatePath, publicPath
+}
+
+func newKeyedService(t *testing.T, signingKeyFile string, verificationKeyFiles ...string) port.AuthService {
+	service, err := auth.NewService(auth.Config{
+		AccessTokenExpiry:    "15m",
+		RefreshTokenExpiry:   "60m",
+		SigningKeyFile:       signingKeyFile,
+		VerificationKeyFiles: verificationKeyFiles,
+	}, newRefreshTokenRepository(), memory.NewTokenStore())
+	require.NoError(t, err)
+	return service
+}
+
+func TestService_AsymmetricKeys(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rsaPrivate, rsaPublic := writeKeyPair(t, dir, "rsa", rsaKey)
+	edPrivate, _ := writeKeyPair(t, dir, "ed25519", edKey)
+	userID := "2b1a7d6e-4c0f-4f4e-9a57-0f0c1f6f8d21"
+
+	t.Run("RS256 tokens validate and the key is published", func(t *testing.T) {
+		service := newKeyedService(t, rsaPrivate)
+		pair, err := service.GenerateTokens(context.Background(), userID, nil)
+		require.NoError(t, err)
+		assert.NoError(t, service.ValidateToken(bearerContext(pair.AccessToken)))
+
+		jwks := service.JWKS()
+		require.Len(t, jwks.Keys, 1)
+		assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+		assert.Equal(t, "RS256", jwks.Keys[0].Alg)
+		assert.NotEmpty(t, jwks.Keys[0].Kid)
+	})
+
+	t.Run("tokens signed before rotation still validate", func(t *testing.T) {
+		old := newKeyedService(t, rsaPrivate)
+		pair, err := old.GenerateTokens(context.Background(), userID, nil)
+		require.NoError(t, err)
+
+		rotated := newKeyedService(t, edPrivate, rsaPublic)
+		assert.NoError(t, rotated.ValidateToken(bearerContext(pair.AccessToken)))
+		assert.Len(t, rotated.JWKS().Keys, 2)
+
+		pair, err = rotated.GenerateTokens(context.Background(), userID, nil)
+		require.NoError(t, err)
+		assert.NoError(t, rotated.ValidateToken(bearerContext(pair.AccessToken)))
+	})
+
+	t.Run("tokens from a retired key are rejected", func(t *testing.T) {
+		old := newKeyedService(t, rsaPrivate)
+		pair, err := old.GenerateTokens(context.Background(), userID, nil)
+		require.NoError(t, err)
+
+		rotated := newKeyedService(t, edPrivate)
+		assert.Error(t, rotated.ValidateToken(bearerContext(pair.AccessToken)))
+	})
+
+	t.Run("shared secret tokens are rejected once keys are configured", func(t *testing.T) {
+		pair, err := newService(t).GenerateTokens(context.Background(), userID, nil)
+		require.NoError(t, err)
+		assert.Error(t, newKeyedService(t, rsaPrivate).ValidateToken(bearerContext(pair.AccessToken)))
+	})
+}
@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 	"eagle-bank.com/internal/core/port"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -16,15 +19,35 @@ type Config struct {
 	APISecret          string `env:"API_SECRET, default=eagle-bank-secret"`
 	AccessTokenExpiry  string `env:"ACCESS_TOKEN_EXPIRY, default=15m"`
 	RefreshTokenExpiry string `env:"REFRESH_TOKEN_EXPIRY, default=60m"`
+	// TokenStore selects where revoked token IDs are kept: postgres or memory
+	TokenStore string `env:"TOKEN_STORE, default=postgres"`
+	// SigningKeyFile is a PEM encoded RSA (RS256) or Ed25519 (EdDSA) private
+	// key. When it is unset tokens are signed HS256 with APISecret.
+	SigningKeyFile string `env:"JWT_SIGNING_KEY_FILE"`
+	// VerificationKeyFiles are PEM encoded public keys still accepted for
+	// tokens signed before the signing key was rotated.
+	VerificationKeyFiles []string `env:"JWT_VERIFICATION_KEY_FILES"`
 }
 
 type Service struct {
 	apiSecret          string
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
+	refreshTokenRepo   port.RefreshTokenRepository
+	tokenStore         port.TokenStore
+	signingKey         *signingKey
+	verificationKeys   map[string]verificationKey
 }
 
-func NewService(config Config) (port.AuthService, error) {
+const refreshTokenType = "refresh"
+
+// mfaTokenExpiry is how long a user has to present their second factor
+const mfaTokenExpiry = 5 * time.Minute
+
+func NewService(
+	config Config,
+	refreshTokenRepo port.RefreshTokenRepository,
+	tokenStore port.TokenStore) (port.AuthService, error) {
 
 	accessTokenExpiry, err := time.ParseDuration(config.AccessTokenExpiry)
 	if err != nil {
@@ -36,54 +59,216 @@ func NewService(config Config) (port.AuthService, error) {
 		return nil, errors.New("invalid refresh token expiry format")
 	}
 
-	return &Service{
+	service := &Service{
 		apiSecret:          config.APISecret,
 		accessTokenExpiry:  accessTokenExpiry,
 		refreshTokenExpiry: refreshTokenExpiry,
-	}, nil
+		refreshTokenRepo:   refreshTokenRepo,
+		tokenStore:         tokenStore,
+	}
+
+	if config.SigningKeyFile == "" {
+		if len(config.VerificationKeyFiles) > 0 {
+			return nil, errors.New("verification keys require a signing key file")
+		}
+		return service, nil
+	}
+
+	service.signingKey, err = loadSigningKey(config.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	service.verificationKeys = map[string]verificationKey{
+		service.signingKey.kid: service.signingKey.verificationKey,
+	}
+	for _, path := range config.VerificationKeyFiles {
+		key, err := loadVerificationKey(path)
+		if err != nil {
+			return nil, err
+		}
+		service.verificationKeys[key.kid] = *key
+	}
+	return service, nil
 }
 
-func (s *Service) ValidateSetPasswordToken(c *gin.Context) error {
-	bearerToken := c.Request.Header.Get("Authorization")
-	var bToken string
-	if len(strings.Split(bearerToken, " ")) == 2 {
+// JWKS returns the public keys tokens may be verified with. It is empty when
+// tokens are signed with the shared secret.
+func (s *Service) JWKS() model.JSONWebKeySet {
+	set := model.JSONWebKeySet{Keys: []model.JSONWebKey{}}
+	for _, key := range s.verificationKeys {
+		set.Keys = append(set.Keys, key.jwk())
+	}
+	slices.SortFunc(set.Keys, func(a, b model.JSONWebKey) int {
+		return strings.Compare(a.Kid, b.Kid)
+	})
+	return set
+}
 
-		bToken = strings.Split(bearerToken, " ")[1]
+func (s *Service) ValidateSetPasswordToken(c *gin.Context) error {
+	scopes, err := s.ExtractScopes(c)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(scopes, model.ScopeSetPassword) {
+		return model.ErrForbidden
 	}
-	//TODO inspect claims
-	_ = bToken
 	return nil
 }
 
+// ExtractScopes returns the scopes granted by the roles claim of the caller's token
+func (s *Service) ExtractScopes(c *gin.Context) ([]string, error) {
+	token, err := s.parseToken(s.ExtractToken(c))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("token is invalid")
+	}
+	return claimRoles(claims), nil
+}
+
 func (s *Service) getAccessTokenExpirationTime() time.Time {
 	return time.Now().Add(s.accessTokenExpiry)
 }
 
 func (s *Service) getRefreshTokenExpirationTime() time.Time {
-	return time.Now().Add(s.accessTokenExpiry)
+	return time.Now().Add(s.refreshTokenExpiry)
 }
 
-func (s *Service) GenerateTokens(userID string, roles []string) (*model.TokenPair, error) {
+func (s *Service) GenerateTokens(ctx context.Context, userID string, roles []string) (*model.TokenPair, error) {
+	return s.generateTokens(ctx, userID, roles, uuid.NewString())
+}
+
+// GenerateMFAToken issues a short-lived token that only allows the second
+// step of a two-factor login. It has no refresh token.
+func (s *Service) GenerateMFAToken(ctx context.Context, userID string) (*model.MFAChallenge, error) {
+	generation, err := s.tokenStore.UserGeneration(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiry := now.Add(mfaTokenExpiry)
+	token, err := s.sign(jwt.MapClaims{
+		"user_id": userID,
+		"roles":   []string{model.ScopeMFA},
+		"jti":     uuid.NewString(),
+		"gen":     generation,
+		"exp":     expiry.Unix(),
+		"iat":     now.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.MFAChallenge{Token: token, ExpiresAt: expiry}, nil
+}
+
+// GenerateAdminToken issues an access token carrying only the admin scope.
+// Admin sessions cannot be refreshed; staff log in again once it expires.
+func (s *Service) GenerateAdminToken(adminID string) (*model.AccessToken, error) {
+	now := time.Now()
+	expiry := s.getAccessTokenExpirationTime()
+	token, err := s.sign(jwt.MapClaims{
+		"user_id": adminID,
+		"roles":   []string{model.ScopeAdmin},
+		"jti":     uuid.NewString(),
+		"exp":     expiry.Unix(),
+		"iat":     now.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.AccessToken{Token: token, ExpiresAt: expiry}, nil
+}
+
+// RefreshTokens redeems a refresh token for a new token pair. Each refresh
+// token can be used once; presenting one again revokes every token issued
+// from the same login, since either the client or an attacker holds a copy.
+func (s *Service) RefreshTokens(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	token, err := s.parseToken(refreshToken)
+	if err != nil {
+		return nil, model.ErrInvalidToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["type"] != refreshTokenType {
+		return nil, model.ErrInvalidToken
+	}
+	tokenID, _ := claims["jti"].(string)
+	userID, _ := claims["user_id"].(string)
+	if tokenID == "" || userID == "" {
+		return nil, model.ErrInvalidToken
+	}
+
+	stored, err := s.refreshTokenRepo.GetRefreshToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.UserID != userID || stored.RevokedAt != nil {
+		return nil, model.ErrInvalidToken
+	}
+
+	// losing the race to mark the token used means it was redeemed twice
+	used, err := s.refreshTokenRepo.UseRefreshToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.UsedAt != nil || !used {
+		if err := s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, model.ErrRefreshTokenReused
+	}
+
+	return s.generateTokens(ctx, userID, claimRoles(claims), stored.FamilyID)
+}
+
+func (s *Service) generateTokens(ctx context.Context, userID string, roles []string, familyID string) (*model.TokenPair, error) {
+	// read before signing, so a revocation racing with this can only ever
+	// leave the new tokens revoked
+	generation, err := s.tokenStore.UserGeneration(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	accessExpiry := s.getAccessTokenExpirationTime()
+	refreshExpiry := s.getRefreshTokenExpirationTime()
 
 	accessClaims := jwt.MapClaims{
 		"user_id": userID,
 		"roles":   roles,
-		"exp":     s.getAccessTokenExpirationTime().Unix(),
-		"iat":     time.Now().Unix(),
+		"jti":     uuid.NewString(),
+		"gen":     generation,
+		"exp":     accessExpiry.Unix(),
+		"iat":     now.Unix(),
 	}
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(s.apiSecret))
+	accessToken, err := s.sign(accessClaims)
 
 	if err != nil {
 		return nil, err
 	}
 
+	refreshTokenID := uuid.NewString()
 	refreshClaims := jwt.MapClaims{
 		"user_id": userID,
-		"type":    "refresh",
-		"exp":     s.getRefreshTokenExpirationTime().Unix(),
-		"iat":     time.Now().Unix(),
+		"roles":   roles,
+		"type":    refreshTokenType,
+		"jti":     refreshTokenID,
+		"gen":     generation,
+		"exp":     refreshExpiry.Unix(),
+		"iat":     now.Unix(),
 	}
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(s.apiSecret))
+	refreshToken, err := s.sign(refreshClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refreshTokenRepo.CreateRefreshToken(ctx, &model.RefreshToken{
+		ID:        refreshTokenID,
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: refreshExpiry.UTC(),
+		CreatedAt: now.UTC(),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -91,28 +276,146 @@ func (s *Service) GenerateTokens(userID string, roles []string) (*model.TokenPai
 	return &model.TokenPair{
 		AccessToken:   accessToken,
 		RefreshToken:  refreshToken,
-		AccessExpiry:  s.getAccessTokenExpirationTime(),
-		RefreshExpiry: s.getRefreshTokenExpirationTime(),
+		AccessExpiry:  accessExpiry,
+		RefreshExpiry: refreshExpiry,
 	}, nil
 }
 
-func (s *Service) ValidateToken(c *gin.Context) error {
-	tokenString := s.ExtractToken(c)
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+func (s *Service) sign(claims jwt.MapClaims) (string, error) {
+	if s.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.apiSecret))
+	}
+	token := jwt.NewWithClaims(s.signingKey.method, claims)
+	token.Header["kid"] = s.signingKey.kid
+	return token.SignedString(s.signingKey.private)
+}
+
+func (s *Service) parseToken(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if s.signingKey == nil {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return []byte(s.apiSecret), nil
+		}
+		// the key, not the token, decides the algorithm
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.verificationKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %v", token.Header["kid"])
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(s.apiSecret), nil
+		return key.public, nil
 	})
+}
+
+func claimRoles(claims jwt.MapClaims) []string {
+	values, _ := claims["roles"].([]interface{})
+	roles := make([]string, 0, len(values))
+	for _, v := range values {
+		if role, ok := v.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func (s *Service) ValidateToken(c *gin.Context) error {
+	token, err := s.parseToken(s.ExtractToken(c))
 	if err != nil {
 		return err
 	}
 
-	//TODO: consider the whole blacklist and revoking mechanism for each token IDs (REDIS)
+	// refresh tokens can only be redeemed, never used to call the API
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && claims["type"] == refreshTokenType {
+		return fmt.Errorf("token is invalid")
+	}
+
 	if !token.Valid {
 		return fmt.Errorf("token is invalid")
 	}
 
+	claims, _ := token.Claims.(jwt.MapClaims)
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return fmt.Errorf("token is invalid")
+	}
+	ctx := c.Request.Context()
+	revoked, err := s.tokenStore.IsRevoked(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return fmt.Errorf("token has been revoked")
+	}
+
+	// tokens without a generation, such as admin tokens, are at generation zero
+	userID, _ := claims["user_id"].(string)
+	tokenGeneration, _ := claims["gen"].(float64)
+	generation, err := s.tokenStore.UserGeneration(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if int64(tokenGeneration) < generation {
+		return fmt.Errorf("token has been revoked")
+	}
+
+	return nil
+}
+
+// RevokeUserSessions revokes every access and refresh token issued to the user so far
+func (s *Service) RevokeUserSessions(ctx context.Context, userID string) error {
+	if err := s.tokenStore.RevokeUser(ctx, userID); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeUserRefreshTokens(ctx, userID)
+}
+
+// Logout revokes the caller's access token and, when one is given, every
+// refresh token issued from the same login as refreshToken.
+func (s *Service) Logout(c *gin.Context, refreshToken string) error {
+	token, err := s.parseToken(s.ExtractToken(c))
+	if err != nil {
+		return model.ErrInvalidToken
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	tokenID, _ := claims["jti"].(string)
+	userID, _ := claims["user_id"].(string)
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil || tokenID == "" {
+		return model.ErrInvalidToken
+	}
+
+	ctx := c.Request.Context()
+	var familyID string
+	if refreshToken != "" {
+		refresh, err := s.parseToken(refreshToken)
+		if err != nil {
+			return model.ErrInvalidToken
+		}
+		refreshClaims, _ := refresh.Claims.(jwt.MapClaims)
+		refreshTokenID, _ := refreshClaims["jti"].(string)
+		if refreshClaims["type"] != refreshTokenType || refreshTokenID == "" {
+			return model.ErrInvalidToken
+		}
+		stored, err := s.refreshTokenRepo.GetRefreshToken(ctx, refreshTokenID)
+		if err != nil {
+			return err
+		}
+		if stored.UserID != userID {
+			return model.ErrInvalidToken
+		}
+		familyID = stored.FamilyID
+	}
+
+	if err := s.tokenStore.Revoke(ctx, tokenID, expiresAt.Time); err != nil {
+		return err
+	}
+	if familyID != "" {
+		return s.refreshTokenRepo.RevokeRefreshTokenFamily(ctx, familyID)
+	}
 	return nil
 }
 
@@ -133,13 +436,7 @@ func (s *Service) ExtractTokenID(c *gin.Context) (string, error) {
 	if exists {
 		return userIDValue.(string), nil
 	}
-	tokenString := s.ExtractToken(c)
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.apiSecret), nil
-	})
+	token, err := s.parseToken(s.ExtractToken(c))
 	if err != nil {
 		return "", err
 	}
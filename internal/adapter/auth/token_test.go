@@ -0,0 +1,219 @@
+package auth_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/auth"
+	"eagle-bank.com/internal/adapter/storage/memory"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"eagle-bank.com/internal/core/port/mocks"
+	"github.com/gin-gonic/gin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRefreshTokenRepository() *mocks.RefreshTokenRepositoryMock {
+	tokens := map[string]*model.RefreshToken{}
+	return &mocks.RefreshTokenRepositoryMock{
+		CreateRefreshTokenFunc: func(ctx context.Context, token *model.RefreshToken) error {
+			stored := *token
+			tokens[token.ID] = &stored
+			return nil
+		},
+		GetRefreshTokenFunc: func(ctx context.Context, id string) (*model.RefreshToken, error) {
+			stored, ok := tokens[id]
+			if !ok {
+				return nil, model.ErrInvalidToken
+			}
+			copied := *stored
+			return &copied, nil
+		},
+		UseRefreshTokenFunc: func(ctx context.Context, id string) (bool, error) {
+			stored, ok := tokens[id]
+			if !ok || stored.UsedAt != nil || stored.RevokedAt != nil {
+				return false, nil
+			}
+			now := time.Now()
+			stored.UsedAt = &now
+			return true, nil
+		},
+		RevokeRefreshTokenFamilyFunc: func(ctx context.Context, familyID string) error {
+			now := time.Now()
+			for _, stored := range tokens {
+				if stored.FamilyID == familyID {
+					stored.RevokedAt = &now
+				}
+			}
+			return nil
+		},
+		RevokeUserRefreshTokensFunc: func(ctx context.Context, userID string) error {
+			now := time.Now()
+			for _, stored := range tokens {
+				if stored.UserID == userID {
+					stored.RevokedAt = &now
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newService(t *testing.T) port.AuthService {
+	service, err := auth.NewService(auth.Config{
+		APISecret:          "test-secret",
+		AccessTokenExpiry:  "15m",
+		RefreshTokenExpiry: "24h",
+	}, newRefreshTokenRepository(), memory.NewTokenStore())
+	require.NoError(t, err)
+	return service
+}
+
+func bearerContext(token string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/", nil)
+	c.Request.Header.Set("Authorization", "Bearer "+token)
+	return c
+}
+
+func TestService_RefreshTokens(t *testing.T) {
+	service := newService(t)
+
+	userID := "2b1a7d6e-4c0f-4f4e-9a57-0f0c1f6f8d21"
+
+	t.Run("refresh expiry is taken from REFRESH_TOKEN_EXPIRY", func(t *testing.T) {
+		pair, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), pair.RefreshExpiry, time.Minute)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), pair.AccessExpiry, time.Minute)
+	})
+
+	t.Run("refresh token rotates within its family", func(t *testing.T) {
+		first, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+
+		second, err := service.RefreshTokens(context.Background(), first.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+		_, err = service.RefreshTokens(context.Background(), second.RefreshToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("reusing a refresh token revokes the family", func(t *testing.T) {
+		first, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+		second, err := service.RefreshTokens(context.Background(), first.RefreshToken)
+		require.NoError(t, err)
+
+		_, err = service.RefreshTokens(context.Background(), first.RefreshToken)
+		assert.ErrorIs(t, err, model.ErrRefreshTokenReused)
+
+		_, err = service.RefreshTokens(context.Background(), second.RefreshToken)
+		assert.ErrorIs(t, err, model.ErrInvalidToken)
+	})
+
+	t.Run("access token cannot be redeemed", func(t *testing.T) {
+		pair, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+
+		_, err = service.RefreshTokens(context.Background(), pair.AccessToken)
+		assert.ErrorIs(t, err, model.ErrInvalidToken)
+	})
+}
+
+func TestService_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := newService(t)
+	userID := "2b1a7d6e-4c0f-4f4e-9a57-0f0c1f6f8d21"
+
+	t.Run("access token stops working after logout", func(t *testing.T) {
+		pair, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+		require.NoError(t, service.ValidateToken(bearerContext(pair.AccessToken)))
+
+		require.NoError(t, service.Logout(bearerContext(pair.AccessToken), ""))
+		assert.Error(t, service.ValidateToken(bearerContext(pair.AccessToken)))
+	})
+
+	t.Run("logout revokes the refresh token family", func(t *testing.T) {
+		pair, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+
+		require.NoError(t, service.Logout(bearerContext(pair.AccessToken), pair.RefreshToken))
+		_, err = service.RefreshTokens(context.Background(), pair.RefreshToken)
+		assert.ErrorIs(t, err, model.ErrInvalidToken)
+	})
+
+	t.Run("revoking a user's sessions revokes every token", func(t *testing.T) {
+		first, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+		second, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+
+		require.NoError(t, service.RevokeUserSessions(context.Background(), userID))
+		assert.Error(t, service.ValidateToken(bearerContext(first.AccessToken)))
+		assert.Error(t, service.ValidateToken(bearerContext(second.AccessToken)))
+		_, err = service.RefreshTokens(context.Background(), second.RefreshToken)
+		assert.ErrorIs(t, err, model.ErrInvalidToken)
+	})
+
+	t.Run("tokens issued in the same second as a revocation stay valid", func(t *testing.T) {
+		otherUserID := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+		// start just after a second boundary so both steps share the second
+		time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+		revokedAt := time.Now()
+		require.NoError(t, service.RevokeUserSessions(context.Background(), otherUserID))
+		pair, err := service.GenerateTokens(context.Background(), otherUserID, []string{"deposit"})
+		require.NoError(t, err)
+		require.Equal(t, revokedAt.Unix(), time.Now().Unix())
+
+		assert.NoError(t, service.ValidateToken(bearerContext(pair.AccessToken)))
+		_, err = service.RefreshTokens(context.Background(), pair.RefreshToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("refresh token cannot authenticate requests", func(t *testing.T) {
+		pair, err := service.GenerateTokens(context.Background(), userID, []string{"deposit"})
+		require.NoError(t, err)
+		assert.Error(t, service.ValidateToken(bearerContext(pair.RefreshToken)))
+	})
+}
+
+func TestService_GenerateMFAToken(t *testing.T) {
+	service := newService(t)
+
+	challenge, err := service.GenerateMFAToken(context.Background(), "user-123")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), challenge.ExpiresAt, time.Second)
+
+	c := bearerContext(challenge.Token)
+	require.NoError(t, service.ValidateToken(c))
+	scopes, err := service.ExtractScopes(c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{model.ScopeMFA}, scopes, "the token must only allow the second login step")
+
+	_, err = service.RefreshTokens(context.Background(), challenge.Token)
+	assert.ErrorIs(t, err, model.ErrInvalidToken)
+}
+
+func TestService_GenerateAdminToken(t *testing.T) {
+	service := newService(t)
+
+	token, err := service.GenerateAdminToken("admin-123")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), token.ExpiresAt, time.Minute)
+
+	c := bearerContext(token.Token)
+	require.NoError(t, service.ValidateToken(c))
+	scopes, err := service.ExtractScopes(c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{model.ScopeAdmin}, scopes)
+
+	_, err = service.RefreshTokens(context.Background(), token.Token)
+	assert.ErrorIs(t, err, model.ErrInvalidToken)
+}
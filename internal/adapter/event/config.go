@@ -0,0 +1,13 @@
+package event
+
+type Config struct {
+	// Publisher selects where outbox events are delivered: log, webhook or nats
+	Publisher  string `env:"EVENT_PUBLISHER, default=log"`
+	WebhookURL string `env:"EVENT_WEBHOOK_URL"`
+	// WebhookSecret signs webhook bodies in the X-Eagle-Signature header
+	WebhookSecret string `env:"EVENT_WEBHOOK_SECRET"`
+	NATSURL       string `env:"EVENT_NATS_URL, default=nats://localhost:4222"`
+	// NATSSubjectPrefix is prepended to the event type to form the subject
+	NATSSubjectPrefix string `env:"EVENT_NATS_SUBJECT_PREFIX, default=eagle-bank"`
+	Timeout           string `env:"EVENT_PUBLISH_TIMEOUT, default=5s"`
+}
@@ -0,0 +1,27 @@
+package event
+
+import (
+	"eagle-bank.com/internal/core/domain/model"
+	"go.uber.org/zap"
+)
+
+/**
+ * LogPublisher implements port.Publisher interface for development
+ * by writing each event to the log
+ */
+
+type LogPublisher struct {
+	logger *zap.SugaredLogger
+}
+
+// NewLogPublisher creates a new log publisher instance
+func NewLogPublisher(logger *zap.SugaredLogger) *LogPublisher {
+	return &LogPublisher{
+		logger: logger,
+	}
+}
+
+func (p *LogPublisher) Publish(event model.Event) error {
+	p.logger.Infow("event published", "id", event.ID, "type", event.Type, "aggregateId", event.AggregateID, "payload", string(event.Payload))
+	return nil
+}
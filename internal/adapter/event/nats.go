@@ -0,0 +1,131 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+/**
+ * NATSPublisher implements port.Publisher interface using the NATS text
+ * protocol directly. Each publish is followed by a PING so that the PONG
+ * confirms the server has processed it before the event is marked published.
+ */
+
+type NATSPublisher struct {
+	addr          string
+	subjectPrefix string
+	timeout       time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNATSPublisher creates a new NATS publisher instance. The connection is
+// made on first publish and re-established after any error.
+func NewNATSPublisher(natsURL string, subjectPrefix string, timeout time.Duration) (*NATSPublisher, error) {
+	parsed, err := url.Parse(natsURL)
+	if err != nil || parsed.Host == "" {
+		return nil, errors.Errorf("invalid NATS url %q", natsURL)
+	}
+	return &NATSPublisher{
+		addr:          parsed.Host,
+		subjectPrefix: subjectPrefix,
+		timeout:       timeout,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(event model.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+	subject := event.Type
+	if p.subjectPrefix != "" {
+		subject = p.subjectPrefix + "." + event.Type
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+	if err := p.publish(subject, body); err != nil {
+		p.close()
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (p *NATSPublisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.close()
+}
+
+func (p *NATSPublisher) connect() error {
+	if p.conn != nil {
+		return nil
+	}
+	conn, err := net.DialTimeout("tcp", p.addr, p.timeout)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to NATS")
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	_ = conn.SetDeadline(time.Now().Add(p.timeout))
+	line, err := p.reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO") {
+		p.close()
+		return errors.New("unexpected NATS greeting")
+	}
+	if _, err := conn.Write([]byte(`CONNECT {"verbose":false,"pedantic":false,"name":"eagle-bank"}` + "\r\n")); err != nil {
+		p.close()
+		return errors.Wrap(err, "failed to connect to NATS")
+	}
+	return nil
+}
+
+func (p *NATSPublisher) publish(subject string, body []byte) error {
+	_ = p.conn.SetDeadline(time.Now().Add(p.timeout))
+	message := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(body), body)
+	if _, err := p.conn.Write([]byte(message)); err != nil {
+		return errors.Wrap(err, "failed to publish to NATS")
+	}
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return errors.Wrap(err, "failed to confirm NATS publish")
+		}
+		switch {
+		case strings.HasPrefix(line, "PONG"):
+			return nil
+		case strings.HasPrefix(line, "PING"):
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return errors.Wrap(err, "failed to reply to NATS ping")
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.Errorf("NATS error: %s", strings.TrimSpace(line))
+		}
+	}
+}
+
+func (p *NATSPublisher) close() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.conn = nil
+	p.reader = nil
+}
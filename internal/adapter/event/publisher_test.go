@@ -0,0 +1,102 @@
+package event_test
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	netHTTP "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/event"
+	"eagle-bank.com/internal/core/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = model.Event{
+	ID:          "6f1c2a7e-5b1d-4f0e-8c3a-2d9e4b7a1c55",
+	Type:        model.EventAccountOpened,
+	AggregateID: "01234567",
+	Payload:     json.RawMessage(`{"accountNumber":"01234567"}`),
+	CreatedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	var received *netHTTP.Request
+	var body []byte
+	status := netHTTP.StatusNoContent
+	server := httptest.NewServer(netHTTP.HandlerFunc(func(w netHTTP.ResponseWriter, r *netHTTP.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	publisher := event.NewWebhookPublisher(server.URL, "webhook-secret", time.Second)
+
+	t.Run("event is posted and signed", func(t *testing.T) {
+		require.NoError(t, publisher.Publish(testEvent))
+		assert.Equal(t, testEvent.ID, received.Header.Get("X-Eagle-Event-Id"))
+		assert.Equal(t, testEvent.Type, received.Header.Get("X-Eagle-Event-Type"))
+
+		mac := hmac.New(sha256.New, []byte("webhook-secret"))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.Header.Get("X-Eagle-Signature"))
+		assert.Contains(t, string(body), `"payload":{"accountNumber":"01234567"}`)
+	})
+
+	t.Run("non 2xx responses are failures", func(t *testing.T) {
+		status = netHTTP.StatusServiceUnavailable
+		assert.Error(t, publisher.Publish(testEvent))
+	})
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	published := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		conn.Write([]byte(`INFO {"server_id":"test"}` + "\r\n"))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "PUB "):
+				payload, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				published <- strings.TrimSpace(line) + "|" + strings.TrimSpace(payload)
+			case strings.HasPrefix(line, "PING"):
+				conn.Write([]byte("PONG\r\n"))
+			}
+		}
+	}()
+
+	publisher, err := event.NewNATSPublisher("nats://"+listener.Addr().String(), "eagle-bank", time.Second)
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish(testEvent))
+	message := <-published
+	assert.True(t, strings.HasPrefix(message, "PUB eagle-bank.account.opened "), message)
+	assert.Contains(t, message, `"id":"`+testEvent.ID+`"`)
+}
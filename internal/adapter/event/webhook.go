@@ -0,0 +1,64 @@
+package event
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+/**
+ * WebhookPublisher implements port.Publisher interface by POSTing each
+ * event as JSON. Receivers should deduplicate on the X-Eagle-Event-Id header
+ * as an event may be delivered more than once.
+ */
+
+type WebhookPublisher struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookPublisher creates a new webhook publisher instance
+func NewWebhookPublisher(url string, secret string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *WebhookPublisher) Publish(event model.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to build webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Eagle-Event-Id", event.ID)
+	req.Header.Set("X-Eagle-Event-Type", event.Type)
+	if p.secret != "" {
+		mac := hmac.New(sha256.New, []byte(p.secret))
+		mac.Write(body)
+		req.Header.Set("X-Eagle-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to deliver webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
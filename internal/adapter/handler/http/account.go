@@ -49,15 +49,23 @@ func (h *AccountHandler) CreateAccount(c *gin.Context) {
 		return
 	}
 
-	//TODO: use the userService to validate the state of the User Account: e.g. Status >= Active
+	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	if !user.IsActive() {
+		abortWithError(c, model.ErrUserNotActive)
+		return
+	}
 
-	account, err := h.accountService.CreateAccount(&model.NewAccount{
+	account, err := h.accountService.CreateAccount(c.Request.Context(), model.UserActor(userID, requestID(c)), &model.NewAccount{
 		UserID: userID,
 		Name:   req.Name,
 		Type:   req.AccountType,
 	})
 	if err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		abortWithError(c, err)
 		return
 	}
 
@@ -65,10 +73,107 @@ func (h *AccountHandler) CreateAccount(c *gin.Context) {
 	c.JSON(http.StatusCreated, account)
 }
 
+type UpdateAccountRequest struct {
+	Name        *string `json:"name"`
+	AccountType *string `json:"accountType"`
+}
+
+type ListAccountsResponse struct {
+	Accounts []model.Account `json:"accounts"`
+}
+
 func (h *AccountHandler) ListAccounts(c *gin.Context) {
-	panic("not-implemented ")
+	h.logger.Infow("ListAccounts handler started")
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	accounts, err := h.accountService.ListAccounts(c.Request.Context(), userID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusOK, ListAccountsResponse{Accounts: accounts})
 }
 
 func (h *AccountHandler) GetAccount(c *gin.Context) {
-	panic("not-implemented ")
+	h.logger.Infow("GetAccount handler started")
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	account, err := h.accountService.GetAccount(c.Request.Context(), c.Param("accountNumber"), userID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	setETag(c, account.Version)
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) UpdateAccount(c *gin.Context) {
+	h.logger.Infow("UpdateAccount handler started")
+	var req UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	account, err := h.accountService.UpdateAccount(c.Request.Context(), model.UserActor(userID, requestID(c)), &model.UpdateAccount{
+		AccountNumber: c.Param("accountNumber"),
+		UserID:        userID,
+		Name:          req.Name,
+		Type:          req.AccountType,
+		Version:       version,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	setETag(c, account.Version)
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	h.logger.Infow("DeleteAccount handler started")
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	err = h.accountService.CloseAccount(c.Request.Context(), model.UserActor(userID, requestID(c)), c.Param("accountNumber"), userID, version)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,391 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	netHTTP "net/http"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/handler/http"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountHandler_GetAccount(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+
+	testAccount := model.Account{
+		AccountNumber:    "01234567",
+		UserID:           userID,
+		SortCode:         "10-10-10",
+		Name:             "My Account",
+		AccountType:      "personal",
+		Balance:          decimal.RequireFromString("100.50"),
+		Currency:         "GBP",
+		CreatedTimestamp: testsupport.TimeNowRoundedMicroseconds().UTC(),
+		UpdatedTimestamp: testsupport.TimeNowRoundedMicroseconds().UTC(),
+		Version:          4,
+	}
+
+	validTestAccountBytes, err := json.Marshal(testAccount)
+	require.NoError(t, err)
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return userID, nil
+		},
+	}
+
+	tests := []struct {
+		desc           string
+		accountService *mocks.AccountServiceMock
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+		expectedETag       string
+	}{
+		{
+			desc: "account not found",
+			accountService: &mocks.AccountServiceMock{
+				GetAccountFunc: func(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+					return nil, model.ErrAccountNotFound
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"account not found"}`,
+		},
+		{
+			desc: "account owned by another user",
+			accountService: &mocks.AccountServiceMock{
+				GetAccountFunc: func(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+					return nil, model.ErrForbidden
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"forbidden"}`,
+		},
+		{
+			desc: "success",
+			accountService: &mocks.AccountServiceMock{
+				GetAccountFunc: func(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+					return &testAccount, nil
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   string(validTestAccountBytes),
+			expectedETag:       `"4"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		testHandler := http.NewAccountHandler(logger, authService, &mocks.UserServiceMock{}, tt.accountService)
+		c, w := testsupport.NewTestContext(nil)
+		c.Params = gin.Params{{Key: "accountNumber", Value: testAccount.AccountNumber}}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.GetAccount(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+
+			calls := tt.accountService.GetAccountCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, testAccount.AccountNumber, calls[0].AccountNumber)
+			assert.Equal(t, userID, calls[0].UserID)
+		})
+	}
+}
+
+func TestAccountHandler_CreateAccount(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+	request := http.NewAccountRequest{Name: "My Account", AccountType: "personal"}
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return userID, nil
+		},
+	}
+
+	tests := []struct {
+		desc       string
+		userStatus string
+		createErr  error
+
+		expectedHttpStatus             int
+		expectedHttpBody               string
+		expectedCreateAccountCallCount int
+	}{
+		{
+			desc:       "user has not set a password",
+			userStatus: model.UserStatusEmailVerified,
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"user must be active"}`,
+		},
+		{
+			desc:       "suspended user",
+			userStatus: model.UserStatusSuspended,
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"user must be active"}`,
+		},
+		{
+			desc:       "active user",
+			userStatus: model.UserStatusActive,
+
+			expectedHttpStatus:             netHTTP.StatusCreated,
+			expectedHttpBody:               `{"userId":"` + userID + `","accountNumber":"01234567"}`,
+			expectedCreateAccountCallCount: 1,
+		},
+		{
+			desc:       "account numbers exhausted",
+			userStatus: model.UserStatusActive,
+			createErr:  model.ErrAccountNumbersExhausted,
+
+			expectedHttpStatus:             netHTTP.StatusServiceUnavailable,
+			expectedHttpBody:               `{"error":"no account numbers left to allocate"}`,
+			expectedCreateAccountCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		userService := &mocks.UserServiceMock{
+			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
+				return &model.User{ID: id, Status: tt.userStatus}, nil
+			},
+		}
+		accountService := &mocks.AccountServiceMock{
+			CreateAccountFunc: func(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
+				if tt.createErr != nil {
+					return nil, tt.createErr
+				}
+				return &model.UserAccount{UserID: newAccount.UserID, AccountNumber: "01234567"}, nil
+			},
+		}
+		testHandler := http.NewAccountHandler(logger, authService, userService, accountService)
+		c, w := testsupport.NewTestContext(request)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.CreateAccount(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+			require.Equal(t, tt.expectedCreateAccountCallCount, len(accountService.CreateAccountCalls()))
+		})
+	}
+}
+
+func TestAccountHandler_UpdateAccount(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+	name := "Renamed Account"
+	request := http.UpdateAccountRequest{Name: &name}
+
+	updatedAccount := model.Account{
+		AccountNumber:    "01234567",
+		UserID:           userID,
+		SortCode:         "10-10-10",
+		Name:             name,
+		AccountType:      "personal",
+		Balance:          decimal.RequireFromString("100.50"),
+		Currency:         "GBP",
+		CreatedTimestamp: testsupport.TimeNowRoundedMicroseconds().UTC(),
+		UpdatedTimestamp: testsupport.TimeNowRoundedMicroseconds().UTC(),
+		Version:          5,
+	}
+
+	updatedAccountBytes, err := json.Marshal(updatedAccount)
+	require.NoError(t, err)
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return userID, nil
+		},
+	}
+
+	version := int64(4)
+
+	tests := []struct {
+		desc      string
+		ifMatch   string
+		updateErr error
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+		expectedETag       string
+		expectedVersion    *int64
+	}{
+		{
+			desc:      "account owned by another user",
+			updateErr: model.ErrForbidden,
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"forbidden"}`,
+		},
+		{
+			desc:      "closed account",
+			updateErr: model.ErrAccountNotFound,
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"account not found"}`,
+		},
+		{
+			desc:      "stale version",
+			ifMatch:   `"4"`,
+			updateErr: model.ErrVersionMismatch,
+
+			expectedHttpStatus: netHTTP.StatusPreconditionFailed,
+			expectedHttpBody:   `{"error":"resource has been modified, fetch it again before retrying"}`,
+			expectedVersion:    &version,
+		},
+		{
+			desc:    "success",
+			ifMatch: `"4"`,
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   string(updatedAccountBytes),
+			expectedETag:       `"5"`,
+			expectedVersion:    &version,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		accountService := &mocks.AccountServiceMock{
+			UpdateAccountFunc: func(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+				if tt.updateErr != nil {
+					return nil, tt.updateErr
+				}
+				return &updatedAccount, nil
+			},
+		}
+		testHandler := http.NewAccountHandler(logger, authService, &mocks.UserServiceMock{}, accountService)
+		c, w := testsupport.NewTestContext(request)
+		c.Params = gin.Params{{Key: "accountNumber", Value: updatedAccount.AccountNumber}}
+		if tt.ifMatch != "" {
+			c.Request.Header.Set("If-Match", tt.ifMatch)
+		}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.UpdateAccount(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+
+			calls := accountService.UpdateAccountCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, updatedAccount.AccountNumber, calls[0].Update.AccountNumber)
+			assert.Equal(t, userID, calls[0].Update.UserID)
+			assert.Equal(t, &name, calls[0].Update.Name)
+			assert.Equal(t, tt.expectedVersion, calls[0].Update.Version)
+		})
+	}
+}
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+	accountNumber := "01234567"
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return userID, nil
+		},
+	}
+
+	version := int64(4)
+
+	tests := []struct {
+		desc     string
+		ifMatch  string
+		closeErr error
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+		expectedVersion    *int64
+	}{
+		{
+			desc:     "account owned by another user",
+			closeErr: model.ErrForbidden,
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"forbidden"}`,
+		},
+		{
+			desc:     "account already closed",
+			closeErr: model.ErrAccountNotFound,
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"account not found"}`,
+		},
+		{
+			desc:     "account with a balance",
+			closeErr: model.ErrAccountNotEmpty,
+
+			expectedHttpStatus: netHTTP.StatusConflict,
+			expectedHttpBody:   `{"error":"account must have a zero balance to be closed"}`,
+		},
+		{
+			desc:    "success",
+			ifMatch: `"4"`,
+
+			expectedHttpStatus: netHTTP.StatusNoContent,
+			expectedVersion:    &version,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		accountService := &mocks.AccountServiceMock{
+			CloseAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string, userID string, version *int64) error {
+				return tt.closeErr
+			},
+		}
+		testHandler := http.NewAccountHandler(logger, authService, &mocks.UserServiceMock{}, accountService)
+		c, w := testsupport.NewTestContext(nil)
+		c.Params = gin.Params{{Key: "accountNumber", Value: accountNumber}}
+		if tt.ifMatch != "" {
+			c.Request.Header.Set("If-Match", tt.ifMatch)
+		}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.DeleteAccount(c)
+			// the recorder keeps gin's default 200 when a handler only calls c.Status
+			c.Writer.WriteHeaderNow()
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			if tt.expectedHttpBody == "" {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+			}
+
+			calls := accountService.CloseAccountCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, accountNumber, calls[0].AccountNumber)
+			assert.Equal(t, userID, calls[0].UserID)
+			assert.Equal(t, tt.expectedVersion, calls[0].Version)
+		})
+	}
+}
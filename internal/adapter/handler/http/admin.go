@@ -0,0 +1,300 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func NewAdminHandler(
+	logger *zap.SugaredLogger,
+	authService port.AuthService,
+	userService port.UserService,
+	adminService port.AdminService,
+) AdminHandler {
+	return AdminHandler{
+		logger:       logger,
+		authService:  authService,
+		userService:  userService,
+		adminService: adminService,
+	}
+}
+
+// AdminHandler serves the operations endpoints used by bank staff
+type AdminHandler struct {
+	logger       *zap.SugaredLogger
+	authService  port.AuthService
+	userService  port.UserService
+	adminService port.AdminService
+}
+
+type AdminLoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Login issues an admin access token. Admin tokens cannot be refreshed.
+func (h *AdminHandler) Login(c *gin.Context) {
+	h.logger.Infow("AdminLogin handler started")
+	var request AdminLoginRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid request",
+		})
+		return
+	}
+	admin, err := h.adminService.Login(c.Request.Context(), request.Email, request.Password, c.ClientIP())
+	if errors.Is(err, model.ErrInvalidCredentials) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorised"})
+		return
+	}
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	token, err := h.authService.GenerateAdminToken(admin.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Login successful",
+		"accessToken": token.Token,
+		"expires":     token.ExpiresAt.Unix(),
+	})
+}
+
+type SearchUsersRequest struct {
+	Query  string `form:"q"`
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+type SearchUsersResponse struct {
+	Users []model.User `json:"users"`
+}
+
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	h.logger.Infow("SearchUsers handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var request SearchUsersRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid request",
+		})
+		return
+	}
+
+	users, err := h.adminService.SearchUsers(c.Request.Context(), model.AdminActor(adminID, requestID(c)), model.UserSearch{
+		Query:  request.Query,
+		Status: request.Status,
+		Limit:  request.Limit,
+		Offset: request.Offset,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, SearchUsersResponse{Users: users})
+}
+
+// AdminAccount shows staff the account status, which customers do not see.
+type AdminAccount struct {
+	model.Account
+	Status string `json:"status"`
+}
+
+type AdminListAccountsResponse struct {
+	Accounts []AdminAccount `json:"accounts"`
+}
+
+func (h *AdminHandler) ListUserAccounts(c *gin.Context) {
+	h.logger.Infow("AdminListUserAccounts handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	accounts, err := h.adminService.ListUserAccounts(c.Request.Context(), model.AdminActor(adminID, requestID(c)), c.Param("userId"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	response := AdminListAccountsResponse{Accounts: make([]AdminAccount, 0, len(accounts))}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, AdminAccount{Account: account, Status: account.Status})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AdminHandler) ListAccountTransactions(c *gin.Context) {
+	h.logger.Infow("AdminListAccountTransactions handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	transactions, err := h.adminService.ListAccountTransactions(c.Request.Context(), model.AdminActor(adminID, requestID(c)), c.Param("accountNumber"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ListTransactionsResponse{Transactions: transactions})
+}
+
+func (h *AdminHandler) FreezeAccount(c *gin.Context) {
+	h.logger.Infow("FreezeAccount handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.adminService.FreezeAccount(c.Request.Context(), model.AdminActor(adminID, requestID(c)), c.Param("accountNumber")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account frozen"})
+}
+
+func (h *AdminHandler) UnfreezeAccount(c *gin.Context) {
+	h.logger.Infow("UnfreezeAccount handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.adminService.UnfreezeAccount(c.Request.Context(), model.AdminActor(adminID, requestID(c)), c.Param("accountNumber")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account unfrozen"})
+}
+
+func (h *AdminHandler) ForcePasswordReset(c *gin.Context) {
+	h.logger.Infow("ForcePasswordReset handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID := c.Param("userId")
+	resetErr := h.adminService.ForcePasswordReset(c.Request.Context(), model.AdminActor(adminID, requestID(c)), userID)
+	// the reset is committed even when its email fails, so the sessions are
+	// still revoked and the caller told what is left to do
+	if resetErr != nil && !errors.Is(resetErr, model.ErrResetEmailNotSent) {
+		abortWithError(c, resetErr)
+		return
+	}
+	// the reset is forced because the password may be known to someone else
+	if err := h.authService.RevokeUserSessions(detachedContext(c), userID); err != nil {
+		h.logger.Errorw("failed to revoke sessions after forcing a password reset", "userId", userID, "error", err)
+		abortWithError(c, err)
+		return
+	}
+	if resetErr != nil {
+		h.logger.Errorw("failed to send forced password reset email", "userId", userID, "error", resetErr)
+		c.JSON(http.StatusOK, gin.H{"message": "Password reset required", "warning": model.ErrResetEmailNotSent.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset required"})
+}
+
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	h.logger.Infow("SuspendUser handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID := c.Param("userId")
+	if err := h.userService.SuspendUser(c.Request.Context(), model.AdminActor(adminID, requestID(c)), userID); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	// a suspended user must not keep using sessions they already have
+	if err := h.authService.RevokeUserSessions(detachedContext(c), userID); err != nil {
+		h.logger.Errorw("failed to revoke sessions after suspension", "userId", userID, "error", err)
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User suspended"})
+}
+
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	h.logger.Infow("ReactivateUser handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.userService.ReactivateUser(c.Request.Context(), model.AdminActor(adminID, requestID(c)), c.Param("userId")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User reactivated"})
+}
+
+type ListAuditRecordsRequest struct {
+	EntityType string     `form:"entityType"`
+	EntityID   string     `form:"entityId"`
+	ActorID    string     `form:"actorId"`
+	RequestID  string     `form:"requestId"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int        `form:"limit"`
+	Offset     int        `form:"offset"`
+}
+
+type ListAuditRecordsResponse struct {
+	Records []model.AuditRecord `json:"records"`
+}
+
+func (h *AdminHandler) ListAuditRecords(c *gin.Context) {
+	h.logger.Infow("ListAuditRecords handler started")
+	adminID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var request ListAuditRecordsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{
+			"message": "Invalid request",
+		})
+		return
+	}
+
+	records, err := h.adminService.ListAuditRecords(c.Request.Context(), model.AdminActor(adminID, requestID(c)), model.AuditFilter{
+		EntityType: request.EntityType,
+		EntityID:   request.EntityID,
+		ActorID:    request.ActorID,
+		RequestID:  request.RequestID,
+		From:       request.From,
+		To:         request.To,
+		Limit:      request.Limit,
+		Offset:     request.Offset,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ListAuditRecordsResponse{Records: records})
+}
@@ -0,0 +1,361 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	netHTTP "net/http"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/handler/http"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAdminHandler_SuspendUser(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	adminID := uuid.NewString()
+	userID := uuid.NewString()
+
+	tests := []struct {
+		desc        string
+		userService *mocks.UserServiceMock
+
+		expectedHttpStatus              int
+		expectedHttpBody                string
+		expectedRevokeSessionsCallCount int
+	}{
+		{
+			desc: "user not found",
+			userService: &mocks.UserServiceMock{
+				SuspendUserFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+					return model.ErrUserNotFound
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"user not found"}`,
+		},
+		{
+			desc: "user is not active",
+			userService: &mocks.UserServiceMock{
+				SuspendUserFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+					return model.CheckUserTransition(model.UserStatusSuspended, model.UserStatusSuspended)
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusConflict,
+			expectedHttpBody:   `{"error":"cannot move from suspended to suspended: invalid user status change"}`,
+		},
+		{
+			desc: "success revokes existing sessions",
+			userService: &mocks.UserServiceMock{
+				SuspendUserFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+					return nil
+				},
+			},
+
+			expectedHttpStatus:              netHTTP.StatusOK,
+			expectedHttpBody:                `{"message":"User suspended"}`,
+			expectedRevokeSessionsCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		authService := &mocks.AuthServiceMock{
+			ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+				return adminID, nil
+			},
+			RevokeUserSessionsFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+		testHandler := http.NewAdminHandler(logger, authService, tt.userService, &mocks.AdminServiceMock{})
+		c, w := testsupport.NewTestContext(nil)
+		c.Params = gin.Params{{Key: "userId", Value: userID}}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.SuspendUser(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			suspendCalls := tt.userService.SuspendUserCalls()
+			require.Len(t, suspendCalls, 1)
+			assert.Equal(t, userID, suspendCalls[0].UserID)
+			assert.Equal(t, model.AdminActor(adminID, ""), suspendCalls[0].Actor)
+
+			calls := authService.RevokeUserSessionsCalls()
+			require.Equal(t, tt.expectedRevokeSessionsCallCount, len(calls))
+			for _, call := range calls {
+				assert.Equal(t, userID, call.UserID)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ForcePasswordReset(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	adminID := uuid.NewString()
+	userID := uuid.NewString()
+
+	tests := []struct {
+		desc         string
+		adminService *mocks.AdminServiceMock
+
+		expectedHttpStatus              int
+		expectedHttpBody                string
+		expectedRevokeSessionsCallCount int
+	}{
+		{
+			desc: "user not found",
+			adminService: &mocks.AdminServiceMock{
+				ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+					return model.ErrUserNotFound
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"user not found"}`,
+		},
+		{
+			desc: "success revokes existing sessions",
+			adminService: &mocks.AdminServiceMock{
+				ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+					return nil
+				},
+			},
+
+			expectedHttpStatus:              netHTTP.StatusOK,
+			expectedHttpBody:                `{"message":"Password reset required"}`,
+			expectedRevokeSessionsCallCount: 1,
+		},
+		{
+			desc: "failed email still revokes sessions and is reported",
+			adminService: &mocks.AdminServiceMock{
+				ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+					return errors.WithMessage(model.ErrResetEmailNotSent, "mail queue is full")
+				},
+			},
+
+			expectedHttpStatus:              netHTTP.StatusOK,
+			expectedHttpBody:                `{"message":"Password reset required","warning":"password reset email could not be sent, the user can request another link"}`,
+			expectedRevokeSessionsCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		authService := &mocks.AuthServiceMock{
+			ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+				return adminID, nil
+			},
+			RevokeUserSessionsFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+		testHandler := http.NewAdminHandler(logger, authService, &mocks.UserServiceMock{}, tt.adminService)
+		c, w := testsupport.NewTestContext(nil)
+		c.Params = gin.Params{{Key: "userId", Value: userID}}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.ForcePasswordReset(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			calls := authService.RevokeUserSessionsCalls()
+			require.Equal(t, tt.expectedRevokeSessionsCallCount, len(calls))
+			for _, call := range calls {
+				assert.Equal(t, userID, call.UserID)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_Login(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	adminID := uuid.NewString()
+
+	tests := []struct {
+		desc         string
+		body         interface{}
+		adminService *mocks.AdminServiceMock
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+	}{
+		{
+			desc:         "missing password",
+			body:         gin.H{"email": "ops@eagle-bank.com"},
+			adminService: &mocks.AdminServiceMock{},
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"message":"Invalid request"}`,
+		},
+		{
+			desc: "wrong password",
+			body: http.AdminLoginRequest{Email: "ops@eagle-bank.com", Password: "wrong"},
+			adminService: &mocks.AdminServiceMock{
+				LoginFunc: func(ctx context.Context, email string, password string, ip string) (*model.Admin, error) {
+					return nil, model.ErrInvalidCredentials
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusUnauthorized,
+			expectedHttpBody:   `{"error":"unauthorised"}`,
+		},
+		{
+			desc: "success issues an admin token",
+			body: http.AdminLoginRequest{Email: "ops@eagle-bank.com", Password: "Admin-passw0rd"},
+			adminService: &mocks.AdminServiceMock{
+				LoginFunc: func(ctx context.Context, email string, password string, ip string) (*model.Admin, error) {
+					return &model.Admin{ID: adminID, Email: email}, nil
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   `{"message":"Login successful","accessToken":"admin-token","expires":1700000000}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		authService := &mocks.AuthServiceMock{
+			GenerateAdminTokenFunc: func(id string) (*model.AccessToken, error) {
+				return &model.AccessToken{Token: "admin-token", ExpiresAt: time.Unix(1700000000, 0)}, nil
+			},
+		}
+		testHandler := http.NewAdminHandler(logger, authService, &mocks.UserServiceMock{}, tt.adminService)
+		c, w := testsupport.NewTestContext(tt.body)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.Login(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			calls := authService.GenerateAdminTokenCalls()
+			if tt.expectedHttpStatus == netHTTP.StatusOK {
+				require.Len(t, calls, 1)
+				assert.Equal(t, adminID, calls[0].AdminID)
+			} else {
+				assert.Empty(t, calls)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_FreezeAccount(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	adminID := uuid.NewString()
+	accountNumber := "01234567"
+
+	tests := []struct {
+		desc         string
+		adminService *mocks.AdminServiceMock
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+	}{
+		{
+			desc: "account not found",
+			adminService: &mocks.AdminServiceMock{
+				FreezeAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string) error {
+					return model.ErrAccountNotFound
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"account not found"}`,
+		},
+		{
+			desc: "account already frozen",
+			adminService: &mocks.AdminServiceMock{
+				FreezeAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string) error {
+					return model.ErrInvalidAccountTransition
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusConflict,
+			expectedHttpBody:   `{"error":"account status change is not allowed"}`,
+		},
+		{
+			desc: "success",
+			adminService: &mocks.AdminServiceMock{
+				FreezeAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string) error {
+					return nil
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   `{"message":"Account frozen"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		authService := &mocks.AuthServiceMock{
+			ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+				return adminID, nil
+			},
+		}
+		testHandler := http.NewAdminHandler(logger, authService, &mocks.UserServiceMock{}, tt.adminService)
+		c, w := testsupport.NewTestContext(nil)
+		c.Params = gin.Params{{Key: "accountNumber", Value: accountNumber}}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.FreezeAccount(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			calls := tt.adminService.FreezeAccountCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, accountNumber, calls[0].AccountNumber)
+			assert.Equal(t, model.AdminActor(adminID, ""), calls[0].Actor)
+		})
+	}
+}
+
+func TestAdminHandler_ListUserAccounts(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return uuid.NewString(), nil
+		},
+	}
+	adminService := &mocks.AdminServiceMock{
+		ListUserAccountsFunc: func(ctx context.Context, actor model.Actor, userID string) ([]model.Account, error) {
+			return []model.Account{{AccountNumber: "01234567", Status: model.AccountFrozenStatus}}, nil
+		},
+	}
+	testHandler := http.NewAdminHandler(logger, authService, &mocks.UserServiceMock{}, adminService)
+	c, w := testsupport.NewTestContext(nil)
+	c.Params = gin.Params{{Key: "userId", Value: uuid.NewString()}}
+
+	testHandler.ListUserAccounts(c)
+	require.Equal(t, netHTTP.StatusOK, w.Code)
+
+	var response struct {
+		Accounts []map[string]interface{} `json:"accounts"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Accounts, 1)
+	assert.Equal(t, "01234567", response.Accounts[0]["accountNumber"])
+	assert.Equal(t, "frozen", response.Accounts[0]["status"], "staff see the account status")
+}
@@ -0,0 +1,8 @@
+package http
+
+type Config struct {
+	// TrustedProxies are the addresses or CIDR ranges of the proxies whose
+	// X-Forwarded-For header is believed. With none, the client IP that
+	// logins are throttled on is always the address of the connecting peer.
+	TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES"`
+}
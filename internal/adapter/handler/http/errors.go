@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// errorStatus maps domain errors onto the HTTP status codes in openapi.yaml
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrUserNotFound),
+		errors.Is(err, model.ErrAdminNotFound),
+		errors.Is(err, model.ErrVerificationTokenNotFound),
+		errors.Is(err, model.ErrAccountNotFound),
+		errors.Is(err, model.ErrTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidToken),
+		errors.Is(err, model.ErrRefreshTokenReused):
+		return http.StatusUnauthorized
+	case errors.Is(err, model.ErrForbidden),
+		errors.Is(err, model.ErrUserSuspended),
+		errors.Is(err, model.ErrUserNotActive),
+		errors.Is(err, model.ErrPasswordResetRequired):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrUserHasAccounts),
+		errors.Is(err, model.ErrAccountNotEmpty),
+		errors.Is(err, model.ErrAccountFrozen),
+		errors.Is(err, model.ErrInvalidAccountTransition),
+		errors.Is(err, model.ErrVerificationTokenUsed),
+		errors.Is(err, model.ErrTOTPNotEnrolled),
+		errors.Is(err, model.ErrTOTPAlreadyEnabled),
+		errors.Is(err, model.ErrInvalidUserTransition):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, model.ErrVerificationTokenExpired):
+		return http.StatusGone
+	case errors.Is(err, model.ErrVerificationResendTooSoon),
+		errors.Is(err, model.ErrPasswordResetTooSoon),
+		errors.Is(err, model.ErrLoginThrottled):
+		return http.StatusTooManyRequests
+	case errors.Is(err, model.ErrUserLocked):
+		return http.StatusLocked
+	case errors.Is(err, model.ErrInsufficientFunds):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, model.ErrInvalidUser),
+		errors.Is(err, model.ErrInvalidTransaction),
+		errors.Is(err, model.ErrInvalidTransfer),
+		errors.Is(err, model.ErrInvalidAccount),
+		errors.Is(err, model.ErrInvalidPassword),
+		errors.Is(err, model.ErrIncorrectPassword),
+		errors.Is(err, model.ErrPasswordReused),
+		errors.Is(err, model.ErrInvalidTOTPCode),
+		errors.Is(err, model.ErrInvalidAuditFilter),
+		errors.Is(err, model.ErrInvalidPasswordResetToken):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, model.ErrAccountNumbersExhausted):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// abortWithError writes an error response for err, hiding the detail of
+// unexpected errors from the caller.
+func abortWithError(c *gin.Context, err error) {
+	status := errorStatus(err)
+	// the driver does not always say that a query was cut short by the deadline
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "request timed out"})
+		return
+	}
+	if status == http.StatusInternalServerError {
+		c.AbortWithStatusJSON(status, gin.H{"error": "internal server error"})
+		return
+	}
+	var retryErr *model.RetryAfterError
+	if errors.As(err, &retryErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+	}
+	var policyErr *model.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		c.AbortWithStatusJSON(status, gin.H{"error": model.ErrInvalidPassword.Error(), "violations": policyErr.Violations})
+		return
+	}
+	c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	etagHeader    = "ETag"
+	ifMatchHeader = "If-Match"
+)
+
+// setETag tags the response with the version of the resource it carries
+func setETag(c *gin.Context, version int64) {
+	c.Header(etagHeader, `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// ifMatchVersion returns the version named by the If-Match header, or nil when
+// the caller did not make the request conditional. Only a single strong ETag
+// previously returned by setETag can match, anything else fails the
+// precondition.
+func ifMatchVersion(c *gin.Context) (*int64, error) {
+	value := strings.TrimSpace(c.GetHeader(ifMatchHeader))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if ok {
+		unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	}
+	if !ok {
+		return nil, model.ErrVersionMismatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, model.ErrVersionMismatch
+	}
+	return &version, nil
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"eagle-bank.com/internal/core/port"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func NewKeyHandler(
+	logger *zap.SugaredLogger,
+	authService port.AuthService,
+) KeyHandler {
+	return KeyHandler{
+		logger:      logger,
+		authService: authService,
+	}
+}
+
+// KeyHandler publishes the public keys other services use to verify our tokens
+type KeyHandler struct {
+	logger      *zap.SugaredLogger
+	authService port.AuthService
+}
+
+func (h *KeyHandler) JWKS(c *gin.Context) {
+	h.logger.Infow("JWKS handler started")
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"net/http"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func NewMFAHandler(
+	logger *zap.SugaredLogger,
+	authService port.AuthService,
+	mfaService port.MFAService,
+) MFAHandler {
+	return MFAHandler{
+		logger:      logger,
+		authService: authService,
+		mfaService:  mfaService,
+	}
+}
+
+// MFAHandler enrols users in TOTP two-factor authentication and completes
+// logins that need a second factor
+type MFAHandler struct {
+	logger      *zap.SugaredLogger
+	authService port.AuthService
+	mfaService  port.MFAService
+}
+
+type ActivateTOTPRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type VerifyLoginRequest struct {
+	Code         string `json:"code" binding:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recoveryCode" binding:"required_without=Code"`
+}
+
+func (h *MFAHandler) EnrolTOTP(c *gin.Context) {
+	h.logger.Infow("EnrolTOTP handler started")
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	enrolment, err := h.mfaService.EnrolTOTP(c.Request.Context(), userID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, enrolment)
+}
+
+func (h *MFAHandler) ActivateTOTP(c *gin.Context) {
+	h.logger.Infow("ActivateTOTP handler started")
+	var req ActivateTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	recoveryCodes, err := h.mfaService.ActivateTOTP(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Two-factor authentication enabled",
+		"recoveryCodes": recoveryCodes,
+	})
+}
+
+// VerifyLogin exchanges the token issued by Login, and a TOTP or recovery
+// code, for a full token pair.
+func (h *MFAHandler) VerifyLogin(c *gin.Context) {
+	h.logger.Infow("VerifyLogin handler started")
+	var req VerifyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.mfaService.VerifySecondFactor(c.Request.Context(), userID, req.Code, req.RecoveryCode); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	tokens, err := h.authService.GenerateTokens(c.Request.Context(), userID, model.CustomerScopes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Login successful",
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"expires":      tokens.AccessExpiry.Unix(),
+	})
+}
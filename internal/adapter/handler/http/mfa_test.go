@@ -0,0 +1,104 @@
+package http_test
+
+import (
+	"context"
+	netHTTP "net/http"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/handler/http"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMFAHandler_VerifyLogin(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+
+	tests := []struct {
+		desc       string
+		mfaService *mocks.MFAServiceMock
+		request    *http.VerifyLoginRequest
+
+		expectedHttpStatus              int
+		expectedHttpBody                string
+		expectedGenerateTokensCallCount int
+	}{
+		{
+			desc:       "neither code nor recovery code",
+			mfaService: &mocks.MFAServiceMock{},
+			request:    &http.VerifyLoginRequest{},
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid request"}`,
+		},
+		{
+			desc:       "code is not six digits",
+			mfaService: &mocks.MFAServiceMock{},
+			request:    &http.VerifyLoginRequest{Code: "12345a"},
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid request"}`,
+		},
+		{
+			desc: "wrong or reused code",
+			mfaService: &mocks.MFAServiceMock{
+				VerifySecondFactorFunc: func(ctx context.Context, userID string, code string, recoveryCode string) error {
+					return model.ErrInvalidTOTPCode
+				},
+			},
+			request: &http.VerifyLoginRequest{Code: "123456"},
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid verification code"}`,
+		},
+		{
+			desc: "success with a recovery code",
+			mfaService: &mocks.MFAServiceMock{
+				VerifySecondFactorFunc: func(ctx context.Context, userID string, code string, recoveryCode string) error {
+					return nil
+				},
+			},
+			request: &http.VerifyLoginRequest{RecoveryCode: "abcd-efgh-ijkl-mnop"},
+
+			expectedHttpStatus:              netHTTP.StatusOK,
+			expectedHttpBody:                `{"message":"Login successful","accessToken":"access","refreshToken":"refresh","expires":1700000000}`,
+			expectedGenerateTokensCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		authService := &mocks.AuthServiceMock{
+			ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+				return userID, nil
+			},
+			GenerateTokensFunc: func(ctx context.Context, userID string, roles []string) (*model.TokenPair, error) {
+				return &model.TokenPair{AccessToken: "access", RefreshToken: "refresh", AccessExpiry: time.Unix(1700000000, 0)}, nil
+			},
+		}
+		testHandler := http.NewMFAHandler(logger, authService, tt.mfaService)
+		c, w := testsupport.NewTestContext(tt.request)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.VerifyLogin(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			calls := authService.GenerateTokensCalls()
+			require.Equal(t, tt.expectedGenerateTokensCallCount, len(calls))
+			for _, call := range calls {
+				assert.Equal(t, userID, call.UserID)
+				assert.Equal(t, model.CustomerScopes, call.Role)
+			}
+		})
+	}
+}
@@ -1,12 +1,75 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"net/http"
+	"slices"
+	"time"
 
+	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+	// maxRequestIDLength matches the request_id column of the audit log
+	maxRequestIDLength = 64
+)
+
+// RequestIDMiddleware tags each request with an ID, taken from the
+// X-Request-ID header when the caller supplies a usable one, so that changes
+// in the audit log can be traced back to the request that made them.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength || !isPrintableASCII(id) {
+			id = uuid.NewString()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+func isPrintableASCII(s string) bool {
+	for _, r := range s {
+		if r < ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// requestID returns the ID given to the request by RequestIDMiddleware
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// TimeoutMiddleware cancels the request context after timeout, so that
+// database calls made for a slow request are abandoned.
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// detachedContext keeps the request's values but not its deadline or
+// cancellation, for work that must finish once a change has been committed.
+func detachedContext(c *gin.Context) context.Context {
+	return context.WithoutCancel(c.Request.Context())
+}
+
 func AuthMiddleware(s port.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := s.ValidateToken(c)
@@ -27,3 +90,198 @@ func AuthMiddleware(s port.AuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireScopes rejects requests whose token does not grant every one of the
+// given scopes. It must run after AuthMiddleware.
+func RequireScopes(s port.AuthService, scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, err := hasScopes(s, c, scopes...)
+		if err != nil {
+			c.String(http.StatusUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+		if !granted {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireActiveAdmin rejects admin tokens whose admin has since been disabled,
+// as the token itself stays valid until it expires. It must run after
+// AuthMiddleware.
+func RequireActiveAdmin(s port.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_, err := s.GetAdmin(c.Request.Context(), c.GetString("user_id"))
+		if errors.Is(err, model.ErrAdminNotFound) {
+			c.String(http.StatusUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+func hasScopes(s port.AuthService, c *gin.Context, scopes ...string) (bool, error) {
+	granted, err := s.ExtractScopes(c)
+	if err != nil {
+		return false, err
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotency-Replayed"
+	maxIdempotencyKeyLength   = 255
+)
+
+// replayedHeaders are the response headers stored and replayed with the body
+var replayedHeaders = []string{"Location", "ETag"}
+
+// IdempotencyMiddleware replays the stored response when a mutating request is
+// retried with the same Idempotency-Key header, so that a client retrying after
+// a timeout does not create duplicates. Keys are scoped to the authenticated
+// user when AuthMiddleware has run before it, and otherwise to the client IP.
+// It belongs after any scope checks, so that a refusal is not replayed once
+// the caller has been granted the scope.
+func IdempotencyMiddleware(repo port.IdempotencyRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be no more than 255 characters"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := &model.IdempotencyRecord{
+			Scope:       idempotencyScope(c),
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: requestFingerprint(c.Request.Method, c.Request.URL.Path, body),
+		}
+
+		stored, created, err := repo.Reserve(c.Request.Context(), record)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if !created {
+			switch {
+			case stored.RequestHash != record.RequestHash:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key has already been used with a different request"})
+			case !stored.Completed():
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is still being processed"})
+			default:
+				for name, value := range stored.Headers {
+					c.Header(name, value)
+				}
+				c.Header(idempotencyReplayedHeader, "true")
+				c.Data(stored.StatusCode, stored.ContentType, stored.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		// the stored record identifies this reservation to Complete and Release
+		record = stored
+
+		// the outcome is stored even if the request timed out or the client left
+		storeCtx := detachedContext(c)
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// release the key if the handler panics so that the client can retry
+		defer func() {
+			if p := recover(); p != nil {
+				_ = repo.Release(storeCtx, record)
+				panic(p)
+			}
+		}()
+
+		c.Next()
+
+		// server errors and refusals for want of a scope are not remembered,
+		// the request may succeed on retry
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusUnauthorized || status == http.StatusForbidden {
+			_ = repo.Release(storeCtx, record)
+			return
+		}
+
+		record.StatusCode = status
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.Headers = map[string]string{}
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				record.Headers[name] = value
+			}
+		}
+		record.ResponseBody = recorder.body.Bytes()
+		_ = repo.Complete(storeCtx, record)
+	}
+}
+
+// idempotencyScope keeps callers from replaying each other's responses.
+// Unauthenticated callers have no user ID, so are told apart by IP.
+func idempotencyScope(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return "anonymous:" + c.ClientIP()
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint identifies a request so that reuse of a key with a
+// different request can be detected.
+func requestFingerprint(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,264 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	netHTTP "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/handler/http"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	records := map[string]*model.IdempotencyRecord{}
+	repo := &mocks.IdempotencyRepositoryMock{
+		ReserveFunc: func(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error) {
+			if stored, ok := records[record.Key]; ok {
+				return stored, false, nil
+			}
+			stored := *record
+			records[record.Key] = &stored
+			return &stored, true, nil
+		},
+		CompleteFunc: func(ctx context.Context, record *model.IdempotencyRecord) error {
+			stored := *record
+			records[record.Key] = &stored
+			return nil
+		},
+		ReleaseFunc: func(ctx context.Context, record *model.IdempotencyRecord) error {
+			delete(records, record.Key)
+			return nil
+		},
+	}
+
+	handlerCalls := 0
+	router := gin.New()
+	router.POST("/accounts", http.IdempotencyMiddleware(repo), func(c *gin.Context) {
+		handlerCalls++
+		c.Header("Location", "/v1/accounts/01000001")
+		c.Header("ETag", `"1"`)
+		c.JSON(netHTTP.StatusCreated, gin.H{"call": handlerCalls})
+	})
+	router.POST("/forbidden", http.IdempotencyMiddleware(repo), func(c *gin.Context) {
+		handlerCalls++
+		c.JSON(netHTTP.StatusForbidden, gin.H{"error": "forbidden"})
+	})
+	router.POST("/failing", http.IdempotencyMiddleware(repo), func(c *gin.Context) {
+		handlerCalls++
+		c.JSON(netHTTP.StatusInternalServerError, gin.H{"error": "internal server error"})
+	})
+
+	send := func(path string, key string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(netHTTP.MethodPost, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("request without a key is not deduplicated", func(t *testing.T) {
+		handlerCalls = 0
+		send("/accounts", "", `{"name":"a"}`)
+		send("/accounts", "", `{"name":"a"}`)
+		assert.Equal(t, 2, handlerCalls)
+		assert.Empty(t, repo.ReserveCalls())
+	})
+
+	t.Run("retry replays the stored response", func(t *testing.T) {
+		handlerCalls = 0
+		first := send("/accounts", "key-1", `{"name":"a"}`)
+		require.Equal(t, netHTTP.StatusCreated, first.Code)
+
+		retry := send("/accounts", "key-1", `{"name":"a"}`)
+		assert.Equal(t, netHTTP.StatusCreated, retry.Code)
+		assert.JSONEq(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get("Idempotency-Replayed"))
+		assert.Equal(t, "/v1/accounts/01000001", retry.Header().Get("Location"))
+		assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+		assert.Equal(t, 1, handlerCalls)
+	})
+
+	t.Run("unauthenticated callers are scoped by client IP", func(t *testing.T) {
+		calls := repo.ReserveCalls()
+		require.NotEmpty(t, calls)
+		assert.Equal(t, "anonymous:192.0.2.1", calls[len(calls)-1].Record.Scope)
+	})
+
+	t.Run("reuse with a different body is rejected", func(t *testing.T) {
+		handlerCalls = 0
+		w := send("/accounts", "key-1", `{"name":"b"}`)
+		assert.Equal(t, netHTTP.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 0, handlerCalls)
+	})
+
+	t.Run("request still in flight is rejected", func(t *testing.T) {
+		// the fingerprint covers method, path and body, so key-1's request matches
+		records["key-2"] = &model.IdempotencyRecord{Key: "key-2", RequestHash: records["key-1"].RequestHash}
+		handlerCalls = 0
+		w := send("/accounts", "key-2", `{"name":"a"}`)
+		assert.Equal(t, netHTTP.StatusConflict, w.Code)
+		assert.Equal(t, 0, handlerCalls)
+	})
+
+	t.Run("server errors release the key", func(t *testing.T) {
+		handlerCalls = 0
+		send("/failing", "key-3", `{}`)
+		send("/failing", "key-3", `{}`)
+		assert.Equal(t, 2, handlerCalls)
+		assert.NotContains(t, records, "key-3")
+	})
+
+	t.Run("refusals for want of a scope release the key", func(t *testing.T) {
+		handlerCalls = 0
+		send("/forbidden", "key-4", `{}`)
+		send("/forbidden", "key-4", `{}`)
+		assert.Equal(t, 2, handlerCalls)
+		assert.NotContains(t, records, "key-4")
+	})
+}
+
+func TestRequireScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := &mocks.AuthServiceMock{
+		ExtractScopesFunc: func(c *gin.Context) ([]string, error) {
+			return []string{model.ScopeSetPassword}, nil
+		},
+	}
+	router := gin.New()
+	router.POST("/accounts", http.RequireScopes(authService, model.ScopeCreateAccount), func(c *gin.Context) {
+		c.Status(netHTTP.StatusCreated)
+	})
+	router.POST("/set-password", http.RequireScopes(authService, model.ScopeSetPassword), func(c *gin.Context) {
+		c.Status(netHTTP.StatusOK)
+	})
+
+	t.Run("set-password token cannot create an account", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(netHTTP.MethodPost, "/accounts", nil))
+		assert.Equal(t, netHTTP.StatusForbidden, w.Code)
+	})
+
+	t.Run("granted scope is allowed through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(netHTTP.MethodPost, "/set-password", nil))
+		assert.Equal(t, netHTTP.StatusOK, w.Code)
+	})
+}
+
+func TestRequireActiveAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	activeID, disabledID := uuid.NewString(), uuid.NewString()
+	adminService := &mocks.AdminServiceMock{
+		GetAdminFunc: func(ctx context.Context, adminID string) (*model.Admin, error) {
+			if adminID == disabledID {
+				return nil, model.ErrAdminNotFound
+			}
+			return &model.Admin{ID: adminID}, nil
+		},
+	}
+	newRouter := func(adminID string) *gin.Engine {
+		router := gin.New()
+		router.GET("/admin/audit", func(c *gin.Context) {
+			c.Set("user_id", adminID)
+		}, http.RequireActiveAdmin(adminService), func(c *gin.Context) {
+			c.Status(netHTTP.StatusOK)
+		})
+		return router
+	}
+
+	t.Run("disabled admin's token is rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(disabledID).ServeHTTP(w, httptest.NewRequest(netHTTP.MethodGet, "/admin/audit", nil))
+		assert.Equal(t, netHTTP.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("active admin is allowed through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter(activeID).ServeHTTP(w, httptest.NewRequest(netHTTP.MethodGet, "/admin/audit", nil))
+		assert.Equal(t, netHTTP.StatusOK, w.Code)
+	})
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(http.RequestIDMiddleware())
+	router.GET("/ping", func(c *gin.Context) {
+		c.Status(netHTTP.StatusNoContent)
+	})
+
+	send := func(requestID string) string {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(netHTTP.MethodGet, "/ping", nil)
+		if requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+		router.ServeHTTP(w, req)
+		return w.Header().Get("X-Request-ID")
+	}
+
+	t.Run("caller's ID is kept", func(t *testing.T) {
+		assert.Equal(t, "req-1234", send("req-1234"))
+	})
+
+	t.Run("missing ID is generated", func(t *testing.T) {
+		first, second := send(""), send("")
+		assert.NotEmpty(t, first)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("unusable ID is replaced", func(t *testing.T) {
+		tooLong := strings.Repeat("a", 65)
+		assert.NotEqual(t, tooLong, send(tooLong))
+		assert.NotEqual(t, "bad\tid", send("bad\tid"))
+	})
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	logger := zaptest.NewLogger(t).Sugar()
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return "user-123", nil
+		},
+	}
+	// a slow query gives up once the request's deadline passes
+	accountService := &mocks.AccountServiceMock{
+		GetAccountFunc: func(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+			<-ctx.Done()
+			return nil, errors.Wrap(ctx.Err(), "failed to execute query")
+		},
+	}
+	handler := http.NewAccountHandler(logger, authService, &mocks.UserServiceMock{}, accountService)
+
+	router := gin.New()
+	router.GET("/accounts/:accountNumber", http.TimeoutMiddleware(10*time.Millisecond), handler.GetAccount)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(netHTTP.MethodGet, "/accounts/01234567", nil))
+
+	assert.Equal(t, netHTTP.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"error":"request timed out"}`, w.Body.String())
+	require.Len(t, accountService.GetAccountCalls(), 1)
+}
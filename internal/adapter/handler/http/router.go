@@ -1,6 +1,9 @@
 package http
 
 import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"github.com/gin-gonic/gin"
 )
@@ -12,37 +15,90 @@ type Router struct {
 
 func NewRouter(
 	authService port.AuthService,
+	idempotencyRepo port.IdempotencyRepository,
+	requestTimeout time.Duration,
+	trustedProxies []string,
 	userHandler UserHandler,
 	accountHandler AccountHandler,
+	transactionHandler TransactionHandler,
+	keyHandler KeyHandler,
+	mfaHandler MFAHandler,
+	adminHandler AdminHandler,
 ) (*Router, error) {
 
 	router := gin.Default()
+	// gin believes X-Forwarded-For from anyone unless told otherwise, which
+	// would let callers pick the IP that their logins are throttled on
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	router.Use(RequestIDMiddleware(), TimeoutMiddleware(requestTimeout))
+
+	router.GET("/.well-known/jwks.json", keyHandler.JWKS)
+
+	// registered after the scope checks on each route, see IdempotencyMiddleware
+	idempotent := IdempotencyMiddleware(idempotencyRepo)
 
 	v1 := router.Group("/v1")
 	{
 		user := v1.Group("/users")
 		{
-			user.POST("/", userHandler.CreateUser)
+			user.POST("/", idempotent, userHandler.CreateUser)
 			user.POST("/verify-email", userHandler.VerifyEmail)
+			user.POST("/verify-email/resend", userHandler.ResendVerificationEmail)
 			user.POST("/login", userHandler.Login)
+			user.POST("/token/refresh", userHandler.RefreshToken)
+			user.POST("/password-reset/request", userHandler.RequestPasswordReset)
+			user.POST("/password-reset/confirm", userHandler.ConfirmPasswordReset)
 
 			authUser := user.Group("/").Use(AuthMiddleware(authService))
 			{
-				authUser.GET("/:userId", userHandler.GetUser)
-				authUser.POST("/set-password", userHandler.SetPassword)
-				authUser.PATCH("/:userId", userHandler.UpdateUser)
+				authUser.GET("/:userId", RequireScopes(authService, model.ScopeProfile), userHandler.GetUser)
+				authUser.POST("/set-password", RequireScopes(authService, model.ScopeSetPassword), idempotent, userHandler.SetPassword)
+				// not idempotent, the response carries new tokens which are not to be stored
+				authUser.POST("/change-password", RequireScopes(authService, model.ScopeProfile), userHandler.ChangePassword)
+				authUser.POST("/logout", idempotent, userHandler.Logout)
+				authUser.POST("/login/mfa", RequireScopes(authService, model.ScopeMFA), idempotent, mfaHandler.VerifyLogin)
+				authUser.POST("/mfa/totp", RequireScopes(authService, model.ScopeProfile), idempotent, mfaHandler.EnrolTOTP)
+				authUser.POST("/mfa/totp/activate", RequireScopes(authService, model.ScopeProfile), idempotent, mfaHandler.ActivateTOTP)
+				authUser.PATCH("/:userId", RequireScopes(authService, model.ScopeProfile), idempotent, userHandler.UpdateUser)
+				authUser.DELETE("/:userId", RequireScopes(authService, model.ScopeProfile), idempotent, userHandler.DeleteUser)
 			}
 		}
 		account := v1.Group("/accounts")
 		{
 			authAccount := account.Group("/").Use(AuthMiddleware(authService))
 			{
-				authAccount.POST("/", accountHandler.CreateAccount)
-				authAccount.GET("/", accountHandler.ListAccounts)
-				authAccount.GET("/:accountNumber", accountHandler.GetAccount)
+				authAccount.POST("/", RequireScopes(authService, model.ScopeCreateAccount), idempotent, accountHandler.CreateAccount)
+				authAccount.GET("/", RequireScopes(authService, model.ScopeAccounts), accountHandler.ListAccounts)
+				authAccount.GET("/:accountNumber", RequireScopes(authService, model.ScopeAccounts), accountHandler.GetAccount)
+				authAccount.PATCH("/:accountNumber", RequireScopes(authService, model.ScopeAccounts), idempotent, accountHandler.UpdateAccount)
+				authAccount.DELETE("/:accountNumber", RequireScopes(authService, model.ScopeAccounts), idempotent, accountHandler.DeleteAccount)
+				// the deposit or withdraw scope is checked by the handler once the type is known
+				authAccount.POST("/:accountNumber/transactions", RequireScopes(authService, model.ScopeAccounts), idempotent, transactionHandler.CreateTransaction)
+				authAccount.GET("/:accountNumber/transactions", RequireScopes(authService, model.ScopeAccounts), transactionHandler.ListTransactions)
+				authAccount.GET("/:accountNumber/transactions/:transactionId", RequireScopes(authService, model.ScopeAccounts), transactionHandler.GetTransaction)
+				authAccount.POST("/:accountNumber/transfers", RequireScopes(authService, model.ScopeAccounts, model.ScopeWithdraw), idempotent, transactionHandler.CreateTransfer)
 			}
 
 		}
+		admin := v1.Group("/admin")
+		{
+			admin.POST("/login", adminHandler.Login)
+
+			authAdmin := admin.Group("/").Use(AuthMiddleware(authService), RequireScopes(authService, model.ScopeAdmin), RequireActiveAdmin(adminHandler.adminService), idempotent)
+			{
+				authAdmin.GET("/users", adminHandler.SearchUsers)
+				authAdmin.GET("/users/:userId/accounts", adminHandler.ListUserAccounts)
+				authAdmin.POST("/users/:userId/suspend", adminHandler.SuspendUser)
+				authAdmin.POST("/users/:userId/reactivate", adminHandler.ReactivateUser)
+				authAdmin.POST("/users/:userId/password-reset", adminHandler.ForcePasswordReset)
+				authAdmin.GET("/accounts/:accountNumber/transactions", adminHandler.ListAccountTransactions)
+				authAdmin.POST("/accounts/:accountNumber/freeze", adminHandler.FreezeAccount)
+				authAdmin.POST("/accounts/:accountNumber/unfreeze", adminHandler.UnfreezeAccount)
+				authAdmin.GET("/audit", adminHandler.ListAuditRecords)
+			}
+		}
 	}
 	return &Router{
 		router,
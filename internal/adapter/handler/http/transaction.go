@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func NewTransactionHandler(
+	logger *zap.SugaredLogger,
+	authService port.AuthService,
+	transactionService port.TransactionService,
+) TransactionHandler {
+	return TransactionHandler{
+		logger:             logger,
+		authService:        authService,
+		transactionService: transactionService,
+	}
+}
+
+type TransactionHandler struct {
+	logger             *zap.SugaredLogger
+	authService        port.AuthService
+	transactionService port.TransactionService
+}
+
+type CreateTransactionRequest struct {
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency" binding:"required"`
+	Type      string          `json:"type" binding:"required"`
+	Reference *string         `json:"reference"`
+}
+
+type CreateTransferRequest struct {
+	SortCode      string          `json:"sortCode" binding:"required"`
+	AccountNumber string          `json:"accountNumber" binding:"required"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency" binding:"required"`
+	Reference     *string         `json:"reference"`
+}
+
+type ListTransactionsResponse struct {
+	Transactions []model.Transaction `json:"transactions"`
+}
+
+var transactionScopes = map[string]string{
+	model.TransactionDepositType:    model.ScopeDeposit,
+	model.TransactionWithdrawalType: model.ScopeWithdraw,
+}
+
+func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
+	h.logger.Infow("CreateTransaction handler started")
+	var req CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// deposits and withdrawals share a route, so the scope depends on the body
+	if scope, ok := transactionScopes[req.Type]; ok {
+		granted, err := hasScopes(h.authService, c, scope)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		if !granted {
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+			return
+		}
+	}
+
+	transaction, err := h.transactionService.CreateTransaction(c.Request.Context(), &model.NewTransaction{
+		AccountNumber: c.Param("accountNumber"),
+		UserID:        userID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Type:          req.Type,
+		Reference:     req.Reference,
+	})
+	if err != nil {
+		h.logger.Infow("CreateTransaction failed", "error", err)
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusCreated, transaction)
+}
+
+func (h *TransactionHandler) CreateTransfer(c *gin.Context) {
+	h.logger.Infow("CreateTransfer handler started")
+	var req CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	transfer, err := h.transactionService.CreateTransfer(c.Request.Context(), &model.NewTransfer{
+		FromAccountNumber: c.Param("accountNumber"),
+		ToSortCode:        req.SortCode,
+		ToAccountNumber:   req.AccountNumber,
+		UserID:            userID,
+		Amount:            req.Amount,
+		Currency:          req.Currency,
+		Reference:         req.Reference,
+	})
+	if err != nil {
+		h.logger.Infow("CreateTransfer failed", "error", err)
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusCreated, transfer)
+}
+
+func (h *TransactionHandler) ListTransactions(c *gin.Context) {
+	h.logger.Infow("ListTransactions handler started")
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	transactions, err := h.transactionService.ListTransactions(c.Request.Context(), c.Param("accountNumber"), userID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusOK, ListTransactionsResponse{Transactions: transactions})
+}
+
+func (h *TransactionHandler) GetTransaction(c *gin.Context) {
+	h.logger.Infow("GetTransaction handler started")
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	transaction, err := h.transactionService.GetTransaction(c.Request.Context(), c.Param("accountNumber"), c.Param("transactionId"), userID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusOK, transaction)
+}
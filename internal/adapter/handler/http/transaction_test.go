@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	netHTTP "net/http"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/handler/http"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionHandler_CreateTransaction(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+	accountNumber := "01234567"
+
+	testTransaction := model.Transaction{
+		ID:               "tan-123abc",
+		Amount:           decimal.RequireFromString("10.99"),
+		Currency:         "GBP",
+		Type:             model.TransactionDepositType,
+		UserID:           userID,
+		CreatedTimestamp: testsupport.TimeNowRoundedMicroseconds().UTC(),
+	}
+
+	validTestTransactionBytes, err := json.Marshal(testTransaction)
+	require.NoError(t, err)
+
+	validRequest := &http.CreateTransactionRequest{
+		Amount:   testTransaction.Amount,
+		Currency: "GBP",
+		Type:     model.TransactionDepositType,
+	}
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return userID, nil
+		},
+		// a token that may deposit but not withdraw
+		ExtractScopesFunc: func(c *gin.Context) ([]string, error) {
+			return []string{model.ScopeAccounts, model.ScopeDeposit}, nil
+		},
+	}
+
+	tests := []struct {
+		desc               string
+		transactionService *mocks.TransactionServiceMock
+		request            *http.CreateTransactionRequest
+
+		expectedHttpStatus                        int
+		expectedHttpBody                          string
+		expectedCreateTransactionServiceCallCount int
+	}{
+		{
+			desc:               "empty payload",
+			transactionService: &mocks.TransactionServiceMock{},
+			request:            nil,
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid request"}`,
+		},
+		{
+			desc:               "withdrawal without the withdraw scope",
+			transactionService: &mocks.TransactionServiceMock{},
+			request: &http.CreateTransactionRequest{
+				Amount:   testTransaction.Amount,
+				Currency: "GBP",
+				Type:     model.TransactionWithdrawalType,
+			},
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"insufficient scope"}`,
+		},
+		{
+			desc: "account not found",
+			transactionService: &mocks.TransactionServiceMock{
+				CreateTransactionFunc: func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+					return nil, model.ErrAccountNotFound
+				},
+			},
+			request: validRequest,
+
+			expectedHttpStatus:                        netHTTP.StatusNotFound,
+			expectedHttpBody:                          `{"error":"account not found"}`,
+			expectedCreateTransactionServiceCallCount: 1,
+		},
+		{
+			desc: "account owned by another user",
+			transactionService: &mocks.TransactionServiceMock{
+				CreateTransactionFunc: func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+					return nil, model.ErrForbidden
+				},
+			},
+			request: validRequest,
+
+			expectedHttpStatus:                        netHTTP.StatusForbidden,
+			expectedHttpBody:                          `{"error":"forbidden"}`,
+			expectedCreateTransactionServiceCallCount: 1,
+		},
+		{
+			desc: "insufficient funds",
+			transactionService: &mocks.TransactionServiceMock{
+				CreateTransactionFunc: func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+					return nil, model.ErrInsufficientFunds
+				},
+			},
+			request: validRequest,
+
+			expectedHttpStatus:                        netHTTP.StatusUnprocessableEntity,
+			expectedHttpBody:                          `{"error":"insufficient funds to process transaction"}`,
+			expectedCreateTransactionServiceCallCount: 1,
+		},
+		{
+			desc: "internal service error",
+			transactionService: &mocks.TransactionServiceMock{
+				CreateTransactionFunc: func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+					return nil, errors.New("test internal service error")
+				},
+			},
+			request: validRequest,
+
+			expectedHttpStatus:                        netHTTP.StatusInternalServerError,
+			expectedHttpBody:                          `{"error":"internal server error"}`,
+			expectedCreateTransactionServiceCallCount: 1,
+		},
+		{
+			desc: "success",
+			transactionService: &mocks.TransactionServiceMock{
+				CreateTransactionFunc: func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+					return &testTransaction, nil
+				},
+			},
+			request: validRequest,
+
+			expectedHttpStatus:                        netHTTP.StatusCreated,
+			expectedHttpBody:                          string(validTestTransactionBytes),
+			expectedCreateTransactionServiceCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		testHandler := http.NewTransactionHandler(logger, authService, tt.transactionService)
+		c, w := testsupport.NewTestContext(tt.request)
+		c.Params = gin.Params{{Key: "accountNumber", Value: accountNumber}}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.CreateTransaction(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			calls := tt.transactionService.CreateTransactionCalls()
+			require.Equal(t, tt.expectedCreateTransactionServiceCallCount, len(calls))
+			for _, call := range calls {
+				assert.Equal(t, accountNumber, call.NewTransaction.AccountNumber)
+				assert.Equal(t, userID, call.NewTransaction.UserID)
+			}
+		})
+	}
+}
@@ -9,17 +9,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 func NewUserHandler(
 	logger *zap.SugaredLogger,
 	authService port.AuthService,
 	userService port.UserService,
+	mfaService port.MFAService,
 ) UserHandler {
 	return UserHandler{
 		logger:      logger,
 		authService: authService,
 		userService: userService,
+		mfaService:  mfaService,
 	}
 }
 
@@ -31,6 +34,7 @@ type UserHandler struct {
 	logger      *zap.SugaredLogger
 	authService port.AuthService
 	userService port.UserService
+	mfaService  port.MFAService
 }
 
 type VerifyEmailRequest struct {
@@ -54,13 +58,40 @@ func (h *UserHandler) Login(c *gin.Context) {
 		c.IndentedJSON(http.StatusBadRequest, gin.H{
 			"message": "Invalid request",
 		})
+		return
 	}
-	user, err := h.userService.Login(request.Email, request.Password)
-	if err != nil {
+	user, err := h.userService.Login(c.Request.Context(), model.AnonymousActor(requestID(c)), request.Email, request.Password, c.ClientIP())
+	if errors.Is(err, model.ErrInvalidCredentials) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorised"})
 		return
 	}
-	tokens, err := h.authService.GenerateTokens(user.ID, []string{"create_account", "deposit", "withdraw"})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	mfaEnabled, err := h.mfaService.IsTOTPEnabled(c.Request.Context(), user.ID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	if mfaEnabled {
+		// tokens are only issued once the second factor is verified
+		challenge, err := h.authService.GenerateMFAToken(c.Request.Context(), user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"message":     "Second factor required",
+			"mfaRequired": true,
+			"mfaToken":    challenge.Token,
+			"expires":     challenge.ExpiresAt.Unix(),
+		})
+		return
+	}
+
+	tokens, err := h.authService.GenerateTokens(c.Request.Context(), user.ID, model.CustomerScopes)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -74,28 +105,82 @@ func (h *UserHandler) Login(c *gin.Context) {
 	})
 }
 
-func (h *UserHandler) GetUser(c *gin.Context) {
-	h.logger.Infow("GetUser handler started")
-	userIDParam := c.Param("userId")
-	userID, err := uuid.Parse(userIDParam)
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	h.logger.Infow("RefreshToken handler started")
+	var request RefreshTokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tokens, err := h.authService.RefreshTokens(c.Request.Context(), request.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid userId format"})
+		abortWithError(c, err)
 		return
 	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"expires":      tokens.AccessExpiry.Unix(),
+	})
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (h *UserHandler) Logout(c *gin.Context) {
+	h.logger.Infow("Logout handler started")
+	var request LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if err := h.authService.Logout(c, request.RefreshToken); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// authorisedUserID returns the userId path parameter once it has been checked
+// against the user in the caller's token, writing an error response otherwise.
+func (h *UserHandler) authorisedUserID(c *gin.Context) (string, bool) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid userId format"})
+		return "", false
+	}
 	tokenUserID, err := h.authService.ExtractTokenID(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-		return
+		return "", false
 	}
 	if tokenUserID != userID.String() {
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return "", false
+	}
+	return userID.String(), true
+}
+
+func (h *UserHandler) GetUser(c *gin.Context) {
+	h.logger.Infow("GetUser handler started")
+	userID, ok := h.authorisedUserID(c)
+	if !ok {
 		return
 	}
-	user, err := h.userService.GetUserByID(userID.String())
+	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
+	setETag(c, user.Version)
 	c.Header("Content-Type", "application/json")
 	c.JSON(http.StatusOK, user)
 }
@@ -116,23 +201,62 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		})
 		return
 	}
-	user, err := h.userService.CreateUser(&newUser)
+	user, err := h.userService.CreateUser(c.Request.Context(), model.AnonymousActor(requestID(c)), &newUser)
 	if err != nil {
 		c.IndentedJSON(http.StatusInternalServerError, gin.H{
 			"message": err.Error(),
 		})
 		return
 	}
-	// TODO: suggested flow is that after creating a new User account at Eagle Bank
-	// that eagle bank then send an email with a verification link to the email provided at CreateUser step.
-
 	c.Header("Content-Type", "application/json")
 	c.JSON(http.StatusCreated, user)
 }
 
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	h.logger.Infow("UpdateUser handler started")
-	panic("not-implemented")
+	userID, ok := h.authorisedUserID(c)
+	if !ok {
+		return
+	}
+	var update model.UpdateUser
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	update.ID = userID
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	update.Version = version
+
+	user, err := h.userService.UpdateUser(c.Request.Context(), model.UserActor(userID, requestID(c)), &update)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	setETag(c, user.Version)
+	c.Header("Content-Type", "application/json")
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	h.logger.Infow("DeleteUser handler started")
+	userID, ok := h.authorisedUserID(c)
+	if !ok {
+		return
+	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	if err := h.userService.DeleteUser(c.Request.Context(), model.UserActor(userID, requestID(c)), userID, version); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
 }
 
 func (h *UserHandler) VerifyEmail(c *gin.Context) {
@@ -144,20 +268,19 @@ func (h *UserHandler) VerifyEmail(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.GetUserByEmailVerificationToken(req.Token)
-
+	user, err := h.userService.GetUserByEmailVerificationToken(c.Request.Context(), req.Token)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email verification token"})
+		abortWithError(c, err)
 		return
 	}
 
-	err = h.userService.VerifyEmail(req.Token)
+	err = h.userService.VerifyEmail(c.Request.Context(), model.AnonymousActor(requestID(c)), req.Token)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email verification token"})
+		abortWithError(c, err)
 		return
 	}
 
-	tokens, err := h.authService.GenerateTokens(user.ID, []string{"set-password"})
+	tokens, err := h.authService.GenerateTokens(c.Request.Context(), user.ID, []string{model.ScopeSetPassword})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -171,6 +294,114 @@ func (h *UserHandler) VerifyEmail(c *gin.Context) {
 	})
 }
 
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+func (h *UserHandler) ResendVerificationEmail(c *gin.Context) {
+	h.logger.Infow("ResendVerificationEmail handler started")
+	var req ResendVerificationEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.userService.ResendVerificationEmail(c.Request.Context(), model.AnonymousActor(requestID(c)), req.Email); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If the email address is awaiting verification a new link has been sent",
+	})
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+func (h *UserHandler) RequestPasswordReset(c *gin.Context) {
+	h.logger.Infow("RequestPasswordReset handler started")
+	var req PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.userService.RequestPasswordReset(c.Request.Context(), model.AnonymousActor(requestID(c)), req.Email); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If an account exists for the email address a password reset link has been sent",
+	})
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+func (h *UserHandler) ConfirmPasswordReset(c *gin.Context) {
+	h.logger.Infow("ConfirmPasswordReset handler started")
+	var req ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), model.AnonymousActor(requestID(c)), req.Token, req.Password); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	h.logger.Infow("ChangePassword handler started")
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	userID, err := h.authService.ExtractTokenID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	scopes, err := h.authService.ExtractScopes(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.userService.ChangePassword(c.Request.Context(), model.UserActor(userID, requestID(c)), userID, req.CurrentPassword, req.NewPassword); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	// every session was revoked with the change, so the caller is given a new
+	// one in place of theirs and all others stay signed out
+	tokens, err := h.authService.GenerateTokens(detachedContext(c), userID, scopes)
+	if err != nil {
+		h.logger.Errorw("failed to issue tokens after a password change", "userId", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Password changed successfully",
+		"accessToken":  tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"expires":      tokens.AccessExpiry.Unix(),
+	})
+}
+
 func (h *UserHandler) SetPassword(c *gin.Context) {
 	h.logger.Infow("SetPassword handler started")
 	var req SetPasswordRequest
@@ -186,7 +417,7 @@ func (h *UserHandler) SetPassword(c *gin.Context) {
 	}
 
 	// Retrieve user record by ID sent in jwt token
-	user, err := h.userService.GetUserByID(userID)
+	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
@@ -203,7 +434,11 @@ func (h *UserHandler) SetPassword(c *gin.Context) {
 		return
 	}
 
-	err = h.userService.SetPassword(user, req.Password)
+	err = h.userService.SetPassword(c.Request.Context(), model.UserActor(userID, requestID(c)), user, req.Password)
+	if errors.Is(err, model.ErrInvalidPassword) || errors.Is(err, model.ErrInvalidUserTransition) {
+		abortWithError(c, err)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "set password error"})
 		return
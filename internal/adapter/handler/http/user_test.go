@@ -1,14 +1,17 @@
 package http_test
 
 import (
+	"context"
 	"encoding/json"
 	netHTTP "net/http"
 	"testing"
+	"time"
 
 	"eagle-bank.com/internal/adapter/handler/http"
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port/mocks"
 	"eagle-bank.com/internal/testsupport"
+	"github.com/gin-gonic/gin"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -154,7 +157,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 			desc:        "internal service error",
 			authService: &mocks.AuthServiceMock{},
 			userService: &mocks.UserServiceMock{
-				CreateUserFunc: func(user *model.NewUser) (*model.User, error) {
+				CreateUserFunc: func(ctx context.Context, actor model.Actor, user *model.NewUser) (*model.User, error) {
 					return nil, errors.New("test internal service error")
 				},
 			},
@@ -175,7 +178,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 			desc:        "success",
 			authService: &mocks.AuthServiceMock{},
 			userService: &mocks.UserServiceMock{
-				CreateUserFunc: func(user *model.NewUser) (*model.User, error) {
+				CreateUserFunc: func(ctx context.Context, actor model.Actor, user *model.NewUser) (*model.User, error) {
 					return &testUser, nil
 				},
 			},
@@ -196,7 +199,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 
 	for _, tt := range tests {
 		tt := tt
-		testHandler := http.NewUserHandler(logger, tt.authService, tt.userService)
+		testHandler := http.NewUserHandler(logger, tt.authService, tt.userService, &mocks.MFAServiceMock{})
 		c, w := testsupport.NewTestContext(tt.newUser)
 
 		t.Run(tt.desc, func(t *testing.T) {
@@ -210,3 +213,491 @@ func TestUserHandler_CreateUser(t *testing.T) {
 	}
 
 }
+
+func TestUserHandler_DeleteUser(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+
+	tests := []struct {
+		desc        string
+		userIDParam string
+		ifMatch     string
+		userService *mocks.UserServiceMock
+
+		expectedHttpStatus                 int
+		expectedHttpBody                   string
+		expectedDeleteUserServiceCallCount int
+	}{
+		{
+			desc:        "invalid userId",
+			userIDParam: "usr-123",
+			userService: &mocks.UserServiceMock{},
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid userId format"}`,
+		},
+		{
+			desc:        "another user",
+			userIDParam: uuid.NewString(),
+			userService: &mocks.UserServiceMock{},
+
+			expectedHttpStatus: netHTTP.StatusForbidden,
+			expectedHttpBody:   `{"error":"forbidden"}`,
+		},
+		{
+			desc:        "user still owns accounts",
+			userIDParam: userID,
+			userService: &mocks.UserServiceMock{
+				DeleteUserFunc: func(ctx context.Context, actor model.Actor, id string, version *int64) error {
+					return model.ErrUserHasAccounts
+				},
+			},
+
+			expectedHttpStatus:                 netHTTP.StatusConflict,
+			expectedHttpBody:                   `{"error":"user cannot be deleted until their bank accounts are closed"}`,
+			expectedDeleteUserServiceCallCount: 1,
+		},
+		{
+			desc:        "user changed since it was fetched",
+			userIDParam: userID,
+			ifMatch:     `"3"`,
+			userService: &mocks.UserServiceMock{
+				DeleteUserFunc: func(ctx context.Context, actor model.Actor, id string, version *int64) error {
+					if version == nil || *version != 3 {
+						return errors.New("unexpected version")
+					}
+					return model.ErrVersionMismatch
+				},
+			},
+
+			expectedHttpStatus:                 netHTTP.StatusPreconditionFailed,
+			expectedHttpBody:                   `{"error":"resource has been modified, fetch it again before retrying"}`,
+			expectedDeleteUserServiceCallCount: 1,
+		},
+		{
+			desc:        "weak If-Match",
+			userIDParam: userID,
+			ifMatch:     `W/"3"`,
+			userService: &mocks.UserServiceMock{},
+
+			expectedHttpStatus: netHTTP.StatusPreconditionFailed,
+			expectedHttpBody:   `{"error":"resource has been modified, fetch it again before retrying"}`,
+		},
+		{
+			desc:        "success",
+			userIDParam: userID,
+			userService: &mocks.UserServiceMock{
+				DeleteUserFunc: func(ctx context.Context, actor model.Actor, id string, version *int64) error {
+					return nil
+				},
+			},
+
+			expectedHttpStatus:                 netHTTP.StatusNoContent,
+			expectedDeleteUserServiceCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		authService := &mocks.AuthServiceMock{
+			ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+				return userID, nil
+			},
+		}
+		testHandler := http.NewUserHandler(logger, authService, tt.userService, &mocks.MFAServiceMock{})
+		c, w := testsupport.NewTestContext(nil)
+		c.Params = gin.Params{{Key: "userId", Value: tt.userIDParam}}
+		if tt.ifMatch != "" {
+			c.Request.Header.Set("If-Match", tt.ifMatch)
+		}
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.DeleteUser(c)
+			c.Writer.WriteHeaderNow()
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			if tt.expectedHttpBody != "" {
+				assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+			}
+
+			require.Equal(t, tt.expectedDeleteUserServiceCallCount, len(tt.userService.DeleteUserCalls()))
+		})
+	}
+}
+
+func TestUserHandler_VerifyEmail(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	request := http.VerifyEmailRequest{Token: uuid.NewString()}
+	user := &model.User{ID: uuid.NewString()}
+
+	tests := []struct {
+		desc        string
+		userService *mocks.UserServiceMock
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+	}{
+		{
+			desc: "unknown token",
+			userService: &mocks.UserServiceMock{
+				GetUserByEmailVerificationTokenFunc: func(ctx context.Context, emailToken string) (*model.User, error) {
+					return nil, model.ErrVerificationTokenNotFound
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusNotFound,
+			expectedHttpBody:   `{"error":"email verification token not found"}`,
+		},
+		{
+			desc: "expired token",
+			userService: &mocks.UserServiceMock{
+				GetUserByEmailVerificationTokenFunc: func(ctx context.Context, emailToken string) (*model.User, error) {
+					return user, nil
+				},
+				VerifyEmailFunc: func(ctx context.Context, actor model.Actor, emailToken string) error {
+					return model.ErrVerificationTokenExpired
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusGone,
+			expectedHttpBody:   `{"error":"email verification token has expired"}`,
+		},
+		{
+			desc: "used token",
+			userService: &mocks.UserServiceMock{
+				GetUserByEmailVerificationTokenFunc: func(ctx context.Context, emailToken string) (*model.User, error) {
+					return user, nil
+				},
+				VerifyEmailFunc: func(ctx context.Context, actor model.Actor, emailToken string) error {
+					return model.ErrVerificationTokenUsed
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusConflict,
+			expectedHttpBody:   `{"error":"email verification token has already been used"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		testHandler := http.NewUserHandler(logger, &mocks.AuthServiceMock{}, tt.userService, &mocks.MFAServiceMock{})
+		c, w := testsupport.NewTestContext(request)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.VerifyEmail(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_ResendVerificationEmail(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userService := &mocks.UserServiceMock{
+		ResendVerificationEmailFunc: func(ctx context.Context, actor model.Actor, email string) error {
+			return &model.RetryAfterError{Err: model.ErrVerificationResendTooSoon, RetryAfter: 42 * time.Second}
+		},
+	}
+	testHandler := http.NewUserHandler(logger, &mocks.AuthServiceMock{}, userService, &mocks.MFAServiceMock{})
+	c, w := testsupport.NewTestContext(http.ResendVerificationEmailRequest{Email: gofakeit.Email()})
+
+	testHandler.ResendVerificationEmail(c)
+	assert.Equal(t, netHTTP.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "42", w.Header().Get("Retry-After"))
+}
+
+func TestUserHandler_ConfirmPasswordReset(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	request := http.ConfirmPasswordResetRequest{Token: uuid.NewString(), Password: "new-passw0rd"}
+
+	tests := []struct {
+		desc        string
+		userService *mocks.UserServiceMock
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+	}{
+		{
+			desc: "expired or used token",
+			userService: &mocks.UserServiceMock{
+				ResetPasswordFunc: func(ctx context.Context, actor model.Actor, resetToken string, password string) error {
+					return model.ErrInvalidPasswordResetToken
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid or expired password reset token"}`,
+		},
+		{
+			desc: "success",
+			userService: &mocks.UserServiceMock{
+				ResetPasswordFunc: func(ctx context.Context, actor model.Actor, resetToken string, password string) error {
+					return nil
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   `{"message":"Password reset successfully"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		testHandler := http.NewUserHandler(logger, &mocks.AuthServiceMock{}, tt.userService, &mocks.MFAServiceMock{})
+		c, w := testsupport.NewTestContext(request)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.ConfirmPasswordReset(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	userID := uuid.NewString()
+	request := http.ChangePasswordRequest{CurrentPassword: "old-passw0rd", NewPassword: "new-passw0rd"}
+
+	authService := &mocks.AuthServiceMock{
+		ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
+			return userID, nil
+		},
+		ExtractScopesFunc: func(c *gin.Context) ([]string, error) {
+			return model.CustomerScopes, nil
+		},
+		GenerateTokensFunc: func(ctx context.Context, userID string, roles []string) (*model.TokenPair, error) {
+			return &model.TokenPair{AccessToken: "access", RefreshToken: "refresh", AccessExpiry: time.Unix(1700000000, 0)}, nil
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		userService *mocks.UserServiceMock
+		request     *http.ChangePasswordRequest
+
+		expectedHttpStatus              int
+		expectedHttpBody                string
+		expectedChangePasswordCallCount int
+	}{
+		{
+			desc:        "empty payload",
+			userService: &mocks.UserServiceMock{},
+			request:     nil,
+
+			expectedHttpStatus: netHTTP.StatusBadRequest,
+			expectedHttpBody:   `{"error":"invalid request"}`,
+		},
+		{
+			desc: "incorrect current password",
+			userService: &mocks.UserServiceMock{
+				ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+					return model.ErrIncorrectPassword
+				},
+			},
+			request: &request,
+
+			expectedHttpStatus:              netHTTP.StatusBadRequest,
+			expectedHttpBody:                `{"error":"current password is incorrect"}`,
+			expectedChangePasswordCallCount: 1,
+		},
+		{
+			desc: "throttled after repeated incorrect current passwords",
+			userService: &mocks.UserServiceMock{
+				ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+					return &model.RetryAfterError{Err: model.ErrLoginThrottled, RetryAfter: 2 * time.Second}
+				},
+			},
+			request: &request,
+
+			expectedHttpStatus:              netHTTP.StatusTooManyRequests,
+			expectedHttpBody:                `{"error":"too many failed login attempts, try again later"}`,
+			expectedChangePasswordCallCount: 1,
+		},
+		{
+			desc: "new password breaks the policy",
+			userService: &mocks.UserServiceMock{
+				ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+					return &model.PasswordPolicyError{Violations: []string{
+						"password must contain a symbol",
+						"password must not contain your name",
+					}}
+				},
+			},
+			request: &request,
+
+			expectedHttpStatus:              netHTTP.StatusBadRequest,
+			expectedHttpBody:                `{"error":"invalid password","violations":["password must contain a symbol","password must not contain your name"]}`,
+			expectedChangePasswordCallCount: 1,
+		},
+		{
+			desc: "recently used password",
+			userService: &mocks.UserServiceMock{
+				ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+					return model.ErrPasswordReused
+				},
+			},
+			request: &request,
+
+			expectedHttpStatus:              netHTTP.StatusBadRequest,
+			expectedHttpBody:                `{"error":"password must not match any of your recent passwords"}`,
+			expectedChangePasswordCallCount: 1,
+		},
+		{
+			desc: "success issues new tokens in place of the revoked ones",
+			userService: &mocks.UserServiceMock{
+				ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+					return nil
+				},
+			},
+			request: &request,
+
+			expectedHttpStatus:              netHTTP.StatusOK,
+			expectedHttpBody:                `{"message":"Password changed successfully","accessToken":"access","refreshToken":"refresh","expires":1700000000}`,
+			expectedChangePasswordCallCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		testHandler := http.NewUserHandler(logger, authService, tt.userService, &mocks.MFAServiceMock{})
+		c, w := testsupport.NewTestContext(tt.request)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.ChangePassword(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+
+			calls := tt.userService.ChangePasswordCalls()
+			require.Equal(t, tt.expectedChangePasswordCallCount, len(calls))
+			for _, call := range calls {
+				assert.Equal(t, userID, call.UserID)
+				assert.Equal(t, request.CurrentPassword, call.CurrentPassword)
+				assert.Equal(t, request.NewPassword, call.NewPassword)
+			}
+			if tt.expectedHttpStatus == netHTTP.StatusOK {
+				require.NotEmpty(t, authService.GenerateTokensCalls())
+				call := authService.GenerateTokensCalls()[len(authService.GenerateTokensCalls())-1]
+				assert.Equal(t, userID, call.UserID)
+				assert.Equal(t, model.CustomerScopes, call.Role)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Login(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	user := &model.User{ID: uuid.NewString(), Email: "jane@example.com", Status: "active"}
+	mfaUser := &model.User{ID: uuid.NewString(), Email: "john@example.com", Status: "active"}
+	request := http.LoginRequest{Email: user.Email, Password: "passw0rd"}
+
+	authService := &mocks.AuthServiceMock{
+		GenerateTokensFunc: func(ctx context.Context, userID string, roles []string) (*model.TokenPair, error) {
+			return &model.TokenPair{AccessToken: "access", RefreshToken: "refresh", AccessExpiry: time.Unix(1700000000, 0)}, nil
+		},
+		GenerateMFATokenFunc: func(ctx context.Context, userID string) (*model.MFAChallenge, error) {
+			return &model.MFAChallenge{Token: "mfa", ExpiresAt: time.Unix(1700000300, 0)}, nil
+		},
+	}
+	mfaService := &mocks.MFAServiceMock{
+		IsTOTPEnabledFunc: func(ctx context.Context, userID string) (bool, error) {
+			return userID == mfaUser.ID, nil
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		userService *mocks.UserServiceMock
+
+		expectedHttpStatus int
+		expectedHttpBody   string
+		expectedRetryAfter string
+	}{
+		{
+			desc: "wrong password",
+			userService: &mocks.UserServiceMock{
+				LoginFunc: func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
+					return nil, model.ErrInvalidCredentials
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusUnauthorized,
+			expectedHttpBody:   `{"error":"unauthorised"}`,
+		},
+		{
+			desc: "throttled after repeated failures",
+			userService: &mocks.UserServiceMock{
+				LoginFunc: func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
+					return nil, &model.RetryAfterError{Err: model.ErrLoginThrottled, RetryAfter: 3500 * time.Millisecond}
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusTooManyRequests,
+			expectedHttpBody:   `{"error":"too many failed login attempts, try again later"}`,
+			expectedRetryAfter: "4",
+		},
+		{
+			desc: "locked out",
+			userService: &mocks.UserServiceMock{
+				LoginFunc: func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
+					return nil, &model.RetryAfterError{Err: model.ErrUserLocked, RetryAfter: 10 * time.Minute}
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusLocked,
+			expectedHttpBody:   `{"error":"user is locked after too many failed login attempts"}`,
+			expectedRetryAfter: "600",
+		},
+		{
+			desc: "success",
+			userService: &mocks.UserServiceMock{
+				LoginFunc: func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
+					return user, nil
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   `{"message":"Login successful","accessToken":"access","refreshToken":"refresh","expires":1700000000}`,
+		},
+		{
+			desc: "second factor required",
+			userService: &mocks.UserServiceMock{
+				LoginFunc: func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
+					return mfaUser, nil
+				},
+			},
+
+			expectedHttpStatus: netHTTP.StatusOK,
+			expectedHttpBody:   `{"message":"Second factor required","mfaRequired":true,"mfaToken":"mfa","expires":1700000300}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		testHandler := http.NewUserHandler(logger, authService, tt.userService, mfaService)
+		c, w := testsupport.NewTestContext(request)
+
+		t.Run(tt.desc, func(t *testing.T) {
+			testHandler.Login(c)
+			assert.Equal(t, tt.expectedHttpStatus, w.Code)
+			assert.JSONEq(t, tt.expectedHttpBody, w.Body.String())
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+
+			calls := tt.userService.LoginCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, request.Email, calls[0].Email)
+			assert.Equal(t, request.Password, calls[0].Password)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package mail
+
+import (
+	"sync"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	asyncQueueSize    = 100
+	asyncSendAttempts = 3
+	asyncRetryBackoff = 2 * time.Second
+)
+
+var ErrMailQueueFull = errors.New("mail queue is full")
+
+/**
+ * AsyncMailer implements port.Mailer interface by queueing messages for a
+ * background worker, so callers are not held up or failed by a slow or
+ * unavailable mail server. Failed sends are retried then logged.
+ */
+
+type AsyncMailer struct {
+	logger *zap.SugaredLogger
+	mailer port.Mailer
+	queue  chan *model.EmailMessage
+	done   sync.WaitGroup
+	once   sync.Once
+}
+
+// NewAsyncMailer creates a new async mailer and starts its worker
+func NewAsyncMailer(logger *zap.SugaredLogger, mailer port.Mailer) *AsyncMailer {
+	m := &AsyncMailer{
+		logger: logger,
+		mailer: mailer,
+		queue:  make(chan *model.EmailMessage, asyncQueueSize),
+	}
+	m.done.Add(1)
+	go m.run()
+	return m
+}
+
+func (m *AsyncMailer) Send(message *model.EmailMessage) error {
+	select {
+	case m.queue <- message:
+		return nil
+	default:
+		return ErrMailQueueFull
+	}
+}
+
+// Close stops accepting messages and waits for queued ones to be sent
+func (m *AsyncMailer) Close() {
+	m.once.Do(func() {
+		close(m.queue)
+	})
+	m.done.Wait()
+}
+
+func (m *AsyncMailer) run() {
+	defer m.done.Done()
+	for message := range m.queue {
+		var err error
+		for attempt := 1; attempt <= asyncSendAttempts; attempt++ {
+			if err = m.mailer.Send(message); err == nil {
+				break
+			}
+			if attempt < asyncSendAttempts {
+				time.Sleep(asyncRetryBackoff * time.Duration(attempt))
+			}
+		}
+		if err != nil {
+			m.logger.Errorw("failed to send email", "to", message.To, "subject", message.Subject, "error", err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package mail
+
+type Config struct {
+	// Mailer selects the delivery adapter: smtp or log
+	Mailer   string `env:"MAILER, default=log"`
+	From     string `env:"MAIL_FROM, default=Eagle Bank <no-reply@eagle-bank.com>"`
+	Host     string `env:"SMTP_HOST, default=localhost"`
+	Port     int    `env:"SMTP_PORT, default=25"`
+	Username string `env:"SMTP_USERNAME"`
+	Password string `env:"SMTP_PASSWORD"`
+	// Dir is where the log adapter also writes each message as an .eml file
+	Dir string `env:"MAIL_DIR"`
+}
@@ -0,0 +1,52 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+/**
+ * LogMailer implements port.Mailer interface for development. Messages are
+ * logged and, when a directory is configured, written there as .eml files.
+ */
+
+type LogMailer struct {
+	logger *zap.SugaredLogger
+	from   string
+	dir    string
+}
+
+// NewLogMailer creates a new log mailer instance
+func NewLogMailer(logger *zap.SugaredLogger, config Config) *LogMailer {
+	return &LogMailer{
+		logger: logger,
+		from:   config.From,
+		dir:    config.Dir,
+	}
+}
+
+func (m *LogMailer) Send(message *model.EmailMessage) error {
+	if message == nil {
+		return errors.New("email message cannot be nil")
+	}
+	m.logger.Infow("email", "to", message.To, "subject", message.Subject, "body", message.TextBody)
+	if m.dir == "" {
+		return nil
+	}
+
+	body, err := buildMessage(m.from, message)
+	if err != nil {
+		return errors.Wrap(err, "failed to build email")
+	}
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	if err := os.WriteFile(filepath.Join(m.dir, name), body, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write email")
+	}
+	return nil
+}
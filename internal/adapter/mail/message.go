@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+// buildMessage renders an RFC 5322 message, as multipart/alternative when the
+// message has an HTML body.
+func buildMessage(from string, message *model.EmailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if message.HTMLBody == "" {
+		buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+		buf.WriteString(message.TextBody)
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", message.TextBody},
+		{"text/html; charset=utf-8", message.HTMLBody},
+	}
+	for _, p := range parts {
+		part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {p.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+package mail
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+/**
+ * SMTPMailer implements port.Mailer interface
+ * and delivers mail through an SMTP relay
+ */
+
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a new SMTP mailer instance
+func NewSMTPMailer(config Config) *SMTPMailer {
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+	return &SMTPMailer{
+		addr: net.JoinHostPort(config.Host, strconv.Itoa(config.Port)),
+		from: config.From,
+		auth: auth,
+	}
+}
+
+func (m *SMTPMailer) Send(message *model.EmailMessage) error {
+	if message == nil {
+		return errors.New("email message cannot be nil")
+	}
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return errors.Wrap(err, "invalid from address")
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return errors.Wrap(err, "invalid to address")
+	}
+	body, err := buildMessage(m.from, message)
+	if err != nil {
+		return errors.Wrap(err, "failed to build email")
+	}
+	if err := smtp.SendMail(m.addr, m.auth, from.Address, []string{to.Address}, body); err != nil {
+		return errors.Wrap(err, "failed to send email")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package mail_test
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/mail"
+	"eagle-bank.com/internal/core/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type smtpSession struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer accepts a single SMTP session and sends what it receives on
+// the returned channel.
+func fakeSMTPServer(t *testing.T) (string, int, <-chan smtpSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		var session smtpSession
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				session.from = strings.Trim(strings.TrimPrefix(command, "MAIL FROM:"), "<>")
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				session.to = append(session.to, strings.Trim(strings.TrimPrefix(command, "RCPT TO:"), "<>"))
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				session.data = data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				sessions <- session
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return host, portNumber, sessions
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	host, port, sessions := fakeSMTPServer(t)
+
+	mailer := mail.NewSMTPMailer(mail.Config{
+		From: "Eagle Bank <no-reply@eagle-bank.com>",
+		Host: host,
+		Port: port,
+	})
+	err := mailer.Send(&model.EmailMessage{
+		To:       "alice@example.com",
+		Subject:  "Verify your Eagle Bank email address",
+		TextBody: "Follow the link to verify",
+		HTMLBody: "<p>Follow the link to verify</p>",
+	})
+	require.NoError(t, err)
+
+	session := <-sessions
+	assert.Equal(t, "no-reply@eagle-bank.com", session.from)
+	assert.Equal(t, []string{"alice@example.com"}, session.to)
+	assert.Contains(t, session.data, "Subject: Verify your Eagle Bank email address")
+	assert.Contains(t, session.data, "Content-Type: multipart/alternative")
+	assert.Contains(t, session.data, "Follow the link to verify")
+	assert.Contains(t, session.data, "<p>Follow the link to verify</p>")
+}
+
+func TestSMTPMailer_SendServerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	mailer := mail.NewSMTPMailer(mail.Config{From: "no-reply@eagle-bank.com", Host: "127.0.0.1", Port: port})
+	err = mailer.Send(&model.EmailMessage{To: "alice@example.com", Subject: "subject", TextBody: "body"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+/**
+ * TokenStore implements port.TokenStore interface in process memory. Revocations
+ * are lost on restart and are not shared between instances, so it is only
+ * suitable for local development and single instance deployments. A password
+ * reset or change revokes sessions in postgres along with the new password, so
+ * access tokens checked against this store stay valid until they expire.
+ */
+
+type TokenStore struct {
+	mu          sync.Mutex
+	revoked     map[string]time.Time
+	generations map[string]int64
+}
+
+// NewTokenStore creates a new in-memory token store instance
+func NewTokenStore() *TokenStore {
+	return &TokenStore{
+		revoked:     make(map[string]time.Time),
+		generations: make(map[string]int64),
+	}
+}
+
+func (s *TokenStore) Revoke(_ context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, expiry := range s.revoked {
+		if !expiry.After(now) {
+			delete(s.revoked, id)
+		}
+	}
+	s.revoked[tokenID] = expiresAt
+	return nil
+}
+
+func (s *TokenStore) IsRevoked(_ context.Context, tokenID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.revoked[tokenID]
+	return ok && expiry.After(time.Now()), nil
+}
+
+func (s *TokenStore) RevokeUser(_ context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generations[userID]++
+	return nil
+}
+
+func (s *TokenStore) UserGeneration(_ context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.generations[userID], nil
+}
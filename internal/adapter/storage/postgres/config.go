@@ -14,6 +14,12 @@ type Config struct {
 	Port         string `env:"POSTGRES_PORT, default=5432"`
 	DatabaseName string `env:"POSTGRES_DB, default=postgres"`
 	SSLMode      string `env:"POSTGRES_SSL_MODE, default=disable"`
+	// MigrateOnStart applies pending migrations before the server starts
+	MigrateOnStart bool `env:"POSTGRES_MIGRATE_ON_START, default=true"`
+	// SeedDemoData adds the demo customers and administrator on start
+	SeedDemoData bool `env:"POSTGRES_SEED_DEMO_DATA, default=false"`
+	// RequestTimeout bounds the database work done for one API request
+	RequestTimeout string `env:"POSTGRES_REQUEST_TIMEOUT, default=5s"`
 }
 
 func (c Config) GetUsername() string {
@@ -25,7 +25,7 @@ func NewDBContext(ctx context.Context, DBConfig Config) (*DBContext, error) {
 }
 
 // OpenDB returns a PostgresSQL sqlx.DB.
-func OpenDB(_ context.Context, dbCfg Config) (*sqlx.DB, error) {
+func OpenDB(ctx context.Context, dbCfg Config) (*sqlx.DB, error) {
 
 	dataSourceName, err := dbCfg.PostgresConnString()
 	if err != nil {
@@ -36,8 +36,7 @@ func OpenDB(_ context.Context, dbCfg Config) (*sqlx.DB, error) {
 		return nil, errors.Wrap(err, "failed to connect to postgres")
 	}
 
-	err = db.Ping()
-	
+	err = db.PingContext(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to ping postgres")
 	} else {
@@ -0,0 +1,337 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+//go:embed seeds/demo_data.sql
+var demoData string
+
+// migrationLockID is the advisory lock held while migrating, so that replicas
+// starting together apply each migration once.
+const migrationLockID int64 = 0x6561676c65 // "eagle"
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned change to the schema and the SQL to undo it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// AppliedMigration is a migration recorded in schema_migrations.
+type AppliedMigration struct {
+	Version int64  `db:"version"`
+	Name    string `db:"name"`
+}
+
+// Migrations returns the migrations built into the binary, oldest first.
+func Migrations() ([]Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return LoadMigrations(files)
+}
+
+// LoadMigrations reads <version>_<name>.up.sql and <version>_<name>.down.sql
+// pairs from fsys, oldest first.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migrations")
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, fmt.Errorf("unexpected file %q in migrations", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", entry.Name(), err)
+		}
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration %s", entry.Name())
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(sql)
+		} else {
+			migration.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator applies and reverts migrations in the eagle schema, recording them
+// in eagle.schema_migrations.
+type Migrator struct {
+	pg         *DBContext
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the given migrations
+func NewMigrator(db *DBContext, migrations []Migration) *Migrator {
+	return &Migrator{
+		db,
+		migrations,
+	}
+}
+
+// Up applies every migration not yet recorded, oldest first, and returns
+// those it applied. A schema created by the old seed.sql is adopted as the
+// initial migration before anything is applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.baseline(ctx, conn, done); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if done[migration.Version] {
+				continue
+			}
+			err := inTx(ctx, conn, func(tx *sqlx.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return errors.Wrapf(err, "failed to apply migration %d_%s", migration.Version, migration.Name)
+				}
+				_, err := tx.ExecContext(ctx, `
+					INSERT INTO eagle.schema_migrations (version, name, applied_at)
+					VALUES ($1, $2, now())`, migration.Version, migration.Name)
+				return errors.Wrap(err, "failed to record migration")
+			})
+			if err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the most recent steps migrations, newest first, and returns
+// those it reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if !done[migration.Version] {
+				continue
+			}
+			err := inTx(ctx, conn, func(tx *sqlx.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return errors.Wrapf(err, "failed to revert migration %d_%s", migration.Version, migration.Name)
+				}
+				_, err := tx.ExecContext(ctx, `DELETE FROM eagle.schema_migrations WHERE version = $1`, migration.Version)
+				return errors.Wrap(err, "failed to remove migration record")
+			})
+			if err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Applied returns the migrations recorded in schema_migrations, oldest first.
+// It only reads, so a database that has never been migrated has none.
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	var exists bool
+	err := m.pg.DB.GetContext(ctx, &exists, `SELECT to_regclass('eagle.schema_migrations') IS NOT NULL`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to look for schema_migrations")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var applied []AppliedMigration
+	err = m.pg.DB.SelectContext(ctx, &applied, `
+		SELECT version, name
+		FROM eagle.schema_migrations
+		ORDER BY version`)
+	return applied, errors.Wrap(err, "failed to read schema_migrations")
+}
+
+// seedTables are the tables built by the seed.sql script that predates
+// migrations, which the first migration reproduces.
+var seedTables = []string{"accounts", "addresses", "user_accounts", "user_verification_tokens", "users"}
+
+// baseline records the first migration as applied when the schema was built
+// by the seed.sql script that predates migrations, so that Up continues from
+// there rather than failing on tables that already exist. Any other schema
+// without recorded migrations is refused, as Up cannot tell which of its
+// migrations have already been made by hand.
+func (m *Migrator) baseline(ctx context.Context, conn *sqlx.Conn, done map[int64]bool) error {
+	if len(done) > 0 || len(m.migrations) == 0 {
+		return nil
+	}
+	var tables []string
+	err := sqlx.SelectContext(ctx, conn, &tables, `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = 'eagle'
+		AND table_name <> 'schema_migrations'
+		ORDER BY table_name`)
+	if err != nil {
+		return errors.Wrap(err, "failed to look for an existing schema")
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	if !slices.Equal(tables, seedTables) {
+		return fmt.Errorf("schema eagle has no recorded migrations but is not the one built by seed.sql: found %v", tables)
+	}
+
+	initial := m.migrations[0]
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO eagle.schema_migrations (version, name, applied_at)
+		VALUES ($1, $2, now())`, initial.Version, initial.Name)
+	if err != nil {
+		return errors.Wrap(err, "failed to record baseline migration")
+	}
+	done[initial.Version] = true
+	return nil
+}
+
+// SeedDemoData adds the demo customers and administrator. It can be run more
+// than once and expects the schema to be up to date.
+func (m *Migrator) SeedDemoData(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		return inTx(ctx, conn, func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(ctx, demoData)
+			return errors.Wrap(err, "failed to seed demo data")
+		})
+	})
+}
+
+// withLock runs fn on a single connection holding the migration lock, after
+// making sure schema_migrations exists. Advisory locks belong to a session,
+// so everything done under the lock must use conn.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.pg.DB.Connx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection")
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return errors.Wrap(err, "failed to take migration lock")
+	}
+	defer func() {
+		// closing the connection would also release the lock
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+	}()
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE SCHEMA IF NOT EXISTS eagle;
+		CREATE TABLE IF NOT EXISTS eagle.schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to create schema_migrations")
+	}
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sqlx.Conn) (map[int64]bool, error) {
+	var versions []int64
+	err := sqlx.SelectContext(ctx, conn, &versions, `SELECT version FROM eagle.schema_migrations`)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read schema_migrations")
+	}
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+	return applied, nil
+}
+
+// inTx runs fn in a transaction on conn with the eagle schema first on the
+// search path, so migration files need not qualify table names.
+func inTx(ctx context.Context, conn *sqlx.Conn, fn func(tx *sqlx.Tx) error) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `SET LOCAL search_path TO eagle`)
+	if err != nil {
+		return errors.Wrap(err, "failed to set search path")
+	}
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
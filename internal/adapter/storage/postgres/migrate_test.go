@@ -0,0 +1,159 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+
+	t.Run("migrations are ordered by version", func(t *testing.T) {
+		migrations, err := postgres.LoadMigrations(fstest.MapFS{
+			"0010_add_index.up.sql":        {Data: []byte("CREATE INDEX ...")},
+			"0010_add_index.down.sql":      {Data: []byte("DROP INDEX ...")},
+			"0002_add_column.up.sql":       {Data: []byte("ALTER TABLE ... ADD")},
+			"0002_add_column.down.sql":     {Data: []byte("ALTER TABLE ... DROP")},
+			"0001_initial_schema.up.sql":   {Data: []byte("CREATE TABLE ...")},
+			"0001_initial_schema.down.sql": {Data: []byte("DROP TABLE ...")},
+		})
+		require.NoError(t, err)
+		require.Len(t, migrations, 3)
+		assert.Equal(t, []int64{1, 2, 10}, []int64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+		assert.Equal(t, "add_column", migrations[1].Name)
+		assert.Equal(t, "ALTER TABLE ... ADD", migrations[1].Up)
+		assert.Equal(t, "ALTER TABLE ... DROP", migrations[1].Down)
+	})
+
+	t.Run("every migration can be reverted", func(t *testing.T) {
+		_, err := postgres.LoadMigrations(fstest.MapFS{
+			"0001_initial_schema.up.sql": {Data: []byte("CREATE TABLE ...")},
+		})
+		require.ErrorContains(t, err, "needs both an up and a down file")
+	})
+
+	t.Run("versions are unique", func(t *testing.T) {
+		_, err := postgres.LoadMigrations(fstest.MapFS{
+			"0001_initial_schema.up.sql": {Data: []byte("CREATE TABLE ...")},
+			"0001_other.down.sql":        {Data: []byte("DROP TABLE ...")},
+		})
+		require.ErrorContains(t, err, "is named both")
+	})
+
+	t.Run("unexpected files are rejected", func(t *testing.T) {
+		_, err := postgres.LoadMigrations(fstest.MapFS{
+			"initial_schema.sql": {Data: []byte("CREATE TABLE ...")},
+		})
+		require.ErrorContains(t, err, "unexpected file")
+	})
+}
+
+func TestMigrations(t *testing.T) {
+	migrations, err := postgres.Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, int64(1), migrations[0].Version)
+}
+
+// newMigrator returns a migrator for the built in migrations over an empty
+// database.
+func newMigrator(t *testing.T) (*postgres.Migrator, *postgres.DBContext, []postgres.Migration) {
+	db := testsupport.NewPostgres(t)
+	migrations, err := postgres.Migrations()
+	require.NoError(t, err)
+	return postgres.NewMigrator(db, migrations), db, migrations
+}
+
+func appliedVersions(t *testing.T, migrator *postgres.Migrator) []int64 {
+	applied, err := migrator.Applied(context.Background())
+	require.NoError(t, err)
+	versions := []int64{}
+	for _, migration := range applied {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+func versions(migrations []postgres.Migration) []int64 {
+	versions := []int64{}
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+func TestMigrator_Up(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every migration is applied once", func(t *testing.T) {
+		migrator, _, migrations := newMigrator(t)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, versions(migrations), versions(applied))
+		assert.Equal(t, versions(migrations), appliedVersions(t, migrator))
+
+		applied, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("schema built by seed.sql is adopted", func(t *testing.T) {
+		migrator, db, migrations := newMigrator(t)
+		_, err := db.DB.Exec(`CREATE SCHEMA eagle; SET search_path TO eagle; ` + migrations[0].Up + `; RESET search_path`)
+		require.NoError(t, err)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, versions(migrations[1:]), versions(applied))
+		assert.Equal(t, versions(migrations), appliedVersions(t, migrator))
+	})
+
+	t.Run("schema changed since seed.sql is refused", func(t *testing.T) {
+		migrator, db, migrations := newMigrator(t)
+		_, err := db.DB.Exec(`CREATE SCHEMA eagle; SET search_path TO eagle; ` + migrations[0].Up + `; ` + migrations[1].Up + `; RESET search_path`)
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		require.ErrorContains(t, err, "not the one built by seed.sql")
+		assert.Empty(t, appliedVersions(t, migrator))
+	})
+}
+
+func TestMigrator_Down(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("newest migration is reverted and can be applied again", func(t *testing.T) {
+		migrator, _, migrations := newMigrator(t)
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+
+		reverted, err := migrator.Down(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, versions(migrations[len(migrations)-1:]), versions(reverted))
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, versions(reverted), versions(applied))
+	})
+
+	t.Run("every migration can be reverted in turn", func(t *testing.T) {
+		migrator, _, migrations := newMigrator(t)
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+
+		reverted, err := migrator.Down(ctx, len(migrations))
+		require.NoError(t, err)
+		assert.Len(t, reverted, len(migrations))
+		assert.Empty(t, appliedVersions(t, migrator))
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, len(migrations))
+	})
+}
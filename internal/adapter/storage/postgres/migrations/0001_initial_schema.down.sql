@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS user_accounts;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS user_verification_tokens;
+DROP TABLE IF EXISTS addresses;
+DROP TABLE IF EXISTS users;
+
+DROP TYPE IF EXISTS account_type;
+DROP TYPE IF EXISTS user_status;
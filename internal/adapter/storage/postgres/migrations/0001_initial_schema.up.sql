@@ -1,13 +1,7 @@
-CREATE SCHEMA IF NOT EXISTS eagle;
-SET SCHEMA 'eagle';
-
-DROP TABLE IF EXISTS user_accounts;
-DROP TABLE IF EXISTS accounts;
-DROP TABLE IF EXISTS user_verification_tokens;
-DROP TABLE IF EXISTS addresses;
-DROP TABLE IF EXISTS users;
-
-DROP TYPE IF EXISTS user_status;
+/*
+ the schema built by the original seed.sql, so that databases created by it can be adopted
+ as having this migration applied. Its demo rows are now in seeds/demo_data.sql.
+ */
 
 CREATE TYPE user_status AS ENUM ('awaiting_verification', 'email_verified', 'active', 'suspended');
 CREATE TYPE account_type AS ENUM ('personal', 'business');
@@ -54,27 +48,6 @@ CREATE TABLE user_verification_tokens (
                                           UNIQUE (user_id)
 );
 
-
-
-INSERT INTO users (name, email, phone_number)
-VALUES
-    ('Alice Smith', 'alice@example.com', '+447123456789'),
-    ('Bob Johnson', 'bob@example.com', '+447234567890'),
-    ('Carol Davis', 'carol@example.com', '+447345678901');
-
-
-INSERT INTO addresses (user_id, line1, line2, town, county, postcode)
-SELECT id, '123 Main Street', NULL, 'London', 'Greater London', 'SW1A 1AA'
-FROM users WHERE email = 'alice@example.com';
-
-INSERT INTO addresses (user_id, line1, line2, town, county, postcode)
-SELECT id, '456 Oak Avenue', 'Flat 2B', 'Manchester', 'Greater Manchester', 'M1 2AB'
-FROM users WHERE email = 'bob@example.com';
-
-INSERT INTO addresses (user_id, line1, line2, line3, town, county, postcode)
-SELECT id, '789 Pine Road', NULL, NULL, 'Bristol', 'City of Bristol', 'BS1 3CD'
-FROM users WHERE email = 'carol@example.com';
-
 CREATE TABLE accounts (
                          account_number     CHAR(8) PRIMARY KEY,  -- fixed 8-digit account number
                          sort_code          CHAR(8) NOT NULL,     -- e.g. "10-10-10"
@@ -95,5 +68,3 @@ CREATE TABLE user_accounts (
                                created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
                                UNIQUE (user_id, account_number) -- prevents duplicate user/account pairs
 );
-
-
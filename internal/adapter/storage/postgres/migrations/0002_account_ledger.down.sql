@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS transfers;
+
+ALTER TABLE accounts DROP COLUMN closed_at;
+ALTER TABLE accounts DROP COLUMN status;
+
+DROP TYPE IF EXISTS transaction_type;
+DROP TYPE IF EXISTS account_status;
@@ -0,0 +1,34 @@
+/* accounts are closed rather than deleted, and frozen by an administrator */
+CREATE TYPE account_status AS ENUM ('open', 'frozen', 'closed');
+CREATE TYPE transaction_type AS ENUM ('deposit', 'withdrawal');
+
+ALTER TABLE accounts ADD COLUMN status account_status NOT NULL DEFAULT 'open';
+ALTER TABLE accounts ADD COLUMN closed_at TIMESTAMPTZ;
+
+CREATE TABLE transfers (
+                           id VARCHAR(40) PRIMARY KEY,              -- e.g. "tfr-123abc"
+                           from_account_number CHAR(8) NOT NULL REFERENCES accounts(account_number),
+                           to_account_number CHAR(8) NOT NULL REFERENCES accounts(account_number),
+                           user_id UUID NOT NULL REFERENCES users(id),
+                           amount NUMERIC(15,2) NOT NULL CHECK (amount > 0),
+                           currency CHAR(3) NOT NULL,
+                           reference VARCHAR(255),
+                           created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           CHECK (from_account_number <> to_account_number)
+);
+
+CREATE TABLE transactions (
+                              id VARCHAR(40) PRIMARY KEY,           -- e.g. "tan-123abc"
+                              account_number CHAR(8) NOT NULL REFERENCES accounts(account_number),
+                              user_id UUID NOT NULL REFERENCES users(id),
+                              amount NUMERIC(15,2) NOT NULL CHECK (amount > 0),
+                              currency CHAR(3) NOT NULL,
+                              transaction_type transaction_type NOT NULL,
+                              reference VARCHAR(255),
+                              transfer_id VARCHAR(40) REFERENCES transfers(id),
+                              balance_after NUMERIC(15,2) NOT NULL CHECK (balance_after >= 0),
+                              created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_transactions_account_number ON transactions(account_number, created_at);
+CREATE INDEX idx_transactions_transfer_id ON transactions(transfer_id);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS password_history;
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS revoked_user_tokens;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+
+ALTER TABLE user_verification_tokens DROP COLUMN created_at;
+ALTER TABLE users ALTER COLUMN status SET DEFAULT 'suspended';
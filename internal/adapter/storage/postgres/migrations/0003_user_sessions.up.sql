@@ -0,0 +1,48 @@
+/* users start out waiting to verify their email address */
+ALTER TABLE users ALTER COLUMN status SET DEFAULT 'awaiting_verification';
+ALTER TABLE user_verification_tokens ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+/* refresh tokens issued from the same login share a family_id so reuse can revoke them all */
+CREATE TABLE refresh_tokens (
+                                id UUID PRIMARY KEY,
+                                family_id UUID NOT NULL,
+                                user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                expires_at TIMESTAMPTZ NOT NULL,
+                                used_at TIMESTAMPTZ,
+                                revoked_at TIMESTAMPTZ,
+                                created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+/* denylist of access tokens (by jti) revoked before they expire, e.g. on logout */
+CREATE TABLE revoked_tokens (
+                                token_id UUID PRIMARY KEY,
+                                expires_at TIMESTAMPTZ NOT NULL,
+                                revoked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+/* every token issued to the user at or before issued_before is revoked, e.g. after a password reset */
+CREATE TABLE revoked_user_tokens (
+                                     user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                                     issued_before TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE password_reset_tokens (
+                                       token UUID PRIMARY KEY,
+                                       user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                       expires_at TIMESTAMPTZ NOT NULL,
+                                       used_at TIMESTAMPTZ,
+                                       created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                       UNIQUE (user_id)
+);
+
+/* previous password hashes, checked so users cannot cycle back to a recent password */
+CREATE TABLE password_history (
+                                  id BIGSERIAL PRIMARY KEY,
+                                  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                  password_hash TEXT NOT NULL,
+                                  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_password_history_user_id ON password_history(user_id, created_at DESC);
@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+                                  scope VARCHAR(64) NOT NULL,            -- user id of the caller, empty when unauthenticated
+                                  idempotency_key VARCHAR(255) NOT NULL,
+                                  method VARCHAR(10) NOT NULL,
+                                  path VARCHAR(255) NOT NULL,
+                                  request_hash CHAR(64) NOT NULL,        -- sha256 of method, path and body
+                                  status_code INTEGER,                   -- null while the request is in flight
+                                  content_type VARCHAR(255),
+                                  response_body BYTEA,
+                                  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  completed_at TIMESTAMPTZ,
+                                  PRIMARY KEY (scope, idempotency_key)
+);
@@ -0,0 +1,14 @@
+/* domain events written in the same transaction as the change, published by the outbox dispatcher */
+CREATE TABLE outbox_events (
+                               id UUID PRIMARY KEY,
+                               event_type VARCHAR(64) NOT NULL,
+                               aggregate_id VARCHAR(64) NOT NULL,
+                               payload JSONB NOT NULL,
+                               attempts INTEGER NOT NULL DEFAULT 0,
+                               next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               last_error TEXT,
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               published_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at) WHERE published_at IS NULL;
@@ -0,0 +1,16 @@
+/* recent failed logins per email address and per client IP, used to slow down credential stuffing */
+CREATE TABLE failed_logins (
+                               scope VARCHAR(8) NOT NULL,
+                               login_key VARCHAR(255) NOT NULL,
+                               failures INT NOT NULL,
+                               last_failure_at TIMESTAMPTZ NOT NULL,
+                               PRIMARY KEY (scope, login_key)
+);
+
+/* users suspended for too many failed logins, with the status to restore when the lock is lifted */
+CREATE TABLE user_lockouts (
+                               user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                               previous_status user_status NOT NULL,
+                               locked_until TIMESTAMPTZ NOT NULL,
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,18 @@
+/* TOTP second factor; the secret is encrypted and enabled_at is set once the user has entered a valid code */
+CREATE TABLE user_totp (
+                           user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                           secret BYTEA NOT NULL,
+                           enabled_at TIMESTAMPTZ,
+                           last_used_step BIGINT,
+                           created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+/* single-use codes for when the authenticator is lost, stored as sha256 hashes */
+CREATE TABLE totp_recovery_codes (
+                                     id BIGSERIAL PRIMARY KEY,
+                                     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                     code_hash CHAR(64) NOT NULL,
+                                     used_at TIMESTAMPTZ,
+                                     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                     UNIQUE (user_id, code_hash)
+);
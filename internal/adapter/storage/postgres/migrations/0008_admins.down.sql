@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN password_reset_required;
+
+DROP TABLE IF EXISTS admins;
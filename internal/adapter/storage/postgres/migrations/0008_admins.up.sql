@@ -0,0 +1,12 @@
+/* bank staff, who log in separately from customers and receive the admin scope */
+CREATE TABLE admins (
+                        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                        name VARCHAR(100) NOT NULL,
+                        email VARCHAR(255) UNIQUE NOT NULL,
+                        password_hash TEXT NOT NULL,
+                        created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                        disabled_at TIMESTAMPTZ
+);
+
+/* set by an administrator to stop the user logging in until they reset their password */
+ALTER TABLE users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
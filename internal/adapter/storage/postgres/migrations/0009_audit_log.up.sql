@@ -0,0 +1,44 @@
+/* append-only record of changes for compliance review */
+CREATE TABLE audit_log (
+                           id UUID PRIMARY KEY,
+                           actor_id VARCHAR(64) NOT NULL,
+                           actor_type VARCHAR(16) NOT NULL,
+                           action VARCHAR(64) NOT NULL,
+                           entity_type VARCHAR(32) NOT NULL,
+                           entity_id VARCHAR(64) NOT NULL,
+                           before JSONB,
+                           after JSONB,
+                           request_id VARCHAR(64),
+                           occurred_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, occurred_at);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor_id, occurred_at);
+CREATE INDEX idx_audit_log_occurred_at ON audit_log(occurred_at);
+
+CREATE FUNCTION reject_audit_log_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
+
+/* a user with their address, as shown to the user and read for audit_log */
+CREATE VIEW user_profiles AS
+SELECT u.id,
+       u.name,
+       u.email,
+       u.phone_number,
+       u.status,
+       u.password_reset_required,
+       a.line1,
+       a.line2,
+       a.line3,
+       a.town,
+       a.county,
+       a.postcode
+FROM users u
+JOIN addresses a ON a.user_id = u.id;
@@ -0,0 +1,3 @@
+/* incremented by every update so that clients can make conditional changes with If-Match */
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE accounts ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
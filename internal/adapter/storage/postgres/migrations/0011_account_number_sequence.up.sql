@@ -0,0 +1,5 @@
+/*
+ serials for new account numbers, formatted as 01 + serial + check digit by the application.
+ Accounts opened before the sequence keep their numbers, so the format is not enforced here.
+ */
+CREATE SEQUENCE account_number_seq AS INTEGER MINVALUE 0 MAXVALUE 99999 START WITH 0 NO CYCLE;
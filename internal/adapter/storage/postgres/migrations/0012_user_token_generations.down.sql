@@ -0,0 +1,4 @@
+ALTER TABLE user_token_generations ADD COLUMN issued_before TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE user_token_generations ALTER COLUMN issued_before DROP DEFAULT;
+ALTER TABLE user_token_generations DROP COLUMN generation;
+ALTER TABLE user_token_generations RENAME TO revoked_user_tokens;
@@ -0,0 +1,10 @@
+/*
+ tokens carry their user's generation, and bumping it revokes every token issued so far.
+ Issue times only have one second resolution, which cannot order a revocation against
+ tokens issued in the same second.
+ */
+ALTER TABLE revoked_user_tokens RENAME TO user_token_generations;
+ALTER TABLE user_token_generations ADD COLUMN generation BIGINT NOT NULL DEFAULT 0;
+/* tokens issued before this migration have no generation, so users revoked until now log in again */
+UPDATE user_token_generations SET generation = 1;
+ALTER TABLE user_token_generations DROP COLUMN issued_before;
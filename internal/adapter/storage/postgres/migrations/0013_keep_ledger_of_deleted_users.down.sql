@@ -0,0 +1,3 @@
+/* rows made by users deleted since are not checked, so that the constraints can be restored */
+ALTER TABLE transfers ADD CONSTRAINT transfers_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) NOT VALID;
+ALTER TABLE transactions ADD CONSTRAINT transactions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) NOT VALID;
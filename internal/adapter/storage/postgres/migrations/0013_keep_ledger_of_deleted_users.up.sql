@@ -0,0 +1,6 @@
+/*
+ users can be deleted once their accounts are closed, but the ledger of those accounts is kept,
+ so transactions and transfers record who made them by ID alone
+ */
+ALTER TABLE transactions DROP CONSTRAINT transactions_user_id_fkey;
+ALTER TABLE transfers DROP CONSTRAINT transfers_user_id_fkey;
@@ -0,0 +1,10 @@
+/* the last email of each kind sent on request to an address, used to throttle verification and password reset emails */
+CREATE TABLE email_sends (
+                             kind VARCHAR(32) NOT NULL,
+                             address VARCHAR(255) NOT NULL,
+                             sent_at TIMESTAMPTZ NOT NULL,
+                             PRIMARY KEY (kind, address)
+);
+
+/* these were counted as failed logins before email sends had their own table */
+DELETE FROM failed_logins WHERE scope IN ('verify', 'reset');
@@ -0,0 +1,5 @@
+/* headers such as Location and ETag are replayed along with the stored response body */
+ALTER TABLE idempotency_keys ADD COLUMN response_headers JSONB;
+
+/* unauthenticated callers are now scoped by client IP rather than sharing the empty scope */
+DELETE FROM idempotency_keys WHERE scope = '';
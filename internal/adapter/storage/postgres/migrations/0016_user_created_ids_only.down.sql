@@ -0,0 +1 @@
+/* the removed name and email are not restored */
@@ -0,0 +1,4 @@
+/* user.created events carried the user's name and email, which are kept out of published events now */
+UPDATE outbox_events
+SET payload = payload - 'name' - 'email'
+WHERE event_type = 'user.created';
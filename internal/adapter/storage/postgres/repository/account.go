@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
 	"eagle-bank.com/internal/adapter/storage/postgres/repository/entity"
 	"eagle-bank.com/internal/core/domain/model"
 	"github.com/google/uuid"
@@ -31,7 +34,7 @@ func NewAccountRepository(db *postgres.DBContext) *AccountRepository {
 
 // TODO: inject a clock into this method for ease of testing
 
-func (ar *AccountRepository) CreateAccount(newAccount *model.NewAccount) (*model.UserAccount, error) {
+func (ar *AccountRepository) CreateAccount(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
 
 	account, err := entity.NewAccount(
 		entity.WithAccountUserID(newAccount.UserID),
@@ -56,7 +59,7 @@ func (ar *AccountRepository) CreateAccount(newAccount *model.NewAccount) (*model
 		return nil, err
 	}
 
-	tx, err := ar.pg.DB.Beginx()
+	tx, err := ar.pg.DB.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -76,10 +79,10 @@ func (ar *AccountRepository) CreateAccount(newAccount *model.NewAccount) (*model
 		}
 	}()
 
-	accountQuery := `	INSERT INTO eagle.accounts (account_number, sort_code, name, account_type, balance, currency, created_at) 
-				VALUES (:account_number, :sort_code, :name, :account_type, :balance, :currency, :created_at)`
+	accountQuery := `	INSERT INTO eagle.accounts (account_number, sort_code, name, account_type, status, balance, currency, created_at) 
+				VALUES (:account_number, :sort_code, :name, :account_type, :status, :balance, :currency, :created_at)`
 
-	_, err = tx.NamedExec(accountQuery, account.FromEntity())
+	_, err = tx.NamedExecContext(ctx, accountQuery, account.FromEntity())
 	if err != nil {
 		if pgErr := new(pq.Error); errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
@@ -93,7 +96,7 @@ func (ar *AccountRepository) CreateAccount(newAccount *model.NewAccount) (*model
 	userAccountQuery := `	INSERT INTO eagle.user_accounts (id, user_id, account_number, created_at) 
 				VALUES (:id, :user_id, :account_number, :created_at)`
 
-	_, err = tx.NamedExec(userAccountQuery, userAccount.FromEntity())
+	_, err = tx.NamedExecContext(ctx, userAccountQuery, userAccount.FromEntity())
 	if err != nil {
 		if pgErr := new(pq.Error); errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
@@ -104,17 +107,40 @@ func (ar *AccountRepository) CreateAccount(newAccount *model.NewAccount) (*model
 		return nil, errors.New("error encountered creating account ")
 	}
 
+	err = insertOutboxEvent(ctx, tx, model.EventAccountOpened, account.AccountNumber(), model.AccountOpenedEvent{
+		AccountNumber: account.AccountNumber(),
+		UserID:        account.UserID(),
+		AccountType:   account.AccountType(),
+		Currency:      account.Currency(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := accountSnapshot(ctx, tx, account.AccountNumber())
+	if err != nil {
+		return nil, err
+	}
+	err = recordChange(ctx, tx, actor, model.AuditActionAccountCreated, model.AuditEntityAccount, account.AccountNumber(), nil, after)
+	if err != nil {
+		return nil, err
+	}
+
 	if commitErr := tx.Commit(); commitErr != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
 	}
 
-	return ar.GetAccountByNumber(account.AccountNumber())
+	return ar.GetAccountByNumber(ctx, account.AccountNumber())
 }
 
-func (ar *AccountRepository) GetAccountByNumber(accountNumber string) (*model.UserAccount, error) {
-	query := `SELECT id, user_id, account_number FROM eagle.user_accounts WHERE account_number = :account_number`
+func (ar *AccountRepository) GetAccountByNumber(ctx context.Context, accountNumber string) (*model.UserAccount, error) {
+	query := `SELECT ua.id, ua.user_id, ua.account_number
+				FROM eagle.user_accounts ua
+				JOIN eagle.accounts a ON a.account_number = ua.account_number
+				WHERE ua.account_number = :account_number
+				AND a.status <> 'closed'`
 	var userAccount entity.UserAccountDAO
-	namedStmt, err := ar.pg.DB.PrepareNamed(query)
+	namedStmt, err := ar.pg.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -123,8 +149,11 @@ func (ar *AccountRepository) GetAccountByNumber(accountNumber string) (*model.Us
 	args := map[string]interface{}{
 		"account_number": accountNumber,
 	}
-	err = namedStmt.Get(&userAccount, args)
+	err = namedStmt.GetContext(ctx, &userAccount, args)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccountNotFound
+		}
 		return nil, errors.Wrap(err, "failed to execute query")
 	}
 	return &model.UserAccount{
@@ -132,3 +161,366 @@ func (ar *AccountRepository) GetAccountByNumber(accountNumber string) (*model.Us
 		AccountNumber: userAccount.AccountNumber,
 	}, nil
 }
+
+func (ar *AccountRepository) GetAccount(ctx context.Context, accountNumber string) (*model.Account, error) {
+	query := `SELECT a.account_number,
+       				ua.user_id,
+       				a.sort_code,
+       				a.name,
+       				a.account_type,
+       				a.status,
+       				a.balance,
+       				a.currency,
+       				a.created_at,
+       				a.updated_at,
+       				a.version
+				FROM eagle.accounts a
+				JOIN eagle.user_accounts ua ON ua.account_number = a.account_number
+				WHERE a.account_number = :account_number
+				AND a.status <> 'closed'`
+
+	var account dao.AccountViewDAO
+	namedStmt, err := ar.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"account_number": accountNumber,
+	}
+	err = namedStmt.GetContext(ctx, &account, args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccountNotFound
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+
+	return account.ConvertToModel(), nil
+}
+
+func (ar *AccountRepository) ListAccountsByUserID(ctx context.Context, userID string) ([]model.Account, error) {
+	query := `SELECT a.account_number,
+       				ua.user_id,
+       				a.sort_code,
+       				a.name,
+       				a.account_type,
+       				a.status,
+       				a.balance,
+       				a.currency,
+       				a.created_at,
+       				a.updated_at,
+       				a.version
+				FROM eagle.accounts a
+				JOIN eagle.user_accounts ua ON ua.account_number = a.account_number
+				WHERE ua.user_id = :user_id
+				AND a.status <> 'closed'
+				ORDER BY a.created_at`
+
+	var rows []dao.AccountViewDAO
+	namedStmt, err := ar.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"user_id": userID,
+	}
+	err = namedStmt.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+
+	accounts := make([]model.Account, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, *row.ConvertToModel())
+	}
+	return accounts, nil
+}
+
+func (ar *AccountRepository) GetEntityByNumber(ctx context.Context, accountNumber string) (*entity.Account, error) {
+	query := `SELECT a.account_number,
+       				ua.user_id,
+       				a.sort_code,
+       				a.name,
+       				a.account_type,
+       				a.status,
+       				a.balance,
+       				a.currency,
+       				a.created_at,
+       				a.updated_at
+				FROM eagle.accounts a
+				JOIN eagle.user_accounts ua ON ua.account_number = a.account_number
+				WHERE a.account_number = :account_number
+				AND a.status <> 'closed'`
+
+	var account entity.AccountDAO
+	namedStmt, err := ar.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"account_number": accountNumber,
+	}
+	err = namedStmt.GetContext(ctx, &account, args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccountNotFound
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+	return account.ToEntity(), nil
+}
+
+func (ar *AccountRepository) UpdateAccount(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+	if update == nil {
+		return nil, errors.New("account update cannot be nil")
+	}
+
+	accountEntity, err := ar.GetEntityByNumber(ctx, update.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := entity.Options[*entity.Account]{
+		entity.WithAccountUpdatedAt(time.Now().UTC()),
+	}
+	if update.Name != nil {
+		opts = opts.Merge(entity.WithAccountName(*update.Name))
+	}
+	if update.Type != nil {
+		opts = opts.Merge(entity.WithAccountType(*update.Type))
+	}
+	err = accountEntity.Modify(opts...)
+	if err != nil {
+		return nil, errors.Wrap(model.ErrInvalidAccount, err.Error())
+	}
+
+	tx, err := ar.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	before, err := accountSnapshot(ctx, tx, accountEntity.AccountNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	accountUpdateQuery := `	UPDATE eagle.accounts SET name = :name,
+	                       account_type = :account_type,
+	                       updated_at = :updated_at,
+	                       version = version + 1
+				WHERE account_number = :account_number
+				AND status <> 'closed'
+				AND (CAST(:version AS BIGINT) IS NULL OR version = :version)`
+
+	account := accountEntity.FromEntity()
+	result, err := tx.NamedExecContext(ctx, accountUpdateQuery, map[string]interface{}{
+		"account_number": account.AccountNumber,
+		"name":           account.Name,
+		"account_type":   account.AccountType,
+		"updated_at":     account.UpdatedAt,
+		"version":        update.Version,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update account")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected < 1 {
+		err = model.ErrAccountNotFound
+		if update.Version != nil {
+			err = model.ErrVersionMismatch
+		}
+		return nil, err
+	}
+
+	after, err := accountSnapshot(ctx, tx, accountEntity.AccountNumber())
+	if err != nil {
+		return nil, err
+	}
+	err = recordChange(ctx, tx, actor, model.AuditActionAccountUpdated, model.AuditEntityAccount, accountEntity.AccountNumber(), before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return ar.GetAccount(ctx, accountEntity.AccountNumber())
+}
+
+// CloseAccount soft-closes an account. Ledger rows keep referencing the account
+// so it is never physically deleted, and only a zero balance may be closed.
+// When version is given the account must still be at that version.
+func (ar *AccountRepository) CloseAccount(ctx context.Context, actor model.Actor, accountNumber string, version *int64) error {
+	tx, err := ar.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var account struct {
+		Balance decimal.Decimal `db:"balance"`
+		Status  string          `db:"status"`
+		Version int64           `db:"version"`
+	}
+	err = tx.GetContext(ctx, &account, `SELECT balance, status, version
+				FROM eagle.accounts
+				WHERE account_number = $1
+				AND status <> 'closed'
+				FOR UPDATE`, accountNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrAccountNotFound
+		}
+		return errors.Wrap(err, "failed to lock account")
+	}
+	if version != nil && *version != account.Version {
+		err = model.ErrVersionMismatch
+		return err
+	}
+
+	// a frozen account stays open until the freeze is lifted
+	if account.Status == model.AccountFrozenStatus {
+		err = model.ErrAccountFrozen
+		return err
+	}
+	if !account.Balance.IsZero() {
+		err = model.ErrAccountNotEmpty
+		return err
+	}
+
+	before, err := accountSnapshot(ctx, tx, accountNumber)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.accounts
+		SET status = $1, closed_at = $2, updated_at = $2, version = version + 1
+		WHERE account_number = $3`, model.AccountClosedStatus, now, accountNumber)
+	if err != nil {
+		return errors.Wrap(err, "failed to close account")
+	}
+
+	after, err := accountSnapshot(ctx, tx, accountNumber)
+	if err != nil {
+		return err
+	}
+	err = recordChange(ctx, tx, actor, model.AuditActionAccountClosed, model.AuditEntityAccount, accountNumber, before, after)
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// SetAccountStatus freezes or unfreezes an account on behalf of an
+// administrator.
+func (ar *AccountRepository) SetAccountStatus(ctx context.Context, actor model.Actor, accountNumber string, status string) error {
+	if status != model.AccountOpenStatus && status != model.AccountFrozenStatus {
+		return model.ErrInvalidAccount
+	}
+
+	tx, err := ar.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var current string
+	err = tx.GetContext(ctx, &current, `SELECT status
+				FROM eagle.accounts
+				WHERE account_number = $1
+				AND status <> 'closed'
+				FOR UPDATE`, accountNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = model.ErrAccountNotFound
+			return err
+		}
+		return errors.Wrap(err, "failed to lock account")
+	}
+	if current == status {
+		err = model.ErrInvalidAccountTransition
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.accounts
+		SET status = $1, updated_at = $2, version = version + 1
+		WHERE account_number = $3`, status, time.Now().UTC(), accountNumber)
+	if err != nil {
+		return errors.Wrap(err, "failed to update account status")
+	}
+
+	action := model.AuditActionAccountFrozen
+	if status == model.AccountOpenStatus {
+		action = model.AuditActionAccountUnfrozen
+	}
+	err = recordChange(ctx, tx, actor, action, model.AuditEntityAccount, accountNumber, statusSnapshot(current), statusSnapshot(status))
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
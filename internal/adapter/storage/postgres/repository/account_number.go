@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+/**
+ * AccountNumberAllocator implements port.AccountNumberAllocator interface
+ * and draws account numbers from a postgres sequence
+ */
+
+// maxAllocationAttempts bounds how many numbers already held by accounts
+// opened before the sequence existed are skipped in one allocation
+const maxAllocationAttempts = 20
+
+type AccountNumberAllocator struct {
+	pg *postgres.DBContext
+}
+
+// NewAccountNumberAllocator creates a new account number allocator instance
+func NewAccountNumberAllocator(db *postgres.DBContext) *AccountNumberAllocator {
+	return &AccountNumberAllocator{
+		db,
+	}
+}
+
+// AllocateAccountNumber takes the next serial from the sequence. Sequence
+// values are never handed out twice, even across concurrent transactions, so
+// the only possible clash is with an account numbered before the sequence
+// existed, and those numbers are skipped.
+func (aa *AccountNumberAllocator) AllocateAccountNumber(ctx context.Context) (string, error) {
+	for range maxAllocationAttempts {
+		var serial int64
+		err := aa.pg.DB.GetContext(ctx, &serial, `SELECT nextval('eagle.account_number_seq')`)
+		if err != nil {
+			// sequence_generator_limit_exceeded
+			if pgErr := new(pq.Error); errors.As(err, &pgErr) && pgErr.Code == "2200H" {
+				return "", model.ErrAccountNumbersExhausted
+			}
+			return "", errors.Wrap(err, "failed to allocate account number")
+		}
+
+		accountNumber, err := model.AccountNumberFromSerial(serial)
+		if err != nil {
+			return "", err
+		}
+
+		var taken bool
+		err = aa.pg.DB.GetContext(ctx, &taken, `SELECT EXISTS (SELECT 1 FROM eagle.accounts WHERE account_number = $1)`, accountNumber)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to check account number")
+		}
+		if !taken {
+			return accountNumber, nil
+		}
+	}
+	return "", errors.New("failed to allocate an unused account number")
+}
@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/service"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountRepository_CloseAccount(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	accountRepo := repository.NewAccountRepository(db)
+	ctx := context.Background()
+
+	t.Run("empty account is closed and no longer found", func(t *testing.T) {
+		userID := createActiveUser(t, db, "empty@example.com")
+		accountNumber := openAccount(t, db, userID)
+
+		require.NoError(t, accountRepo.CloseAccount(ctx, model.UserActor(userID, ""), accountNumber, nil))
+
+		_, err := accountRepo.GetAccount(ctx, accountNumber)
+		require.ErrorIs(t, err, model.ErrAccountNotFound)
+		err = accountRepo.CloseAccount(ctx, model.UserActor(userID, ""), accountNumber, nil)
+		require.ErrorIs(t, err, model.ErrAccountNotFound)
+	})
+
+	t.Run("account with a balance is kept open", func(t *testing.T) {
+		userID := createActiveUser(t, db, "balance@example.com")
+		accountNumber := openAccount(t, db, userID)
+		_, err := repository.NewTransactionRepository(db).CreateTransaction(ctx, &model.NewTransaction{
+			AccountNumber: accountNumber,
+			UserID:        userID,
+			Amount:        decimal.NewFromInt(5),
+			Currency:      "GBP",
+			Type:          "deposit",
+			TransactionID: service.GenerateTransactionID(),
+		})
+		require.NoError(t, err)
+
+		err = accountRepo.CloseAccount(ctx, model.UserActor(userID, ""), accountNumber, nil)
+		require.ErrorIs(t, err, model.ErrAccountNotEmpty)
+
+		account, err := accountRepo.GetAccount(ctx, accountNumber)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(5).Equal(account.Balance))
+	})
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+/**
+ * AdminRepository implements port.AdminRepository interface
+ * and provides access to the postgres database
+ */
+
+type AdminRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewAdminRepository creates a new admin repository instance
+func NewAdminRepository(db *postgres.DBContext) *AdminRepository {
+	return &AdminRepository{
+		db,
+	}
+}
+
+func (ar *AdminRepository) Login(ctx context.Context, email string, password string) (string, error) {
+	var admin dao.AdminDAO
+	err := ar.pg.DB.GetContext(ctx, &admin, `
+		SELECT id, name, email, password_hash, created_at, disabled_at
+		FROM eagle.admins
+		WHERE LOWER(email) = LOWER($1)`, email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", errors.Wrap(err, "failed to execute query")
+	}
+	if err != nil || admin.DisabledAt != nil {
+		return "", model.ErrInvalidCredentials
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password))
+	if err != nil {
+		return "", model.ErrInvalidCredentials
+	}
+	return admin.ID, nil
+}
+
+func (ar *AdminRepository) GetAdminByID(ctx context.Context, id string) (*model.Admin, error) {
+	var admin dao.AdminDAO
+	err := ar.pg.DB.GetContext(ctx, &admin, `
+		SELECT id, name, email, password_hash, created_at, disabled_at
+		FROM eagle.admins
+		WHERE id = $1
+		AND disabled_at IS NULL`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAdminNotFound
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+	return admin.ConvertToModel(), nil
+}
@@ -0,0 +1,30 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAdminRepository_Login(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	adminRepo := repository.NewAdminRepository(db)
+
+	adminID := uuid.NewString()
+	hash, err := bcrypt.GenerateFromPassword([]byte("Admin-passw0rd"), bcrypt.MinCost)
+	require.NoError(t, err)
+	_, err = db.DB.Exec(`INSERT INTO eagle.admins (id, name, email, password_hash) VALUES ($1, 'Ops', 'Ops@Eagle-Bank.com', $2)`, adminID, string(hash))
+	require.NoError(t, err)
+
+	t.Run("email is matched ignoring case, as failures are counted", func(t *testing.T) {
+		id, err := adminRepo.Login(context.Background(), "ops@eagle-bank.com", "Admin-passw0rd")
+		require.NoError(t, err)
+		assert.Equal(t, adminID, id)
+	})
+}
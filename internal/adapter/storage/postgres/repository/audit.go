@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+/**
+ * AuditRepository implements port.AuditRepository interface
+ * and provides access to the postgres database
+ */
+
+type AuditRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *postgres.DBContext) *AuditRepository {
+	return &AuditRepository{
+		db,
+	}
+}
+
+func (ar *AuditRepository) RecordAudit(ctx context.Context, record model.AuditRecord) error {
+	tx, err := ar.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	err = insertAuditRecord(ctx, tx, record)
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// ListAuditRecords returns the records matching filter, oldest first.
+func (ar *AuditRepository) ListAuditRecords(ctx context.Context, filter model.AuditFilter) ([]model.AuditRecord, error) {
+	query := `SELECT id, actor_id, actor_type, action, entity_type, entity_id, before, after, request_id, occurred_at
+				FROM eagle.audit_log
+				WHERE (:entity_type = '' OR entity_type = :entity_type)
+				AND (:entity_id = '' OR entity_id = :entity_id)
+				AND (:actor_id = '' OR actor_id = :actor_id)
+				AND (:request_id = '' OR request_id = :request_id)
+				AND (CAST(:from AS TIMESTAMPTZ) IS NULL OR occurred_at >= :from)
+				AND (CAST(:to AS TIMESTAMPTZ) IS NULL OR occurred_at < :to)
+				ORDER BY occurred_at, id
+				LIMIT :limit OFFSET :offset`
+
+	var rows []dao.AuditRecordDAO
+	namedStmt, err := ar.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"entity_type": filter.EntityType,
+		"entity_id":   filter.EntityID,
+		"actor_id":    filter.ActorID,
+		"request_id":  filter.RequestID,
+		"from":        filter.From,
+		"to":          filter.To,
+		"limit":       filter.Limit,
+		"offset":      filter.Offset,
+	}
+	err = namedStmt.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+
+	records := make([]model.AuditRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, *row.ConvertToModel())
+	}
+	return records, nil
+}
+
+// recordChange appends a record of a change made by actor inside tx.
+func recordChange(ctx context.Context, tx *sqlx.Tx, actor model.Actor, action string, entityType string, entityID string, before json.RawMessage, after json.RawMessage) error {
+	var requestID *string
+	if actor.RequestID != "" {
+		requestID = &actor.RequestID
+	}
+	return insertAuditRecord(ctx, tx, model.AuditRecord{
+		ActorID:    actor.ID,
+		ActorType:  actor.Type,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+		RequestID:  requestID,
+	})
+}
+
+// insertAuditRecord appends to the audit log inside tx, so the record is only
+// kept if the change it describes commits.
+func insertAuditRecord(ctx context.Context, tx *sqlx.Tx, record model.AuditRecord) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO eagle.audit_log (id, actor_id, actor_type, action, entity_type, entity_id, before, after, request_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		uuid.NewString(),
+		record.ActorID,
+		record.ActorType,
+		record.Action,
+		record.EntityType,
+		record.EntityID,
+		nullableJSON(record.Before),
+		nullableJSON(record.After),
+		record.RequestID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to record audit entry")
+	}
+	return nil
+}
+
+func nullableJSON(value []byte) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
+
+// statusSnapshot is the before or after state of a status change.
+func statusSnapshot(status string) json.RawMessage {
+	snapshot, _ := json.Marshal(map[string]string{"status": status})
+	return snapshot
+}
+
+// auditedUser is what the audit log keeps of a user. The log cannot be
+// changed, so the user's personal details stay out of it and a change to them
+// is recorded by field name only; the details are erased with the user.
+// Records written before this held the full profile, and are left to the
+// approved erasure process rather than rewritten here.
+type auditedUser struct {
+	ID                    string   `json:"id"`
+	Status                string   `json:"status"`
+	PasswordResetRequired bool     `json:"passwordResetRequired"`
+	ChangedFields         []string `json:"changedFields,omitempty"`
+}
+
+// userSnapshot is the audited state of user, naming the personal details
+// that changed to reach it.
+func userSnapshot(user *model.User, changedFields ...string) json.RawMessage {
+	snapshot, _ := json.Marshal(auditedUser{
+		ID:                    user.ID,
+		Status:                user.Status,
+		PasswordResetRequired: user.PasswordResetRequired,
+		ChangedFields:         changedFields,
+	})
+	return snapshot
+}
+
+// changedUserFields names the personal details that differ between before and
+// after, using the field names of the user's JSON.
+func changedUserFields(before *model.User, after *model.User) []string {
+	var changed []string
+	for _, field := range []struct {
+		name          string
+		before, after string
+	}{
+		{"name", before.Name, after.Name},
+		{"email", before.Email, after.Email},
+		{"phoneNumber", before.PhoneNumber, after.PhoneNumber},
+		{"line1", before.Line1, after.Line1},
+		{"line2", optionalString(before.Line2), optionalString(after.Line2)},
+		{"line3", optionalString(before.Line3), optionalString(after.Line3)},
+		{"town", before.Town, after.Town},
+		{"county", optionalString(before.County), optionalString(after.County)},
+		{"postcode", before.Postcode, after.Postcode},
+	} {
+		if field.before != field.after {
+			changed = append(changed, field.name)
+		}
+	}
+	return changed
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// userProfile is the user, including their address, as seen inside tx.
+func userProfile(ctx context.Context, tx *sqlx.Tx, userID string) (*model.User, error) {
+	var user dao.UserViewDAO
+	err := tx.GetContext(ctx, &user, `
+		SELECT id, name, email, phone_number, status, password_reset_required, line1, line2, line3, town, county, postcode
+		FROM eagle.user_profiles
+		WHERE id = $1`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, errors.Wrap(err, "failed to read user for audit")
+	}
+	return user.ConvertToModel(), nil
+}
+
+// accountSnapshot is the account, including its status, as seen inside tx.
+func accountSnapshot(ctx context.Context, tx *sqlx.Tx, accountNumber string) (json.RawMessage, error) {
+	var account dao.AccountViewDAO
+	err := tx.GetContext(ctx, &account, `
+		SELECT a.account_number, ua.user_id, a.sort_code, a.name, a.account_type, a.status, a.balance, a.currency, a.created_at, a.updated_at
+		FROM eagle.accounts a
+		JOIN eagle.user_accounts ua ON ua.account_number = a.account_number
+		WHERE a.account_number = $1`, accountNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccountNotFound
+		}
+		return nil, errors.Wrap(err, "failed to read account for audit")
+	}
+	return json.Marshal(struct {
+		*model.Account
+		Status string `json:"status"`
+	}{account.ConvertToModel(), account.Status})
+}
@@ -0,0 +1,38 @@
+package dao
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/shopspring/decimal"
+)
+
+type AccountViewDAO struct {
+	AccountNumber string          `db:"account_number"`
+	UserID        string          `db:"user_id"`
+	SortCode      string          `db:"sort_code"`
+	Name          string          `db:"name"`
+	AccountType   string          `db:"account_type"`
+	Status        string          `db:"status"`
+	Balance       decimal.Decimal `db:"balance"`
+	Currency      string          `db:"currency"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+	Version       int64           `db:"version"`
+}
+
+func (a AccountViewDAO) ConvertToModel() *model.Account {
+	return &model.Account{
+		AccountNumber:    a.AccountNumber,
+		UserID:           a.UserID,
+		SortCode:         a.SortCode,
+		Name:             a.Name,
+		AccountType:      a.AccountType,
+		Status:           a.Status,
+		Balance:          a.Balance,
+		Currency:         a.Currency,
+		CreatedTimestamp: a.CreatedAt,
+		UpdatedTimestamp: a.UpdatedAt,
+		Version:          a.Version,
+	}
+}
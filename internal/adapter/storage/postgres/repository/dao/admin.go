@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type AdminDAO struct {
+	ID           string     `db:"id"`
+	Name         string     `db:"name"`
+	Email        string     `db:"email"`
+	PasswordHash string     `db:"password_hash"`
+	CreatedAt    time.Time  `db:"created_at"`
+	DisabledAt   *time.Time `db:"disabled_at"`
+}
+
+func (a AdminDAO) ConvertToModel() *model.Admin {
+	return &model.Admin{
+		ID:        a.ID,
+		Name:      a.Name,
+		Email:     a.Email,
+		CreatedAt: a.CreatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"encoding/json"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type AuditRecordDAO struct {
+	ID         string    `db:"id"`
+	ActorID    string    `db:"actor_id"`
+	ActorType  string    `db:"actor_type"`
+	Action     string    `db:"action"`
+	EntityType string    `db:"entity_type"`
+	EntityID   string    `db:"entity_id"`
+	Before     []byte    `db:"before"`
+	After      []byte    `db:"after"`
+	RequestID  *string   `db:"request_id"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+func (a AuditRecordDAO) ConvertToModel() *model.AuditRecord {
+	return &model.AuditRecord{
+		ID:         a.ID,
+		ActorID:    a.ActorID,
+		ActorType:  a.ActorType,
+		Action:     a.Action,
+		EntityType: a.EntityType,
+		EntityID:   a.EntityID,
+		Before:     json.RawMessage(a.Before),
+		After:      json.RawMessage(a.After),
+		RequestID:  a.RequestID,
+		OccurredAt: a.OccurredAt,
+	}
+}
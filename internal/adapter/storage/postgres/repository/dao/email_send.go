@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type EmailSendDAO struct {
+	Kind    string    `db:"kind"`
+	Address string    `db:"address"`
+	SentAt  time.Time `db:"sent_at"`
+}
+
+func (e EmailSendDAO) ConvertToModel() *model.EmailSend {
+	return &model.EmailSend{
+		Kind:    e.Kind,
+		Address: e.Address,
+		SentAt:  e.SentAt,
+	}
+}
@@ -0,0 +1,40 @@
+package dao
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type IdempotencyRecordDAO struct {
+	Scope        string         `db:"scope"`
+	Key          string         `db:"idempotency_key"`
+	Method       string         `db:"method"`
+	Path         string         `db:"path"`
+	RequestHash  string         `db:"request_hash"`
+	StatusCode   sql.NullInt32  `db:"status_code"`
+	ContentType  sql.NullString `db:"content_type"`
+	Headers      []byte         `db:"response_headers"`
+	ResponseBody []byte         `db:"response_body"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+func (r IdempotencyRecordDAO) ConvertToModel() *model.IdempotencyRecord {
+	// the headers are only ever written by marshalling a map, so cannot be malformed
+	var headers map[string]string
+	_ = json.Unmarshal(r.Headers, &headers)
+	return &model.IdempotencyRecord{
+		Scope:        r.Scope,
+		Key:          r.Key,
+		Method:       r.Method,
+		Path:         r.Path,
+		RequestHash:  r.RequestHash,
+		StatusCode:   int(r.StatusCode.Int32),
+		ContentType:  r.ContentType.String,
+		Headers:      headers,
+		ResponseBody: r.ResponseBody,
+		CreatedAt:    r.CreatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type FailedLoginsDAO struct {
+	Failures      int       `db:"failures"`
+	LastFailureAt time.Time `db:"last_failure_at"`
+}
+
+func (f FailedLoginsDAO) ConvertToModel() *model.FailedLogins {
+	return &model.FailedLogins{
+		Failures:      f.Failures,
+		LastFailureAt: f.LastFailureAt,
+	}
+}
+
+type UserLockoutDAO struct {
+	UserID         string    `db:"user_id"`
+	PreviousStatus string    `db:"previous_status"`
+	LockedUntil    time.Time `db:"locked_until"`
+}
+
+func (l UserLockoutDAO) ConvertToModel() *model.UserLockout {
+	return &model.UserLockout{
+		UserID:         l.UserID,
+		PreviousStatus: l.PreviousStatus,
+		LockedUntil:    l.LockedUntil,
+	}
+}
@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type TOTPDAO struct {
+	UserID       string     `db:"user_id"`
+	Secret       []byte     `db:"secret"`
+	EnabledAt    *time.Time `db:"enabled_at"`
+	LastUsedStep *int64     `db:"last_used_step"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+func (t TOTPDAO) ConvertToModel() *model.TOTP {
+	return &model.TOTP{
+		UserID:       t.UserID,
+		Secret:       t.Secret,
+		EnabledAt:    t.EnabledAt,
+		LastUsedStep: t.LastUsedStep,
+		CreatedAt:    t.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package dao
+
+import (
+	"encoding/json"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type OutboxEventDAO struct {
+	ID          string    `db:"id"`
+	EventType   string    `db:"event_type"`
+	AggregateID string    `db:"aggregate_id"`
+	Payload     []byte    `db:"payload"`
+	Attempts    int       `db:"attempts"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (o OutboxEventDAO) ConvertToModel() model.Event {
+	return model.Event{
+		ID:          o.ID,
+		Type:        o.EventType,
+		AggregateID: o.AggregateID,
+		Payload:     json.RawMessage(o.Payload),
+		CreatedAt:   o.CreatedAt,
+		Attempts:    o.Attempts,
+	}
+}
@@ -0,0 +1,41 @@
+package dao
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type RefreshTokenDAO struct {
+	ID        string     `db:"id"`
+	FamilyID  string     `db:"family_id"`
+	UserID    string     `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+func (r RefreshTokenDAO) ConvertToModel() *model.RefreshToken {
+	return &model.RefreshToken{
+		ID:        r.ID,
+		FamilyID:  r.FamilyID,
+		UserID:    r.UserID,
+		ExpiresAt: r.ExpiresAt,
+		UsedAt:    r.UsedAt,
+		RevokedAt: r.RevokedAt,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func ConvertRefreshTokenFromModel(m *model.RefreshToken) RefreshTokenDAO {
+	return RefreshTokenDAO{
+		ID:        m.ID,
+		FamilyID:  m.FamilyID,
+		UserID:    m.UserID,
+		ExpiresAt: m.ExpiresAt,
+		UsedAt:    m.UsedAt,
+		RevokedAt: m.RevokedAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -16,6 +16,9 @@ type UserViewDAO struct {
 	Town        string  `db:"town"`
 	County      *string `db:"county"`
 	Postcode    string  `db:"postcode"`
+
+	PasswordResetRequired bool  `db:"password_reset_required"`
+	Version               int64 `db:"version"`
 }
 
 func (u UserViewDAO) ConvertToModel() *model.User {
@@ -31,5 +34,8 @@ func (u UserViewDAO) ConvertToModel() *model.User {
 		Town:        u.Town,
 		County:      u.County,
 		Postcode:    u.Postcode,
+
+		PasswordResetRequired: u.PasswordResetRequired,
+		Version:               u.Version,
 	}
 }
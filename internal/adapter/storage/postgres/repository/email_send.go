@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+/**
+ * EmailSendRepository implements port.EmailSendRepository interface
+ * and provides access to the postgres database
+ */
+
+type EmailSendRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewEmailSendRepository creates a new email send repository instance
+func NewEmailSendRepository(db *postgres.DBContext) *EmailSendRepository {
+	return &EmailSendRepository{
+		db,
+	}
+}
+
+func (er *EmailSendRepository) RecordEmailSend(ctx context.Context, kind string, address string, since time.Time) (*model.EmailSend, bool, error) {
+	var send dao.EmailSendDAO
+	// the update is skipped, returning no row, when the last send is too recent
+	err := er.pg.DB.GetContext(ctx, &send, `
+		INSERT INTO eagle.email_sends (kind, address, sent_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (kind, address) DO UPDATE
+		SET sent_at = EXCLUDED.sent_at
+		WHERE eagle.email_sends.sent_at < $4
+		RETURNING kind, address, sent_at`,
+		kind, address, time.Now().UTC(), since.UTC())
+	if err == nil {
+		return send.ConvertToModel(), true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, errors.Wrap(err, "failed to record email send")
+	}
+
+	err = er.pg.DB.GetContext(ctx, &send, `
+		SELECT kind, address, sent_at
+		FROM eagle.email_sends
+		WHERE kind = $1 AND address = $2`, kind, address)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to get email send")
+	}
+	return send.ConvertToModel(), false, nil
+}
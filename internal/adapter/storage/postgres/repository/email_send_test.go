@@ -0,0 +1,44 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailSendRepository_RecordEmailSend(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	emailSendRepo := repository.NewEmailSendRepository(db)
+	ctx := context.Background()
+	address := "jane@example.com"
+
+	first, recorded, err := emailSendRepo.RecordEmailSend(ctx, model.EmailKindPasswordReset, address, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, recorded)
+
+	t.Run("send within the interval is refused", func(t *testing.T) {
+		last, recorded, err := emailSendRepo.RecordEmailSend(ctx, model.EmailKindPasswordReset, address, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.False(t, recorded)
+		assert.WithinDuration(t, first.SentAt, last.SentAt, time.Millisecond)
+	})
+
+	t.Run("other kinds of email are counted separately", func(t *testing.T) {
+		_, recorded, err := emailSendRepo.RecordEmailSend(ctx, model.EmailKindVerification, address, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.True(t, recorded)
+	})
+
+	t.Run("send after the interval is recorded", func(t *testing.T) {
+		last, recorded, err := emailSendRepo.RecordEmailSend(ctx, model.EmailKindPasswordReset, address, time.Now())
+		require.NoError(t, err)
+		assert.True(t, recorded)
+		assert.True(t, last.SentAt.After(first.SentAt))
+	})
+}
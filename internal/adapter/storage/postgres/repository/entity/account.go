@@ -3,11 +3,12 @@ package entity
 import (
 	"time"
 
+	"eagle-bank.com/internal/core/domain/model"
 	"github.com/shopspring/decimal"
 )
 
 const (
-	eagleSortCode       = "10-10-10"
+	eagleSortCode       = model.EagleSortCode
 	accountPrivateType  = "private"
 	accountBusinessType = "business"
 )
@@ -15,6 +16,7 @@ const (
 func NewAccount(opts ...Option[*Account]) (Account, error) {
 	newEntity := Account{
 		sortCode:  eagleSortCode,
+		status:    model.AccountOpenStatus,
 		createdAt: time.Now().UTC(),
 	}
 	err := newEntity.Modify(opts...)
@@ -37,6 +39,7 @@ func (u *Account) Modify(opts ...Option[*Account]) error {
 		SortCode:      cl.sortCode,
 		Name:          cl.name,
 		AccountType:   cl.accountType,
+		Status:        cl.status,
 		Balance:       cl.balance.String(),
 		Currency:      cl.currency,
 		CreatedAt:     cl.createdAt,
@@ -56,6 +59,7 @@ type Account struct {
 	sortCode      string
 	name          string
 	accountType   string
+	status        string
 	balance       decimal.Decimal
 	currency      string
 	createdAt     time.Time
@@ -68,6 +72,7 @@ type accountValidation struct {
 	SortCode      string    `valid:"required"`
 	Name          string    `valid:"required"`
 	AccountType   string    `valid:"required"`
+	Status        string    `valid:"in(open|frozen|closed),required"`
 	Balance       string    `valid:"required"`
 	Currency      string    `valid:"required"`
 	CreatedAt     time.Time `valid:"required"`
@@ -94,6 +99,10 @@ func (a *Account) AccountType() string {
 	return a.accountType
 }
 
+func (a *Account) Status() string {
+	return a.status
+}
+
 func (a *Account) Balance() decimal.Decimal {
 	return a.balance
 }
@@ -140,6 +149,12 @@ func WithAccountType(accountType string) Option[*Account] {
 	}
 }
 
+func WithAccountStatus(status string) Option[*Account] {
+	return func(a *Account) {
+		a.status = status
+	}
+}
+
 func WithAccountBalance(balance decimal.Decimal) Option[*Account] {
 	return func(a *Account) {
 		a.balance = balance
@@ -170,6 +185,7 @@ func (a *Account) FromEntity() AccountDAO {
 		SortCode:      a.sortCode,
 		Name:          a.name,
 		AccountType:   a.accountType,
+		Status:        a.status,
 		Balance:       a.balance,
 		Currency:      a.currency,
 		CreatedAt:     a.createdAt,
@@ -184,6 +200,7 @@ func (a *AccountDAO) ToEntity() *Account {
 		sortCode:      a.SortCode,
 		name:          a.Name,
 		accountType:   a.AccountType,
+		status:        a.Status,
 		balance:       a.Balance,
 		currency:      a.Currency,
 		createdAt:     a.CreatedAt,
@@ -197,6 +214,7 @@ type AccountDAO struct {
 	SortCode      string          `db:"sort_code"`
 	Name          string          `db:"name"`
 	AccountType   string          `db:"account_type"`
+	Status        string          `db:"status"`
 	Balance       decimal.Decimal `db:"balance"`
 	Currency      string          `db:"currency"`
 	CreatedAt     time.Time       `db:"created_at"`
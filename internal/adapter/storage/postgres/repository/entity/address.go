@@ -81,7 +81,7 @@ func (a *Address) Line2() *string {
 }
 
 func (a *Address) Line3() *string {
-	return a.line2
+	return a.line3
 }
 
 func (a *Address) Town() string {
@@ -100,6 +100,10 @@ func (a *Address) CreatedAt() time.Time {
 	return a.createdAt
 }
 
+func (a *Address) UpdatedAt() time.Time {
+	return a.updatedAt
+}
+
 func WithUserAddressID(id ID) Option[*Address] {
 	return func(a *Address) {
 		a.id = id
@@ -154,6 +158,12 @@ func WithUserAddressCreatedAt(createdAt time.Time) Option[*Address] {
 	}
 }
 
+func WithUserAddressUpdatedAt(updatedAt time.Time) Option[*Address] {
+	return func(a *Address) {
+		a.updatedAt = updatedAt
+	}
+}
+
 type AddressDAO struct {
 	ID        ID        `db:"id"`
 	UserID    ID        `db:"user_id"`
@@ -182,6 +192,21 @@ func (a *Address) FromEntity() AddressDAO {
 	}
 }
 
+func (a *AddressDAO) ToEntity() *Address {
+	return &Address{
+		id:        a.ID,
+		userID:    a.UserID,
+		line1:     a.Line1,
+		line2:     a.Line2,
+		line3:     a.Line3,
+		town:      a.Town,
+		county:    a.County,
+		postcode:  a.Postcode,
+		createdAt: a.CreatedAt,
+		updatedAt: a.UpdatedAt,
+	}
+}
+
 func ConvertUserAddressFromModel(m *model.User) *AddressDAO {
 	return &AddressDAO{
 		Line1:    m.Line1,
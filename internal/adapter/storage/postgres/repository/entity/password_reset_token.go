@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"time"
+)
+
+// passwordResetTokenLifetime is kept short as the token grants control of the account
+const passwordResetTokenLifetime = 15 * time.Minute
+
+func NewPasswordResetToken(opts ...Option[*PasswordResetToken]) (PasswordResetToken, error) {
+	newEntity := PasswordResetToken{
+		expiresAt: time.Now().Add(passwordResetTokenLifetime),
+		createdAt: time.Now().UTC(),
+	}
+	err := newEntity.Modify(opts...)
+	if err != nil {
+		return PasswordResetToken{}, err
+	}
+	return newEntity, nil
+}
+
+func (rt *PasswordResetToken) Modify(opts ...Option[*PasswordResetToken]) error {
+	cl, err := Clone(rt)
+	if err != nil {
+		return err
+	}
+	ApplyOptions(opts, cl)
+
+	err = validate(passwordResetTokenValidation{
+		Token:     cl.token,
+		UserID:    cl.userID,
+		ExpiresAt: cl.expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	*rt = *cl
+	return nil
+}
+
+type PasswordResetToken struct {
+	token     ID
+	userID    ID
+	expiresAt time.Time
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+type passwordResetTokenValidation struct {
+	Token     ID        `valid:"uuid,required"`
+	UserID    ID        `valid:"uuid,required"`
+	ExpiresAt time.Time `valid:"required"`
+}
+
+func (rt *PasswordResetToken) Token() ID {
+	return rt.token
+}
+
+func (rt *PasswordResetToken) UserID() ID {
+	return rt.userID
+}
+
+func (rt *PasswordResetToken) ExpiresAt() time.Time {
+	return rt.expiresAt
+}
+
+func (rt *PasswordResetToken) UsedAt() *time.Time {
+	return rt.usedAt
+}
+
+func (rt *PasswordResetToken) CreatedAt() time.Time {
+	return rt.createdAt
+}
+
+func WithPasswordResetTokenID(token ID) Option[*PasswordResetToken] {
+	return func(rt *PasswordResetToken) {
+		rt.token = token
+	}
+}
+
+func WithPasswordResetTokenUserID(userID ID) Option[*PasswordResetToken] {
+	return func(rt *PasswordResetToken) {
+		rt.userID = userID
+	}
+}
+
+func WithPasswordResetTokenExpiresAt(expiresAt time.Time) Option[*PasswordResetToken] {
+	return func(rt *PasswordResetToken) {
+		rt.expiresAt = expiresAt
+	}
+}
+
+func WithPasswordResetTokenCreatedAt(createdAt time.Time) Option[*PasswordResetToken] {
+	return func(rt *PasswordResetToken) {
+		rt.createdAt = createdAt
+	}
+}
+
+type PasswordResetTokenDAO struct {
+	Token     ID         `db:"token"`
+	UserID    ID         `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+func (rt *PasswordResetToken) FromEntity() *PasswordResetTokenDAO {
+	return &PasswordResetTokenDAO{
+		Token:     rt.token,
+		UserID:    rt.userID,
+		ExpiresAt: rt.expiresAt,
+		UsedAt:    rt.usedAt,
+		CreatedAt: rt.createdAt,
+	}
+}
+
+func (rt PasswordResetTokenDAO) ToEntity() *PasswordResetToken {
+	return &PasswordResetToken{
+		token:     rt.Token,
+		userID:    rt.UserID,
+		expiresAt: rt.ExpiresAt,
+		usedAt:    rt.UsedAt,
+		createdAt: rt.CreatedAt,
+	}
+}
@@ -0,0 +1,222 @@
+package entity
+
+import (
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/shopspring/decimal"
+)
+
+func NewTransaction(opts ...Option[*Transaction]) (Transaction, error) {
+	newEntity := Transaction{
+		createdAt: time.Now().UTC(),
+	}
+	err := newEntity.Modify(opts...)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return newEntity, nil
+}
+
+func (t *Transaction) Modify(opts ...Option[*Transaction]) error {
+	cl, err := Clone(t)
+	if err != nil {
+		return err
+	}
+	ApplyOptions(opts, cl)
+
+	err = validate(transactionValidation{
+		ID:              cl.id,
+		AccountNumber:   cl.accountNumber,
+		UserID:          cl.userID,
+		Amount:          cl.amount.String(),
+		Currency:        cl.currency,
+		TransactionType: cl.transactionType,
+		CreatedAt:       cl.createdAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	*t = *cl
+	return nil
+}
+
+type Transaction struct {
+	id              string
+	accountNumber   string
+	userID          string
+	amount          decimal.Decimal
+	currency        string
+	transactionType string
+	reference       *string
+	transferID      *string
+	balanceAfter    decimal.Decimal
+	createdAt       time.Time
+}
+
+type transactionValidation struct {
+	ID              string    `valid:"required"`
+	AccountNumber   string    `valid:"required"`
+	UserID          string    `valid:"uuid,required"`
+	Amount          string    `valid:"required"`
+	Currency        string    `valid:"required"`
+	TransactionType string    `valid:"in(deposit|withdrawal),required"`
+	CreatedAt       time.Time `valid:"required"`
+}
+
+func (t *Transaction) ID() string {
+	return t.id
+}
+
+func (t *Transaction) AccountNumber() string {
+	return t.accountNumber
+}
+
+func (t *Transaction) UserID() string {
+	return t.userID
+}
+
+func (t *Transaction) Amount() decimal.Decimal {
+	return t.amount
+}
+
+func (t *Transaction) Currency() string {
+	return t.currency
+}
+
+func (t *Transaction) TransactionType() string {
+	return t.transactionType
+}
+
+func (t *Transaction) Reference() *string {
+	return t.reference
+}
+
+func (t *Transaction) TransferID() *string {
+	return t.transferID
+}
+
+func (t *Transaction) BalanceAfter() decimal.Decimal {
+	return t.balanceAfter
+}
+
+func (t *Transaction) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func WithTransactionID(id string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.id = id
+	}
+}
+
+func WithTransactionAccountNumber(accountNumber string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.accountNumber = accountNumber
+	}
+}
+
+func WithTransactionUserID(userID string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.userID = userID
+	}
+}
+
+func WithTransactionAmount(amount decimal.Decimal) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.amount = amount
+	}
+}
+
+func WithTransactionCurrency(currency string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.currency = currency
+	}
+}
+
+func WithTransactionType(transactionType string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.transactionType = transactionType
+	}
+}
+
+func WithTransactionReference(reference *string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.reference = reference
+	}
+}
+
+func WithTransactionTransferID(transferID *string) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.transferID = transferID
+	}
+}
+
+func WithTransactionBalanceAfter(balanceAfter decimal.Decimal) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.balanceAfter = balanceAfter
+	}
+}
+
+func WithTransactionCreatedAt(createdAt time.Time) Option[*Transaction] {
+	return func(t *Transaction) {
+		t.createdAt = createdAt
+	}
+}
+
+func (t *Transaction) FromEntity() TransactionDAO {
+	return TransactionDAO{
+		ID:              t.id,
+		AccountNumber:   t.accountNumber,
+		UserID:          t.userID,
+		Amount:          t.amount,
+		Currency:        t.currency,
+		TransactionType: t.transactionType,
+		Reference:       t.reference,
+		TransferID:      t.transferID,
+		BalanceAfter:    t.balanceAfter,
+		CreatedAt:       t.createdAt,
+	}
+}
+
+func (t *TransactionDAO) ToEntity() *Transaction {
+	return &Transaction{
+		id:              t.ID,
+		accountNumber:   t.AccountNumber,
+		userID:          t.UserID,
+		amount:          t.Amount,
+		currency:        t.Currency,
+		transactionType: t.TransactionType,
+		reference:       t.Reference,
+		transferID:      t.TransferID,
+		balanceAfter:    t.BalanceAfter,
+		createdAt:       t.CreatedAt,
+	}
+}
+
+func (t *TransactionDAO) ConvertToModel() *model.Transaction {
+	return &model.Transaction{
+		ID:               t.ID,
+		Amount:           t.Amount,
+		Currency:         t.Currency,
+		Type:             t.TransactionType,
+		Reference:        t.Reference,
+		UserID:           t.UserID,
+		TransferID:       t.TransferID,
+		CreatedTimestamp: t.CreatedAt,
+	}
+}
+
+type TransactionDAO struct {
+	ID              string          `db:"id"`
+	AccountNumber   string          `db:"account_number"`
+	UserID          string          `db:"user_id"`
+	Amount          decimal.Decimal `db:"amount"`
+	Currency        string          `db:"currency"`
+	TransactionType string          `db:"transaction_type"`
+	Reference       *string         `db:"reference"`
+	TransferID      *string         `db:"transfer_id"`
+	BalanceAfter    decimal.Decimal `db:"balance_after"`
+	CreatedAt       time.Time       `db:"created_at"`
+}
@@ -3,13 +3,15 @@ package entity
 import (
 	"time"
 
+	"eagle-bank.com/internal/core/domain/model"
 	"github.com/google/uuid"
 )
 
 const (
-	UserAwaitingVerificationStatus = "awaiting_verification"
-	UserEmailVerifiedStatus        = "email_verified"
-	UserActiveStatus               = "active"
+	UserAwaitingVerificationStatus = model.UserStatusAwaitingVerification
+	UserEmailVerifiedStatus        = model.UserStatusEmailVerified
+	UserActiveStatus               = model.UserStatusActive
+	UserSuspendedStatus            = model.UserStatusSuspended
 )
 
 func NewUser(opts ...Option[*User]) (User, error) {
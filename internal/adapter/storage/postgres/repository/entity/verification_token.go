@@ -7,6 +7,7 @@ import (
 func NewVerificationToken(opts ...Option[*VerificationToken]) (VerificationToken, error) {
 	newEntity := VerificationToken{
 		expiresAt: time.Now().Add(time.Hour),
+		createdAt: time.Now().UTC(),
 	}
 	err := newEntity.Modify(opts...)
 	if err != nil {
@@ -40,6 +41,7 @@ type VerificationToken struct {
 	userID    ID
 	expiresAt time.Time
 	usedAt    *time.Time
+	createdAt time.Time
 }
 
 type verificationTokenValidation struct {
@@ -60,6 +62,14 @@ func (vt *VerificationToken) ExpiresAt() time.Time {
 	return vt.expiresAt
 }
 
+func (vt *VerificationToken) UsedAt() *time.Time {
+	return vt.usedAt
+}
+
+func (vt *VerificationToken) CreatedAt() time.Time {
+	return vt.createdAt
+}
+
 func WithVerificationTokenID(token ID) Option[*VerificationToken] {
 	return func(vt *VerificationToken) {
 		vt.token = token
@@ -78,11 +88,18 @@ func WithVerificationTokenExpiresAt(expiresAt time.Time) Option[*VerificationTok
 	}
 }
 
+func WithVerificationTokenCreatedAt(createdAt time.Time) Option[*VerificationToken] {
+	return func(vt *VerificationToken) {
+		vt.createdAt = createdAt
+	}
+}
+
 type VerificationTokenDAO struct {
 	Token     ID         `db:"token"`
 	UserID    ID         `db:"user_id"`
 	ExpiresAt time.Time  `db:"expires_at"`
 	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
 }
 
 func (vt *VerificationToken) FromEntity() *VerificationTokenDAO {
@@ -91,6 +108,7 @@ func (vt *VerificationToken) FromEntity() *VerificationTokenDAO {
 		UserID:    vt.userID,
 		ExpiresAt: vt.expiresAt,
 		UsedAt:    vt.usedAt,
+		CreatedAt: vt.createdAt,
 	}
 }
 
@@ -100,5 +118,6 @@ func (vt VerificationTokenDAO) ToEntity() *VerificationToken {
 		userID:    vt.UserID,
 		expiresAt: vt.ExpiresAt,
 		usedAt:    vt.UsedAt,
+		createdAt: vt.CreatedAt,
 	}
 }
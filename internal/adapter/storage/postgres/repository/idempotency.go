@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	// idempotencyKeyTTL is how long a key is remembered before it may be reused
+	idempotencyKeyTTL = 24 * time.Hour
+	// idempotencyLease is how long a request may hold its key without
+	// completing before a retry may take it over, so that a key is not stuck
+	// in flight after a crash. It is well beyond the request timeout.
+	idempotencyLease = time.Minute
+)
+
+/**
+ * IdempotencyRepository implements port.IdempotencyRepository interface
+ * and provides access to the postgres database
+ */
+
+type IdempotencyRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewIdempotencyRepository creates a new idempotency repository instance
+func NewIdempotencyRepository(db *postgres.DBContext) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db,
+	}
+}
+
+func (ir *IdempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error) {
+	if record == nil {
+		return nil, false, errors.New("idempotency record cannot be nil")
+	}
+
+	// expired keys and lapsed leases are forgotten so that they can be
+	// reserved again
+	now := time.Now().UTC()
+	_, err := ir.pg.DB.ExecContext(ctx, `
+		DELETE FROM eagle.idempotency_keys
+		WHERE scope = $1 AND idempotency_key = $2
+		AND (created_at < $3 OR (status_code IS NULL AND created_at < $4))`,
+		record.Scope, record.Key, now.Add(-idempotencyKeyTTL), now.Add(-idempotencyLease))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to expire idempotency key")
+	}
+
+	result, err := ir.pg.DB.NamedExecContext(ctx, `	INSERT INTO eagle.idempotency_keys (scope, idempotency_key, method, path, request_hash, created_at)
+				VALUES (:scope, :idempotency_key, :method, :path, :request_hash, :created_at)
+				ON CONFLICT (scope, idempotency_key) DO NOTHING`, map[string]interface{}{
+		"scope":           record.Scope,
+		"idempotency_key": record.Key,
+		"method":          record.Method,
+		"path":            record.Path,
+		"request_hash":    record.RequestHash,
+		"created_at":      now,
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to reserve idempotency key")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	stored, err := ir.get(ctx, record.Scope, record.Key)
+	if err != nil {
+		return nil, false, err
+	}
+	return stored, rowsAffected == 1, nil
+}
+
+// Complete stores the response of the request that reserved the key. The
+// reservation is matched on its creation time, so a request whose lease was
+// taken over cannot overwrite the response of the one that took it.
+func (ir *IdempotencyRepository) Complete(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil {
+		return errors.New("idempotency record cannot be nil")
+	}
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode response headers")
+	}
+	_, err = ir.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.idempotency_keys
+		SET status_code = $1, content_type = $2, response_headers = $3, response_body = $4, completed_at = $5
+		WHERE scope = $6 AND idempotency_key = $7 AND created_at = $8`,
+		record.StatusCode, record.ContentType, headers, record.ResponseBody, time.Now().UTC(),
+		record.Scope, record.Key, record.CreatedAt.UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to store idempotent response")
+	}
+	return nil
+}
+
+// Release forgets the reservation made by the request, as Complete matches it.
+func (ir *IdempotencyRepository) Release(ctx context.Context, record *model.IdempotencyRecord) error {
+	if record == nil {
+		return errors.New("idempotency record cannot be nil")
+	}
+	_, err := ir.pg.DB.ExecContext(ctx, `
+		DELETE FROM eagle.idempotency_keys
+		WHERE scope = $1 AND idempotency_key = $2 AND created_at = $3 AND status_code IS NULL`,
+		record.Scope, record.Key, record.CreatedAt.UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to release idempotency key")
+	}
+	return nil
+}
+
+func (ir *IdempotencyRepository) get(ctx context.Context, scope string, key string) (*model.IdempotencyRecord, error) {
+	query := `SELECT scope, idempotency_key, method, path, request_hash, status_code, content_type, response_headers, response_body, created_at
+				FROM eagle.idempotency_keys
+				WHERE scope = :scope
+				AND idempotency_key = :idempotency_key`
+
+	var record dao.IdempotencyRecordDAO
+	namedStmt, err := ir.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"scope":           scope,
+		"idempotency_key": key,
+	}
+	err = namedStmt.GetContext(ctx, &record, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+	return record.ConvertToModel(), nil
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository_Reserve(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	newRecord := func(key string) *model.IdempotencyRecord {
+		return &model.IdempotencyRecord{
+			Scope:       "anonymous:192.0.2.1",
+			Key:         key,
+			Method:      "POST",
+			Path:        "/v1/users/",
+			RequestHash: "0000000000000000000000000000000000000000000000000000000000000000",
+		}
+	}
+
+	t.Run("completed response is stored with its headers", func(t *testing.T) {
+		stored, created, err := idempotencyRepo.Reserve(ctx, newRecord("key-1"))
+		require.NoError(t, err)
+		require.True(t, created)
+		stored.StatusCode = 201
+		stored.ContentType = "application/json"
+		stored.Headers = map[string]string{"Location": "/v1/users/usr-1"}
+		stored.ResponseBody = []byte(`{}`)
+		require.NoError(t, idempotencyRepo.Complete(ctx, stored))
+
+		replayed, created, err := idempotencyRepo.Reserve(ctx, newRecord("key-1"))
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, 201, replayed.StatusCode)
+		assert.Equal(t, stored.Headers, replayed.Headers)
+	})
+
+	t.Run("request in flight past its lease is taken over", func(t *testing.T) {
+		crashed, created, err := idempotencyRepo.Reserve(ctx, newRecord("key-2"))
+		require.NoError(t, err)
+		require.True(t, created)
+
+		_, created, err = idempotencyRepo.Reserve(ctx, newRecord("key-2"))
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		_, err = db.DB.Exec(`UPDATE eagle.idempotency_keys SET created_at = $1 WHERE idempotency_key = 'key-2'`,
+			time.Now().Add(-2*time.Minute))
+		require.NoError(t, err)
+		retry, created, err := idempotencyRepo.Reserve(ctx, newRecord("key-2"))
+		require.NoError(t, err)
+		require.True(t, created)
+
+		// the original request finishing late leaves the new reservation alone
+		crashed.StatusCode = 201
+		require.NoError(t, idempotencyRepo.Complete(ctx, crashed))
+		require.NoError(t, idempotencyRepo.Release(ctx, crashed))
+		_, created, err = idempotencyRepo.Reserve(ctx, newRecord("key-2"))
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		require.NoError(t, idempotencyRepo.Release(ctx, retry))
+		_, created, err = idempotencyRepo.Reserve(ctx, newRecord("key-2"))
+		require.NoError(t, err)
+		assert.True(t, created)
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+/**
+ * LoginAttemptRepository implements port.LoginAttemptRepository interface
+ * and provides access to the postgres database
+ */
+
+type LoginAttemptRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewLoginAttemptRepository creates a new login attempt repository instance
+func NewLoginAttemptRepository(db *postgres.DBContext) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db,
+	}
+}
+
+func (lr *LoginAttemptRepository) GetFailedLogins(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+	var failed dao.FailedLoginsDAO
+	err := lr.pg.DB.GetContext(ctx, &failed, `
+		SELECT failures, last_failure_at
+		FROM eagle.failed_logins
+		WHERE scope = $1 AND login_key = $2 AND last_failure_at >= $3`,
+		scope, key, since.UTC())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &model.FailedLogins{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to get failed logins")
+	}
+	return failed.ConvertToModel(), nil
+}
+
+func (lr *LoginAttemptRepository) RecordFailedLogin(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+	var failed dao.FailedLoginsDAO
+	err := lr.pg.DB.GetContext(ctx, &failed, `
+		INSERT INTO eagle.failed_logins (scope, login_key, failures, last_failure_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, login_key) DO UPDATE
+		SET failures = CASE
+				WHEN eagle.failed_logins.last_failure_at < $4 THEN 1
+				ELSE eagle.failed_logins.failures + 1
+			END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures, last_failure_at`,
+		scope, key, time.Now().UTC(), since.UTC())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to record failed login")
+	}
+	return failed.ConvertToModel(), nil
+}
+
+func (lr *LoginAttemptRepository) ResetFailedLogins(ctx context.Context, scope string, key string) error {
+	return resetFailedLogins(ctx, lr.pg.DB, scope, key)
+}
+
+// resetFailedLogins forgets the failed logins counted against the key.
+func resetFailedLogins(ctx context.Context, db sqlx.ExecerContext, scope string, key string) error {
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM eagle.failed_logins
+		WHERE scope = $1 AND login_key = $2`, scope, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to reset failed logins")
+	}
+	return nil
+}
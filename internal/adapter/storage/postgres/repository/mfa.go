@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+/**
+ * MFARepository implements port.MFARepository interface
+ * and provides access to the postgres database
+ */
+
+type MFARepository struct {
+	pg *postgres.DBContext
+}
+
+// NewMFARepository creates a new MFA repository instance
+func NewMFARepository(db *postgres.DBContext) *MFARepository {
+	return &MFARepository{
+		db,
+	}
+}
+
+func (mr *MFARepository) SaveTOTPSecret(ctx context.Context, userID string, secret []byte) error {
+	result, err := mr.pg.DB.ExecContext(ctx, `
+		INSERT INTO eagle.user_totp (user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at
+		WHERE eagle.user_totp.enabled_at IS NULL`,
+		userID, secret, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to save TOTP secret")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to save TOTP secret")
+	}
+	if rows == 0 {
+		return model.ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+func (mr *MFARepository) GetTOTP(ctx context.Context, userID string) (*model.TOTP, error) {
+	var totp dao.TOTPDAO
+	err := mr.pg.DB.GetContext(ctx, &totp, `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at
+		FROM eagle.user_totp
+		WHERE user_id = $1`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrTOTPNotEnrolled
+		}
+		return nil, errors.Wrap(err, "failed to get TOTP enrolment")
+	}
+	return totp.ConvertToModel(), nil
+}
+
+func (mr *MFARepository) ActivateTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := mr.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE eagle.user_totp
+		SET enabled_at = $1, last_used_step = $2
+		WHERE user_id = $3
+		AND enabled_at IS NULL`, now, step, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to enable TOTP")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		err = model.ErrTOTPAlreadyEnabled
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM eagle.totp_recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove old recovery codes")
+	}
+	for _, hash := range recoveryCodeHashes {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO eagle.totp_recovery_codes (user_id, code_hash, created_at)
+			VALUES ($1, $2, $3)`, userID, hash, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to save recovery code")
+		}
+	}
+
+	err = insertAuditRecord(ctx, tx, model.AuditRecord{
+		ActorID:    userID,
+		ActorType:  model.AuditActorUser,
+		Action:     model.AuditActionTOTPEnabled,
+		EntityType: model.AuditEntityUser,
+		EntityID:   userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+func (mr *MFARepository) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result, err := mr.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.user_totp
+		SET last_used_step = $1
+		WHERE user_id = $2
+		AND enabled_at IS NOT NULL
+		AND (last_used_step IS NULL OR last_used_step < $1)`, step, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to record TOTP use")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (mr *MFARepository) UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error) {
+	result, err := mr.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.totp_recovery_codes
+		SET used_at = $1
+		WHERE user_id = $2
+		AND code_hash = $3
+		AND used_at IS NULL`, time.Now().UTC(), userID, codeHash)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to use recovery code")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+/**
+ * OutboxRepository implements port.OutboxRepository interface
+ * and provides access to the postgres database
+ */
+
+type OutboxRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *postgres.DBContext) *OutboxRepository {
+	return &OutboxRepository{
+		db,
+	}
+}
+
+// insertOutboxEvent records an event inside tx, so it is only published if
+// the change it describes commits.
+func insertOutboxEvent(ctx context.Context, tx *sqlx.Tx, eventType string, aggregateID string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event payload")
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO eagle.outbox_events (id, event_type, aggregate_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)`, uuid.NewString(), eventType, aggregateID, body, time.Now().UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to record event")
+	}
+	return nil
+}
+
+func (or *OutboxRepository) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+	now := time.Now().UTC()
+	var rows []dao.OutboxEventDAO
+	err := or.pg.DB.SelectContext(ctx, &rows, `
+		UPDATE eagle.outbox_events
+		SET next_attempt_at = $1, attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM eagle.outbox_events
+			WHERE published_at IS NULL
+			AND next_attempt_at <= $2
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, aggregate_id, payload, attempts, created_at`, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim outbox events")
+	}
+
+	events := make([]model.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, row.ConvertToModel())
+	}
+	return events, nil
+}
+
+func (or *OutboxRepository) MarkPublished(ctx context.Context, id string) error {
+	_, err := or.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.outbox_events
+		SET published_at = $1, last_error = NULL
+		WHERE id = $2`, time.Now().UTC(), id)
+	if err != nil {
+		return errors.Wrap(err, "failed to mark event published")
+	}
+	return nil
+}
+
+func (or *OutboxRepository) MarkFailed(ctx context.Context, id string, cause error, nextAttemptAt time.Time) error {
+	_, err := or.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.outbox_events
+		SET next_attempt_at = $1, last_error = $2
+		WHERE id = $3`, nextAttemptAt.UTC(), cause.Error(), id)
+	if err != nil {
+		return errors.Wrap(err, "failed to mark event failed")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	outboxRepo := repository.NewOutboxRepository(db)
+	ctx := context.Background()
+
+	userID := createActiveUser(t, db, "events@example.com")
+
+	events, err := outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	event := events[0]
+
+	t.Run("user created event carries only the user's ID", func(t *testing.T) {
+		assert.Equal(t, model.EventUserCreated, event.Type)
+		assert.Equal(t, 1, event.Attempts)
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		assert.Equal(t, map[string]interface{}{"userId": userID}, payload)
+	})
+
+	t.Run("claimed event is leased", func(t *testing.T) {
+		events, err := outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("failed event is claimed again once due", func(t *testing.T) {
+		require.NoError(t, outboxRepo.MarkFailed(ctx, event.ID, errors.New("broker unavailable"), time.Now().Add(-time.Second)))
+
+		events, err := outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, event.ID, events[0].ID)
+		assert.Equal(t, 2, events[0].Attempts)
+	})
+
+	t.Run("published event is not claimed again", func(t *testing.T) {
+		require.NoError(t, outboxRepo.MarkPublished(ctx, event.ID))
+		_, err := db.DB.Exec(`UPDATE eagle.outbox_events SET next_attempt_at = $1`, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		events, err := outboxRepo.ClaimEvents(ctx, 10, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/dao"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+/**
+ * RefreshTokenRepository implements port.RefreshTokenRepository interface
+ * and provides access to the postgres database
+ */
+
+type RefreshTokenRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository instance
+func NewRefreshTokenRepository(db *postgres.DBContext) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db,
+	}
+}
+
+func (rr *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	if token == nil {
+		return errors.New("refresh token cannot be nil")
+	}
+	tokenQuery := `	INSERT INTO eagle.refresh_tokens (id, family_id, user_id, expires_at, created_at)
+				VALUES (:id, :family_id, :user_id, :expires_at, :created_at)`
+	_, err := rr.pg.DB.NamedExecContext(ctx, tokenQuery, dao.ConvertRefreshTokenFromModel(token))
+	if err != nil {
+		return errors.Wrap(err, "failed to store refresh token")
+	}
+	return nil
+}
+
+func (rr *RefreshTokenRepository) GetRefreshToken(ctx context.Context, id string) (*model.RefreshToken, error) {
+	query := `SELECT id, family_id, user_id, expires_at, used_at, revoked_at, created_at
+				FROM eagle.refresh_tokens
+				WHERE id = :id`
+
+	var token dao.RefreshTokenDAO
+	namedStmt, err := rr.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"id": id,
+	}
+	err = namedStmt.GetContext(ctx, &token, args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvalidToken
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+	return token.ConvertToModel(), nil
+}
+
+func (rr *RefreshTokenRepository) UseRefreshToken(ctx context.Context, id string) (bool, error) {
+	result, err := rr.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.refresh_tokens
+		SET used_at = $1
+		WHERE id = $2
+		AND used_at IS NULL
+		AND revoked_at IS NULL
+		AND expires_at > $1`, time.Now().UTC(), id)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to use refresh token")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (rr *RefreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := rr.pg.DB.ExecContext(ctx, `
+		UPDATE eagle.refresh_tokens
+		SET revoked_at = $1
+		WHERE family_id = $2
+		AND revoked_at IS NULL`, time.Now().UTC(), familyID)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke refresh token family")
+	}
+	return nil
+}
+
+func (rr *RefreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	return revokeUserRefreshTokens(ctx, rr.pg.DB, userID)
+}
+
+// revokeUserRefreshTokens revokes every refresh token the user still holds.
+func revokeUserRefreshTokens(ctx context.Context, db sqlx.ExecerContext, userID string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE eagle.refresh_tokens
+		SET revoked_at = $1
+		WHERE user_id = $2
+		AND revoked_at IS NULL`, time.Now().UTC(), userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke user refresh tokens")
+	}
+	return nil
+}
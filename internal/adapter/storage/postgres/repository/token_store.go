@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+/**
+ * TokenStore implements port.TokenStore interface
+ * and provides access to the postgres database
+ */
+
+type TokenStore struct {
+	pg *postgres.DBContext
+}
+
+// NewTokenStore creates a new postgres backed token store instance
+func NewTokenStore(db *postgres.DBContext) *TokenStore {
+	return &TokenStore{
+		db,
+	}
+}
+
+func (ts *TokenStore) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	now := time.Now().UTC()
+	if _, err := ts.pg.DB.ExecContext(ctx, `DELETE FROM eagle.revoked_tokens WHERE expires_at <= $1`, now); err != nil {
+		return errors.Wrap(err, "failed to prune revoked tokens")
+	}
+	_, err := ts.pg.DB.ExecContext(ctx, `
+		INSERT INTO eagle.revoked_tokens (token_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token_id) DO NOTHING`, tokenID, expiresAt.UTC(), now)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke token")
+	}
+	return nil
+}
+
+func (ts *TokenStore) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var revoked bool
+	err := ts.pg.DB.GetContext(ctx, &revoked, `
+		SELECT EXISTS (
+			SELECT 1 FROM eagle.revoked_tokens
+			WHERE token_id = $1
+			AND expires_at > $2
+		)`, tokenID, time.Now().UTC())
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check revoked tokens")
+	}
+	return revoked, nil
+}
+
+func (ts *TokenStore) RevokeUser(ctx context.Context, userID string) error {
+	return revokeUserTokens(ctx, ts.pg.DB, userID)
+}
+
+// revokeUserTokens bumps the user's token generation, so that every access
+// token issued before it is rejected.
+func revokeUserTokens(ctx context.Context, db sqlx.ExecerContext, userID string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO eagle.user_token_generations (user_id, generation)
+		VALUES ($1, 1)
+		ON CONFLICT (user_id) DO UPDATE
+		SET generation = user_token_generations.generation + 1`, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke user tokens")
+	}
+	return nil
+}
+
+func (ts *TokenStore) UserGeneration(ctx context.Context, userID string) (int64, error) {
+	var generation int64
+	err := ts.pg.DB.GetContext(ctx, &generation, `
+		SELECT COALESCE(MAX(generation), 0)
+		FROM eagle.user_token_generations
+		WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get user token generation")
+	}
+	return generation, nil
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository/entity"
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+/**
+ * TransactionRepository implements port.TransactionRepository interface
+ * and provides access to the postgres database
+ */
+
+type TransactionRepository struct {
+	pg *postgres.DBContext
+}
+
+// NewTransactionRepository creates a new transaction repository instance
+func NewTransactionRepository(db *postgres.DBContext) *TransactionRepository {
+	return &TransactionRepository{
+		db,
+	}
+}
+
+// CreateTransaction writes a ledger row and applies it to the account balance
+// within a single database transaction. The account row is locked for the
+// duration so that concurrent transactions against it are serialised.
+func (tr *TransactionRepository) CreateTransaction(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+	if newTransaction == nil {
+		return nil, errors.New("new transaction cannot be nil")
+	}
+
+	tx, err := tr.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	account, err := lockAccount(ctx, tx, newTransaction.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := postTransaction(ctx, tx, account, newTransaction, nil, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return tr.GetTransaction(ctx, transaction.AccountNumber(), transaction.ID())
+}
+
+// CreateTransfer moves money between two eagle accounts as a single database
+// transaction, posting a withdrawal to the payer and a deposit to the payee
+// which share the transfer ID. Each leg is recorded against the user who owns
+// its account. Both account rows are locked in account number order so that
+// opposing transfers cannot deadlock.
+func (tr *TransactionRepository) CreateTransfer(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error) {
+	if newTransfer == nil {
+		return nil, errors.New("new transfer cannot be nil")
+	}
+
+	tx, err := tr.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	accountNumbers := []string{newTransfer.FromAccountNumber, newTransfer.ToAccountNumber}
+	sort.Strings(accountNumbers)
+
+	accounts := make(map[string]*entity.AccountDAO, len(accountNumbers))
+	for _, accountNumber := range accountNumbers {
+		var account *entity.AccountDAO
+		account, err = lockAccount(ctx, tx, accountNumber)
+		if err != nil {
+			if accountNumber == newTransfer.ToAccountNumber && errors.Is(err, model.ErrAccountNotFound) {
+				return nil, errors.Wrap(err, "payee")
+			}
+			return nil, err
+		}
+		accounts[accountNumber] = account
+	}
+
+	now := time.Now().UTC()
+	_, err = tx.NamedExecContext(ctx, `	INSERT INTO eagle.transfers (id, from_account_number, to_account_number, user_id, amount, currency, reference, created_at)
+				VALUES (:id, :from_account_number, :to_account_number, :user_id, :amount, :currency, :reference, :created_at)`,
+		map[string]interface{}{
+			"id":                  newTransfer.TransferID,
+			"from_account_number": newTransfer.FromAccountNumber,
+			"to_account_number":   newTransfer.ToAccountNumber,
+			"user_id":             newTransfer.UserID,
+			"amount":              newTransfer.Amount,
+			"currency":            newTransfer.Currency,
+			"reference":           newTransfer.Reference,
+			"created_at":          now,
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "error encountered creating transfer")
+	}
+
+	debit, err := postTransaction(ctx, tx, accounts[newTransfer.FromAccountNumber], &model.NewTransaction{
+		AccountNumber: newTransfer.FromAccountNumber,
+		UserID:        newTransfer.UserID,
+		Amount:        newTransfer.Amount,
+		Currency:      newTransfer.Currency,
+		Type:          model.TransactionWithdrawalType,
+		Reference:     newTransfer.Reference,
+		TransactionID: newTransfer.DebitTransactionID,
+	}, &newTransfer.TransferID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = postTransaction(ctx, tx, accounts[newTransfer.ToAccountNumber], &model.NewTransaction{
+		AccountNumber: newTransfer.ToAccountNumber,
+		UserID:        newTransfer.PayeeUserID,
+		Amount:        newTransfer.Amount,
+		Currency:      newTransfer.Currency,
+		Type:          model.TransactionDepositType,
+		Reference:     newTransfer.Reference,
+		TransactionID: newTransfer.CreditTransactionID,
+	}, &newTransfer.TransferID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	debitTransaction, err := tr.GetTransaction(ctx, debit.AccountNumber(), debit.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Transfer{
+		ID:                newTransfer.TransferID,
+		FromAccountNumber: newTransfer.FromAccountNumber,
+		ToSortCode:        model.EagleSortCode,
+		ToAccountNumber:   newTransfer.ToAccountNumber,
+		Amount:            newTransfer.Amount,
+		Currency:          newTransfer.Currency,
+		Reference:         newTransfer.Reference,
+		Transaction:       *debitTransaction,
+		CreatedTimestamp:  now,
+	}, nil
+}
+
+// lockAccount takes a row lock on an open account for the rest of tx.
+// Frozen accounts are rejected so no money moves in or out of them.
+func lockAccount(ctx context.Context, tx *sqlx.Tx, accountNumber string) (*entity.AccountDAO, error) {
+	var account entity.AccountDAO
+	err := tx.GetContext(ctx, &account, `SELECT account_number, status, balance, currency
+				FROM eagle.accounts
+				WHERE account_number = $1
+				AND status <> 'closed'
+				FOR UPDATE`, accountNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccountNotFound
+		}
+		return nil, errors.Wrap(err, "failed to lock account")
+	}
+	if account.Status == model.AccountFrozenStatus {
+		return nil, model.ErrAccountFrozen
+	}
+	return &account, nil
+}
+
+// postTransaction applies a transaction to an account locked by lockAccount
+// and records it in the ledger. The account balance held in memory is kept in
+// step so the same account can be posted to more than once within tx.
+func postTransaction(ctx context.Context, tx *sqlx.Tx, account *entity.AccountDAO, newTransaction *model.NewTransaction, transferID *string, now time.Time) (*entity.Transaction, error) {
+	if account.Currency != newTransaction.Currency {
+		return nil, model.ErrInvalidTransaction
+	}
+
+	balance := account.Balance
+	switch newTransaction.Type {
+	case model.TransactionDepositType:
+		balance = balance.Add(newTransaction.Amount)
+	case model.TransactionWithdrawalType:
+		balance = balance.Sub(newTransaction.Amount)
+	}
+	if balance.LessThan(decimal.Zero) {
+		return nil, model.ErrInsufficientFunds
+	}
+
+	transaction, err := entity.NewTransaction(
+		entity.WithTransactionID(newTransaction.TransactionID),
+		entity.WithTransactionAccountNumber(newTransaction.AccountNumber),
+		entity.WithTransactionUserID(newTransaction.UserID),
+		entity.WithTransactionAmount(newTransaction.Amount),
+		entity.WithTransactionCurrency(newTransaction.Currency),
+		entity.WithTransactionType(newTransaction.Type),
+		entity.WithTransactionReference(newTransaction.Reference),
+		entity.WithTransactionTransferID(transferID),
+		entity.WithTransactionBalanceAfter(balance),
+		entity.WithTransactionCreatedAt(now),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.accounts
+		SET balance = $1, updated_at = $2, version = version + 1
+		WHERE account_number = $3`, balance, now, account.AccountNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update account balance")
+	}
+
+	transactionQuery := `	INSERT INTO eagle.transactions (id, account_number, user_id, amount, currency, transaction_type, reference, transfer_id, balance_after, created_at)
+				VALUES (:id, :account_number, :user_id, :amount, :currency, :transaction_type, :reference, :transfer_id, :balance_after, :created_at)`
+
+	_, err = tx.NamedExecContext(ctx, transactionQuery, transaction.FromEntity())
+	if err != nil {
+		return nil, errors.Wrap(err, "error encountered creating transaction")
+	}
+
+	err = insertOutboxEvent(ctx, tx, model.EventTransactionPosted, transaction.ID(), model.TransactionPostedEvent{
+		TransactionID: transaction.ID(),
+		AccountNumber: transaction.AccountNumber(),
+		UserID:        transaction.UserID(),
+		Type:          transaction.TransactionType(),
+		Amount:        transaction.Amount(),
+		Currency:      transaction.Currency(),
+		BalanceAfter:  balance,
+		TransferID:    transferID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account.Balance = balance
+	return &transaction, nil
+}
+
+func (tr *TransactionRepository) ListTransactions(ctx context.Context, accountNumber string) ([]model.Transaction, error) {
+	query := `SELECT id, account_number, user_id, amount, currency, transaction_type, reference, transfer_id, balance_after, created_at
+				FROM eagle.transactions
+				WHERE account_number = :account_number
+				ORDER BY created_at DESC`
+
+	var rows []entity.TransactionDAO
+	namedStmt, err := tr.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"account_number": accountNumber,
+	}
+	err = namedStmt.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+
+	transactions := make([]model.Transaction, 0, len(rows))
+	for _, row := range rows {
+		transactions = append(transactions, *row.ConvertToModel())
+	}
+	return transactions, nil
+}
+
+func (tr *TransactionRepository) GetTransaction(ctx context.Context, accountNumber string, transactionID string) (*model.Transaction, error) {
+	query := `SELECT id, account_number, user_id, amount, currency, transaction_type, reference, transfer_id, balance_after, created_at
+				FROM eagle.transactions
+				WHERE account_number = :account_number
+				AND id = :id`
+
+	var transaction entity.TransactionDAO
+	namedStmt, err := tr.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"account_number": accountNumber,
+		"id":             transactionID,
+	}
+	err = namedStmt.GetContext(ctx, &transaction, args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrTransactionNotFound
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+
+	return transaction.ConvertToModel(), nil
+}
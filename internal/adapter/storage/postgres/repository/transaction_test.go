@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/service"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionRepository_CreateTransfer(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	transactionRepo := repository.NewTransactionRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	ctx := context.Background()
+
+	payerID := createActiveUser(t, db, "payer@example.com")
+	payeeID := createActiveUser(t, db, "payee@example.com")
+	payerAccount := openAccount(t, db, payerID)
+	payeeAccount := openAccount(t, db, payeeID)
+	_, err := transactionRepo.CreateTransaction(ctx, &model.NewTransaction{
+		AccountNumber: payerAccount,
+		UserID:        payerID,
+		Amount:        decimal.NewFromInt(50),
+		Currency:      "GBP",
+		Type:          model.TransactionDepositType,
+		TransactionID: service.GenerateTransactionID(),
+	})
+	require.NoError(t, err)
+
+	newTransfer := func(amount int64) *model.NewTransfer {
+		return &model.NewTransfer{
+			FromAccountNumber:   payerAccount,
+			ToSortCode:          model.EagleSortCode,
+			ToAccountNumber:     payeeAccount,
+			UserID:              payerID,
+			PayeeUserID:         payeeID,
+			Amount:              decimal.NewFromInt(amount),
+			Currency:            "GBP",
+			TransferID:          service.GenerateTransferID(),
+			DebitTransactionID:  service.GenerateTransactionID(),
+			CreditTransactionID: service.GenerateTransactionID(),
+		}
+	}
+
+	t.Run("each leg is recorded against its account's owner", func(t *testing.T) {
+		transfer := newTransfer(20)
+		_, err := transactionRepo.CreateTransfer(ctx, transfer)
+		require.NoError(t, err)
+
+		debit, err := transactionRepo.GetTransaction(ctx, payerAccount, transfer.DebitTransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, payerID, debit.UserID)
+		assert.Equal(t, model.TransactionWithdrawalType, debit.Type)
+		credit, err := transactionRepo.GetTransaction(ctx, payeeAccount, transfer.CreditTransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, payeeID, credit.UserID)
+		assert.Equal(t, model.TransactionDepositType, credit.Type)
+	})
+
+	t.Run("insufficient funds moves nothing", func(t *testing.T) {
+		_, err := transactionRepo.CreateTransfer(ctx, newTransfer(100))
+		require.ErrorIs(t, err, model.ErrInsufficientFunds)
+
+		payer, err := accountRepo.GetAccount(ctx, payerAccount)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(30).Equal(payer.Balance))
+		payee, err := accountRepo.GetAccount(ctx, payeeAccount)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(20).Equal(payee.Balance))
+	})
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"eagle-bank.com/internal/adapter/storage/postgres"
@@ -31,7 +34,7 @@ func NewUserRepository(db *postgres.DBContext) *UserRepository {
 		db,
 	}
 }
-func (ur *UserRepository) CreateUser(newUser *model.NewUser) (*model.User, error) {
+func (ur *UserRepository) CreateUser(ctx context.Context, actor model.Actor, newUser *model.NewUser) (*model.User, error) {
 	if newUser == nil {
 		return nil, errors.New("new user cannot be nil")
 	}
@@ -64,7 +67,7 @@ func (ur *UserRepository) CreateUser(newUser *model.NewUser) (*model.User, error
 		return nil, err
 	}
 
-	newVerificationToken := entity.ID(uuid.NewString())
+	newVerificationToken := entity.ID(newUser.VerificationToken)
 	token, err := entity.NewVerificationToken(
 		entity.WithVerificationTokenID(newVerificationToken),
 		entity.WithVerificationTokenUserID(newUserID),
@@ -74,7 +77,7 @@ func (ur *UserRepository) CreateUser(newUser *model.NewUser) (*model.User, error
 		return nil, err
 	}
 
-	tx, err := ur.pg.DB.Beginx()
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -97,7 +100,7 @@ func (ur *UserRepository) CreateUser(newUser *model.NewUser) (*model.User, error
 	userQuery := `	INSERT INTO eagle.users (id, name, email, phone_number, status, created_at) 
 				VALUES (:id, :name, :email, :phone_number, :status, :created_at)`
 
-	_, err = tx.NamedExec(userQuery, user.FromEntity())
+	_, err = tx.NamedExecContext(ctx, userQuery, user.FromEntity())
 	if err != nil {
 		if pgErr := new(pq.Error); errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" {
@@ -111,14 +114,757 @@ func (ur *UserRepository) CreateUser(newUser *model.NewUser) (*model.User, error
 	userAddressQuery := `	INSERT INTO eagle.addresses (id, user_id, line1, line2, line3, town, county, postcode, created_at) 
 				VALUES (:id, :user_id, :line1, :line2, :line3, :town, :county, :postcode, :created_at)`
 
-	_, err = tx.NamedExec(userAddressQuery, userAddress.FromEntity())
+	_, err = tx.NamedExecContext(ctx, userAddressQuery, userAddress.FromEntity())
 	if err != nil {
 		return nil, err
 	}
 
-	tokenQuery := `	INSERT INTO eagle.user_verification_tokens (token, user_id, expires_at) 
-				VALUES (:token, :user_id, :expires_at)`
-	_, err = tx.NamedExec(tokenQuery, token.FromEntity())
+	tokenQuery := `	INSERT INTO eagle.user_verification_tokens (token, user_id, expires_at, created_at) 
+				VALUES (:token, :user_id, :expires_at, :created_at)`
+	_, err = tx.NamedExecContext(ctx, tokenQuery, token.FromEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	err = insertOutboxEvent(ctx, tx, model.EventUserCreated, newUserID.String(), model.UserCreatedEvent{
+		UserID: newUserID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := userProfile(ctx, tx, newUserID.String())
+	if err != nil {
+		return nil, err
+	}
+	err = recordChange(ctx, tx, actor, model.AuditActionUserCreated, model.AuditEntityUser, newUserID.String(), nil, userSnapshot(profile))
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return ur.GetUserByID(ctx, newUserID.String())
+}
+
+func (ur *UserRepository) GetUserByEmailVerificationToken(ctx context.Context, emailToken string) (*model.User, error) {
+	if emailToken == "" {
+		return nil, errors.New("emailToken cannot be empty")
+	}
+	query := `	SELECT token, user_id, expires_at, used_at, created_at 
+				FROM eagle.user_verification_tokens
+				WHERE token = :token
+				`
+
+	var verificationToken entity.VerificationTokenDAO
+	namedStmt, err := ur.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"token": emailToken,
+	}
+	err = namedStmt.GetContext(ctx, &verificationToken, args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrVerificationTokenNotFound
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+
+	return ur.GetUserByID(ctx, verificationToken.UserID.String())
+}
+
+// GetUserByPasswordResetToken returns the user a reset token was issued to.
+// The token is not checked for expiry or use; ResetPassword does that.
+func (ur *UserRepository) GetUserByPasswordResetToken(ctx context.Context, resetToken string) (*model.User, error) {
+	var userID uuid.UUID
+	err := ur.pg.DB.GetContext(ctx, &userID, `
+		SELECT user_id
+		FROM eagle.password_reset_tokens
+		WHERE token = $1`, resetToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvalidPasswordResetToken
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+	return ur.GetUserByID(ctx, userID.String())
+}
+
+func (ur *UserRepository) VerifyEmail(ctx context.Context, actor model.Actor, emailToken string) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Lock the token so a concurrent request cannot also use it
+	var verificationToken entity.VerificationTokenDAO
+	err = tx.GetContext(ctx, &verificationToken, `
+		SELECT token, user_id, expires_at, used_at, created_at
+		FROM eagle.user_verification_tokens
+		WHERE token = $1
+		FOR UPDATE`, emailToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = model.ErrVerificationTokenNotFound
+		}
+		return err
+	}
+	now := time.Now().UTC()
+	if verificationToken.UsedAt != nil {
+		err = model.ErrVerificationTokenUsed
+		return err
+	}
+	if !verificationToken.ExpiresAt.After(now) {
+		err = model.ErrVerificationTokenExpired
+		return err
+	}
+	userID := verificationToken.UserID.String()
+
+	status, err := lockUserStatus(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	err = model.CheckUserTransition(status, model.UserStatusEmailVerified)
+	if err != nil {
+		return err
+	}
+
+	// Mark token as used
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.user_verification_tokens
+		SET used_at = $1
+		WHERE token = $2`, now, emailToken)
+	if err != nil {
+		return err
+	}
+
+	// Update user record to set status
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.users
+		SET status = $1, updated_at = $2, version = version + 1
+		WHERE id = $3`, model.UserStatusEmailVerified, now, userID)
+	if err != nil {
+		return err
+	}
+
+	err = insertOutboxEvent(ctx, tx, model.EventEmailVerified, userID, model.EmailVerifiedEvent{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionEmailVerified, model.AuditEntityUser, userID,
+		statusSnapshot(status), statusSnapshot(model.UserStatusEmailVerified))
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// ReplaceVerificationToken issues a new verification token for a user still
+// awaiting verification, replacing the old one unless it was issued after
+// notIssuedSince.
+func (ur *UserRepository) ReplaceVerificationToken(ctx context.Context, actor model.Actor, email string, emailToken string, notIssuedSince time.Time) (*model.User, error) {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var user entity.UserDAO
+	err = tx.GetContext(ctx, &user, `
+		SELECT id, status
+		FROM eagle.users
+		WHERE email = $1
+		FOR UPDATE`, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = model.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.Status != entity.UserAwaitingVerificationStatus {
+		err = model.ErrUserAlreadyVerified
+		return nil, err
+	}
+
+	var issuedAt time.Time
+	err = tx.GetContext(ctx, &issuedAt, `
+		SELECT created_at
+		FROM eagle.user_verification_tokens
+		WHERE user_id = $1`, user.ID)
+	if err == nil && issuedAt.After(notIssuedSince) {
+		err = model.ErrVerificationResendTooSoon
+		return nil, err
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	token, err := entity.NewVerificationToken(
+		entity.WithVerificationTokenID(entity.ID(emailToken)),
+		entity.WithVerificationTokenUserID(entity.ID(user.ID)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM eagle.user_verification_tokens WHERE user_id = $1`, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenQuery := `	INSERT INTO eagle.user_verification_tokens (token, user_id, expires_at, created_at) 
+				VALUES (:token, :user_id, :expires_at, :created_at)`
+	_, err = tx.NamedExecContext(ctx, tokenQuery, token.FromEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionVerificationSent, model.AuditEntityUser, user.ID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return ur.GetUserByID(ctx, user.ID)
+}
+
+// ReplacePasswordResetToken issues a new password reset token for a user with
+// a password, replacing any earlier token unless it was issued after
+// notIssuedSince.
+func (ur *UserRepository) ReplacePasswordResetToken(ctx context.Context, actor model.Actor, email string, resetToken string, notIssuedSince time.Time) (*model.User, error) {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// users without a password must verify their email address instead
+	var userID string
+	err = tx.GetContext(ctx, &userID, `
+		SELECT id
+		FROM eagle.users
+		WHERE email = $1
+		AND password_hash IS NOT NULL
+		FOR UPDATE`, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	var issuedAt time.Time
+	err = tx.GetContext(ctx, &issuedAt, `
+		SELECT created_at
+		FROM eagle.password_reset_tokens
+		WHERE user_id = $1`, userID)
+	if err == nil && issuedAt.After(notIssuedSince) {
+		err = model.ErrPasswordResetTooSoon
+		return nil, err
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	token, err := entity.NewPasswordResetToken(
+		entity.WithPasswordResetTokenID(entity.ID(resetToken)),
+		entity.WithPasswordResetTokenUserID(entity.ID(userID)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM eagle.password_reset_tokens WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenQuery := `	INSERT INTO eagle.password_reset_tokens (token, user_id, expires_at, created_at) 
+				VALUES (:token, :user_id, :expires_at, :created_at)`
+	_, err = tx.NamedExecContext(ctx, tokenQuery, token.FromEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionResetRequested, model.AuditEntityUser, userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return ur.GetUserByID(ctx, userID)
+}
+
+// ResetPassword uses a password reset token to replace the user's password.
+// Proving control of the email address also lifts any lockout and clears the
+// failed logins counted against loginKey, and every existing session of the
+// user is revoked, all in the same transaction as the new password.
+func (ur *UserRepository) ResetPassword(ctx context.Context, actor model.Actor, resetToken string, hash []byte, loginKey string) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Lock the token so a concurrent request cannot also use it
+	var token entity.PasswordResetTokenDAO
+	err = tx.GetContext(ctx, &token, `
+		SELECT token, user_id, expires_at, used_at, created_at
+		FROM eagle.password_reset_tokens
+		WHERE token = $1
+		FOR UPDATE`, resetToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = model.ErrInvalidPasswordResetToken
+		}
+		return err
+	}
+	now := time.Now().UTC()
+	if token.UsedAt != nil || !token.ExpiresAt.After(now) {
+		err = model.ErrInvalidPasswordResetToken
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.password_reset_tokens
+		SET used_at = $1
+		WHERE token = $2`, now, resetToken)
+	if err != nil {
+		return err
+	}
+
+	userID := token.UserID.String()
+	err = replacePasswordHash(ctx, tx, userID, hash, now)
+	if err != nil {
+		return err
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionPasswordReset, model.AuditEntityUser, userID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = unlockUser(ctx, tx, actor, userID); err != nil {
+		return err
+	}
+	if err = resetFailedLogins(ctx, tx, model.LoginScopeEmail, loginKey); err != nil {
+		return err
+	}
+	if err = revokeUserTokens(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err = revokeUserRefreshTokens(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// GetPasswordHashes returns the user's current password hash followed by up
+// to limit-1 previous hashes, newest first.
+func (ur *UserRepository) GetPasswordHashes(ctx context.Context, userID string, limit int) ([]string, error) {
+	var hashes []string
+	err := ur.pg.DB.SelectContext(ctx, &hashes, `
+		SELECT password_hash FROM (
+			SELECT password_hash, updated_at AS changed_at, 0 AS position
+			FROM eagle.users
+			WHERE id = $1
+			AND password_hash IS NOT NULL
+			UNION ALL
+			SELECT password_hash, created_at AS changed_at, 1 AS position
+			FROM eagle.password_history
+			WHERE user_id = $1
+		) hashes
+		ORDER BY position, changed_at DESC
+		LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get password history")
+	}
+	return hashes, nil
+}
+
+// ChangePassword replaces the password of a logged in user, keeping the old
+// hash in the password history and recording the change in the audit log.
+// Every existing session of the user is revoked in the same transaction.
+func (ur *UserRepository) ChangePassword(ctx context.Context, actor model.Actor, userID string, hash []byte) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	err = replacePasswordHash(ctx, tx, userID, hash, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionPasswordChanged, model.AuditEntityUser, userID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = revokeUserTokens(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err = revokeUserRefreshTokens(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// replacePasswordHash moves the user's current hash into the password history
+// and sets the new one, satisfying any reset forced by an administrator. Only
+// the hashes needed to refuse reuse of recent passwords are kept.
+func replacePasswordHash(ctx context.Context, tx *sqlx.Tx, userID string, hash []byte, now time.Time) error {
+	var current *string
+	err := tx.GetContext(ctx, &current, `
+		SELECT password_hash
+		FROM eagle.users
+		WHERE id = $1
+		FOR UPDATE`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrUserNotFound
+		}
+		return err
+	}
+	if current != nil {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO eagle.password_history (user_id, password_hash, created_at)
+			VALUES ($1, $2, $3)`, userID, *current, now)
+		if err != nil {
+			return errors.Wrap(err, "failed to record password history")
+		}
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM eagle.password_history
+			WHERE user_id = $1
+			AND id NOT IN (
+				SELECT id FROM eagle.password_history
+				WHERE user_id = $1
+				ORDER BY created_at DESC, id DESC
+				LIMIT $2
+			)`, userID, model.PasswordHistoryDepth-1)
+		if err != nil {
+			return errors.Wrap(err, "failed to prune password history")
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.users
+		SET password_hash = $1, password_reset_required = FALSE, updated_at = $2, version = version + 1
+		WHERE id = $3`, string(hash), now, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to update password")
+	}
+	return nil
+}
+
+// SetPassword sets the first password of a user who has verified their email,
+// activating them.
+func (ur *UserRepository) SetPassword(ctx context.Context, actor model.Actor, user *model.User, hash []byte) error {
+	if user == nil {
+		return errors.New("user cannot be nil")
+	}
+
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	status, err := lockUserStatus(ctx, tx, user.ID)
+	if err != nil {
+		return err
+	}
+	err = model.CheckUserTransition(status, model.UserStatusActive)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.users
+		SET password_hash = $1, status = $2, updated_at = $3, version = version + 1
+		WHERE id = $4`, string(hash), model.UserStatusActive, time.Now().UTC(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionPasswordSet, model.AuditEntityUser, user.ID,
+		statusSnapshot(status), statusSnapshot(model.UserStatusActive))
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// ChangeUserStatus moves a user to a new status on behalf of an
+// administrator. Reactivating a user also lifts any login lockout and clears
+// the failed logins counted against their email.
+func (ur *UserRepository) ChangeUserStatus(ctx context.Context, actor model.Actor, userID string, status string) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	current, err := lockUserStatus(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	// either change ends a login lockout: reactivating lifts it, and an
+	// administrator's suspension takes its place so that it is not lifted
+	// when the lock runs out
+	result, err := tx.ExecContext(ctx, `DELETE FROM eagle.user_lockouts WHERE user_id = $1`, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove lockout")
+	}
+	lockedOut, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if lockedOut == 0 || status != model.UserStatusSuspended {
+		err = model.CheckUserTransition(current, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.users
+		SET status = $1, updated_at = $2, version = version + 1
+		WHERE id = $3`, status, time.Now().UTC(), userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to update user status")
+	}
+
+	action := model.AuditActionUserSuspended
+	if status == model.UserStatusActive {
+		action = model.AuditActionUserReactivated
+
+		// the failed logins behind a lockout go with it, otherwise the next
+		// wrong password locks the user out again straight away
+		var loginKey string
+		err = tx.GetContext(ctx, &loginKey, `SELECT LOWER(TRIM(email)) FROM eagle.users WHERE id = $1`, userID)
+		if err != nil {
+			return errors.Wrap(err, "failed to read login key")
+		}
+		err = resetFailedLogins(ctx, tx, model.LoginScopeEmail, loginKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = recordChange(ctx, tx, actor, action, model.AuditEntityUser, userID, statusSnapshot(current), statusSnapshot(status))
+	if err != nil {
+		return err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
+}
+
+// ForcePasswordReset stops the user logging in until they reset their password
+// and issues them a reset token, replacing any earlier one.
+func (ur *UserRepository) ForcePasswordReset(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error) {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// users without a password must verify their email address instead
+	var resetRequired bool
+	err = tx.GetContext(ctx, &resetRequired, `
+		SELECT password_reset_required
+		FROM eagle.users
+		WHERE id = $1
+		AND password_hash IS NOT NULL
+		FOR UPDATE`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	token, err := entity.NewPasswordResetToken(
+		entity.WithPasswordResetTokenID(entity.ID(resetToken)),
+		entity.WithPasswordResetTokenUserID(entity.ID(userID)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.users
+		SET password_reset_required = TRUE, updated_at = $1, version = version + 1
+		WHERE id = $2`, now, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to require password reset")
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM eagle.password_reset_tokens WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenQuery := `	INSERT INTO eagle.password_reset_tokens (token, user_id, expires_at, created_at) 
+				VALUES (:token, :user_id, :expires_at, :created_at)`
+	_, err = tx.NamedExecContext(ctx, tokenQuery, token.FromEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	before, _ := json.Marshal(map[string]bool{"passwordResetRequired": resetRequired})
+	after, _ := json.Marshal(map[string]bool{"passwordResetRequired": true})
+	err = recordChange(ctx, tx, actor, model.AuditActionPasswordResetForced, model.AuditEntityUser, userID, before, after)
 	if err != nil {
 		return nil, err
 	}
@@ -127,141 +873,277 @@ func (ur *UserRepository) CreateUser(newUser *model.NewUser) (*model.User, error
 		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
 	}
 
-	return ur.GetUserByID(newUserID.String())
+	return ur.GetUserByID(ctx, userID)
 }
 
-func (ur *UserRepository) GetUserByEmailVerificationToken(emailToken string) (*model.User, error) {
-	if emailToken == "" {
-		return nil, errors.New("emailToken cannot be empty")
-	}
-	query := `	SELECT token, user_id, expires_at, used_at 
-				FROM eagle.user_verification_tokens
-				WHERE token = :token
-				`
+// SearchUsers lists users whose name or email contains the query, optionally
+// restricted to one status, ordered by name.
+func (ur *UserRepository) SearchUsers(ctx context.Context, search model.UserSearch) ([]model.User, error) {
+	query := `SELECT u.id,
+       				u.name,
+       				u.email,
+       				u.phone_number,
+       				u.status,
+       				u.password_reset_required,
+       				u.version,
+       				a.line1,
+       				a.line2,
+       				a.line3,
+       				a.town,
+       				a.county,
+       				a.postcode
+				FROM eagle.users u
+				JOIN eagle.addresses a ON a.user_id = u.id
+				WHERE (u.name ILIKE :pattern OR u.email ILIKE :pattern)
+				AND (:status = '' OR CAST(u.status AS TEXT) = :status)
+				ORDER BY u.name, u.id
+				LIMIT :limit OFFSET :offset`
 
-	var verificationToken entity.VerificationTokenDAO
-	namedStmt, err := ur.pg.DB.PrepareNamed(query)
+	var rows []dao.UserViewDAO
+	namedStmt, err := ur.pg.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
 	defer namedStmt.Close()
 	args := map[string]interface{}{
-		"token": emailToken,
+		"pattern": "%" + escapeLike(search.Query) + "%",
+		"status":  search.Status,
+		"limit":   search.Limit,
+		"offset":  search.Offset,
 	}
-	err = namedStmt.Get(&verificationToken, args)
+	err = namedStmt.SelectContext(ctx, &rows, args)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute query")
 	}
 
-	return ur.GetUserByID(verificationToken.UserID.String())
+	users := make([]model.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, *row.ConvertToModel())
+	}
+	return users, nil
 }
 
-func (ur *UserRepository) VerifyEmail(emailToken string) error {
+// escapeLike stops wildcards in a search term matching more than the term.
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
 
-	query := `SELECT user_id 
-				FROM eagle.user_verification_tokens  
-				WHERE token = :token
-				AND used_at IS NULL 
-				AND expires_at > NOW()`
+// lockUserStatus returns the user's status, locking their row until tx ends so
+// that concurrent status changes are applied one at a time.
+func lockUserStatus(ctx context.Context, tx *sqlx.Tx, userID string) (string, error) {
+	var status string
+	err := tx.GetContext(ctx, &status, `
+		SELECT status
+		FROM eagle.users
+		WHERE id = $1
+		FOR UPDATE`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", model.ErrUserNotFound
+		}
+		return "", err
+	}
+	return status, nil
+}
 
-	var userID string
-	namedStmt, err := ur.pg.DB.PrepareNamed(query)
+func (ur *UserRepository) Login(ctx context.Context, email string, password string) (string, error) {
+	user, err := ur.GetUserByEmail(ctx, email)
+	if err != nil || user == nil || user.PasswordHash == nil {
+		return "", model.ErrInvalidCredentials
+	}
+	// Compare the stored bcrypt hash with the provided password
+	err = bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(password))
 	if err != nil {
-		return err
+		return "", model.ErrInvalidCredentials
 	}
 
-	defer namedStmt.Close()
-	args := map[string]interface{}{
-		"token": emailToken,
+	return user.ID, nil
+}
+
+// LockUser suspends an active user until the given time after too many failed
+// logins. Users in any other status are left alone. The email is matched
+// ignoring case, as failed logins are counted by the lowercased address.
+func (ur *UserRepository) LockUser(ctx context.Context, actor model.Actor, email string, until time.Time) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var user struct {
+		ID     string `db:"id"`
+		Status string `db:"status"`
+		Locked bool   `db:"locked"`
 	}
-	err = namedStmt.Get(&userID, args)
+	err = tx.GetContext(ctx, &user, `
+		SELECT u.id, u.status, l.user_id IS NOT NULL AS locked
+		FROM eagle.users u
+		LEFT JOIN eagle.user_lockouts l ON l.user_id = u.id
+		WHERE LOWER(u.email) = LOWER($1)
+		FOR UPDATE OF u`, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("invalid or expired token")
-		} else {
-			return errors.New("invalid or expired token")
+			err = model.ErrUserNotFound
 		}
+		return err
 	}
+	userID := user.ID
 
-	// Mark token as used
-	_, err = ur.pg.DB.Exec(`
-		UPDATE eagle.user_verification_tokens
-		SET used_at = $1
-		WHERE token = $2`, time.Now().UTC(), emailToken)
+	// failures after an earlier lock has run out extend it rather than
+	// being ignored while the user is still suspended by it
+	if user.Locked {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE eagle.user_lockouts
+			SET locked_until = $1
+			WHERE user_id = $2`, until.UTC(), userID)
+		if err != nil {
+			return errors.Wrap(err, "failed to extend lockout")
+		}
+		if commitErr := tx.Commit(); commitErr != nil {
+			return fmt.Errorf("failed to commit transaction: %w", commitErr)
+		}
+		return nil
+	}
+	err = model.CheckUserTransition(user.Status, model.UserStatusSuspended)
 	if err != nil {
 		return err
 	}
 
-	// Update user record to set status
-	_, err = ur.pg.DB.Exec(`
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO eagle.user_lockouts (user_id, previous_status, locked_until)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET locked_until = EXCLUDED.locked_until`,
+		userID, entity.UserActiveStatus, until.UTC())
+	if err != nil {
+		return errors.Wrap(err, "failed to record lockout")
+	}
+	_, err = tx.ExecContext(ctx, `
 		UPDATE eagle.users
-		SET status = $1
-		WHERE id = $2`, entity.UserEmailVerifiedStatus, userID)
+		SET status = $1, updated_at = $2, version = version + 1
+		WHERE id = $3`, entity.UserSuspendedStatus, time.Now().UTC(), userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to suspend user")
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionUserLocked, model.AuditEntityUser, userID,
+		statusSnapshot(entity.UserActiveStatus), statusSnapshot(entity.UserSuspendedStatus))
 	if err != nil {
 		return err
 	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
 	return nil
 }
 
-func (ur *UserRepository) SetPassword(user *model.User, hash []byte) error {
-	if user == nil {
-		return errors.New("user cannot be nil")
+// GetUserLockoutByEmail returns the lockout of the user with the given email,
+// matched ignoring case, or nil if they are not locked.
+func (ur *UserRepository) GetUserLockoutByEmail(ctx context.Context, email string) (*model.UserLockout, error) {
+	var lockout dao.UserLockoutDAO
+	err := ur.pg.DB.GetContext(ctx, &lockout, `
+		SELECT l.user_id, l.previous_status, l.locked_until
+		FROM eagle.user_lockouts l
+		JOIN eagle.users u ON u.id = l.user_id
+		WHERE LOWER(u.email) = LOWER($1)`, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get lockout")
 	}
+	return lockout.ConvertToModel(), nil
+}
 
-	userEntity, err := ur.GetEntityByID(user.ID)
+// UnlockUser lifts a lockout, restoring the status the user had before it.
+// Users who are not locked are left alone.
+func (ur *UserRepository) UnlockUser(ctx context.Context, actor model.Actor, userID string) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	if err = unlockUser(ctx, tx, actor, userID); err != nil {
 		return err
 	}
-	if userEntity == nil {
-		return errors.New("failed to get user entity")
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
 	}
-	passwordHash := string(hash)
-	err = userEntity.Modify(
-		entity.WithUserStatus(entity.UserActiveStatus),
-		entity.WithUserPasswordHash(&passwordHash),
-	)
+	return nil
+}
+
+// unlockUser lifts the user's lockout within tx, as UnlockUser does.
+func unlockUser(ctx context.Context, tx *sqlx.Tx, actor model.Actor, userID string) error {
+	var lockout dao.UserLockoutDAO
+	err := tx.GetContext(ctx, &lockout, `
+		DELETE FROM eagle.user_lockouts
+		WHERE user_id = $1
+		RETURNING user_id, previous_status, locked_until`, userID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
 		return err
 	}
 
-	_, err = ur.pg.DB.NamedExec(`
-	      UPDATE eagle.users
-	      SET password_hash = :password_hash, status = :status
-	      WHERE id = :id
-	  `, map[string]interface{}{
-		"password_hash": string(hash),
-		"status":        entity.UserActiveStatus,
-		"id":            user.ID,
-	})
-
+	current, err := lockUserStatus(ctx, tx, userID)
 	if err != nil {
 		return err
 	}
-
-	return nil
-}
-
-func (ur *UserRepository) Login(email string, password string) (string, error) {
-	user, err := ur.GetUserByEmail(email)
-	if err != nil || user == nil || user.PasswordHash == nil {
-		return "", errors.New("invalid email or password")
+	// only restore users still suspended by the lockout
+	if model.CheckUserTransition(current, lockout.PreviousStatus) != nil {
+		return nil
 	}
-	// Compare the stored bcrypt hash with the provided password
-	err = bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(password))
+	_, err = tx.ExecContext(ctx, `
+		UPDATE eagle.users
+		SET status = $1, updated_at = $2, version = version + 1
+		WHERE id = $3`, lockout.PreviousStatus, time.Now().UTC(), userID)
 	if err != nil {
-		return "", errors.New("invalid email or password")
+		return errors.Wrap(err, "failed to restore user status")
 	}
-
-	return user.ID, nil
+	return recordChange(ctx, tx, actor, model.AuditActionUserUnlocked, model.AuditEntityUser, userID,
+		statusSnapshot(current), statusSnapshot(lockout.PreviousStatus))
 }
 
-func (ur *UserRepository) GetUserByID(id string) (*model.User, error) {
+func (ur *UserRepository) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	query := `SELECT u.id, 
        				u.name, 
        				u.email, 
        				u.phone_number, 
        				u.status,
+       				u.password_reset_required,
+       				u.version,
        				a.line1,
        				a.line2,
        				a.line3,
@@ -273,7 +1155,7 @@ func (ur *UserRepository) GetUserByID(id string) (*model.User, error) {
 				WHERE u.id = :user_id`
 
 	var user dao.UserViewDAO
-	namedStmt, err := ur.pg.DB.PrepareNamed(query)
+	namedStmt, err := ur.pg.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -282,15 +1164,18 @@ func (ur *UserRepository) GetUserByID(id string) (*model.User, error) {
 	args := map[string]interface{}{
 		"user_id": id,
 	}
-	err = namedStmt.Get(&user, args)
+	err = namedStmt.GetContext(ctx, &user, args)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
 		return nil, errors.Wrap(err, "failed to execute query")
 	}
 
 	return user.ConvertToModel(), nil
 }
 
-func (ur *UserRepository) GetUserByEmail(email string) (*entity.UserDAO, error) {
+func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*entity.UserDAO, error) {
 	query := `SELECT u.id, 
        				u.name, 
        				u.email, 
@@ -301,7 +1186,7 @@ func (ur *UserRepository) GetUserByEmail(email string) (*entity.UserDAO, error)
 				WHERE u.email = :email`
 
 	var user entity.UserDAO
-	namedStmt, err := ur.pg.DB.PrepareNamed(query)
+	namedStmt, err := ur.pg.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +1195,7 @@ func (ur *UserRepository) GetUserByEmail(email string) (*entity.UserDAO, error)
 	args := map[string]interface{}{
 		"email": email,
 	}
-	err = namedStmt.Get(&user, args)
+	err = namedStmt.GetContext(ctx, &user, args)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute query")
 	}
@@ -318,7 +1203,7 @@ func (ur *UserRepository) GetUserByEmail(email string) (*entity.UserDAO, error)
 	return &user, nil
 }
 
-func (ur *UserRepository) GetEntityByID(id string) (*entity.User, error) {
+func (ur *UserRepository) GetEntityByID(ctx context.Context, id string) (*entity.User, error) {
 	query := `SELECT id, 
        				name, 
        				email, 
@@ -330,7 +1215,7 @@ func (ur *UserRepository) GetEntityByID(id string) (*entity.User, error) {
 				WHERE u.id = :user_id`
 
 	var user entity.UserDAO
-	namedStmt, err := ur.pg.DB.PrepareNamed(query)
+	namedStmt, err := ur.pg.DB.PrepareNamedContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -342,19 +1227,59 @@ func (ur *UserRepository) GetEntityByID(id string) (*entity.User, error) {
 	args := map[string]interface{}{
 		"user_id": id,
 	}
-	err = namedStmt.Get(&user, args)
+	err = namedStmt.GetContext(ctx, &user, args)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
 		return nil, errors.Wrap(err, "failed to execute query")
 	}
 	return user.ToEntity(), nil
 }
 
-func (ur *UserRepository) UpdateUser(user *model.User) (*model.User, error) {
+func (ur *UserRepository) GetAddressEntityByUserID(ctx context.Context, userID string) (*entity.Address, error) {
+	query := `SELECT id,
+       				user_id,
+       				line1,
+       				line2,
+       				line3,
+       				town,
+       				county,
+       				postcode,
+       				created_at,
+       				updated_at
+				FROM eagle.addresses
+				WHERE user_id = :user_id`
+
+	var address entity.AddressDAO
+	namedStmt, err := ur.pg.DB.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer namedStmt.Close()
+	args := map[string]interface{}{
+		"user_id": userID,
+	}
+	err = namedStmt.GetContext(ctx, &address, args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, errors.Wrap(err, "failed to execute query")
+	}
+	return address.ToEntity(), nil
+}
+
+// UpdateUser updates the user's contact details and address, provided the
+// user is still at user.Version. Status and password are managed by their own
+// flows and are left untouched.
+func (ur *UserRepository) UpdateUser(ctx context.Context, actor model.Actor, user *model.User) (*model.User, error) {
 	if user == nil {
 		return nil, errors.New("user cannot be nil")
 	}
 
-	userEntity, err := ur.GetEntityByID(user.ID)
+	userEntity, err := ur.GetEntityByID(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -362,44 +1287,187 @@ func (ur *UserRepository) UpdateUser(user *model.User) (*model.User, error) {
 		return nil, errors.New("failed to get user entity")
 	}
 	err = userEntity.Modify(
-		entity.WithUserStatus(entity.UserActiveStatus),
-		entity.WithUserPasswordHash(&user.Password),
+		entity.WithUserName(user.Name),
+		entity.WithUserPhoneNumber(user.PhoneNumber),
 	)
+	if err != nil {
+		return nil, errors.Wrap(model.ErrInvalidUser, err.Error())
+	}
+
+	addressEntity, err := ur.GetAddressEntityByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	now := time.Now().UTC()
+	err = addressEntity.Modify(
+		entity.WithUserAddressLine1(user.Line1),
+		entity.WithUserAddressLine2(user.Line2),
+		entity.WithUserAddressLine3(user.Line3),
+		entity.WithUserAddressTown(user.Town),
+		entity.WithUserAddressCounty(user.County),
+		entity.WithUserAddressPostcode(user.Postcode),
+		entity.WithUserAddressUpdatedAt(now),
+	)
+	if err != nil {
+		return nil, errors.Wrap(model.ErrInvalidUser, err.Error())
+	}
 
-	userUpdateQuery := `	UPDATE eagle.users SET name = :name, 
-	                       phone_number = :phone_number, 
-	                       status = :status, 
-	                       password_hash = :password, 
-	                       updated_at = :updated_at 
-				WHERE id = :user_id`
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
 
-	namedStmt, err := ur.pg.DB.PrepareNamed(userUpdateQuery)
+	before, err := userProfile(ctx, tx, string(userEntity.ID()))
 	if err != nil {
 		return nil, err
 	}
 
-	defer namedStmt.Close()
-	args := map[string]interface{}{
+	userUpdateQuery := `	UPDATE eagle.users SET name = :name, 
+	                       phone_number = :phone_number, 
+	                       updated_at = :updated_at,
+	                       version = version + 1
+				WHERE id = :user_id
+				AND version = :version`
+
+	result, err := tx.NamedExecContext(ctx, userUpdateQuery, map[string]interface{}{
 		"user_id":      userEntity.ID(),
 		"name":         userEntity.Name(),
 		"phone_number": userEntity.PhoneNumber(),
-		"status":       userEntity.Status(),
-		"password":     userEntity.PasswordHash(),
-		"updated_at":   time.Now().UTC(),
-	}
-
-	result, err := namedStmt.Exec(args)
+		"updated_at":   now,
+		"version":      user.Version,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	// the user was read above, so they have been changed since user was read
 	if rowsAffected < 1 {
-		return nil, errors.New("failed to set password")
+		err = model.ErrVersionMismatch
+		return nil, err
+	}
+
+	addressUpdateQuery := `	UPDATE eagle.addresses SET line1 = :line1,
+	                       line2 = :line2,
+	                       line3 = :line3,
+	                       town = :town,
+	                       county = :county,
+	                       postcode = :postcode,
+	                       updated_at = :updated_at
+				WHERE id = :id`
+
+	_, err = tx.NamedExecContext(ctx, addressUpdateQuery, addressEntity.FromEntity())
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := userProfile(ctx, tx, string(userEntity.ID()))
+	if err != nil {
+		return nil, err
+	}
+	err = recordChange(ctx, tx, actor, model.AuditActionUserUpdated, model.AuditEntityUser, string(userEntity.ID()),
+		userSnapshot(before), userSnapshot(after, changedUserFields(before, after)...))
+	if err != nil {
+		return nil, err
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+
+	return ur.GetUserByID(ctx, string(userEntity.ID()))
+}
+
+// DeleteUser removes a user whose bank accounts have all been closed.
+// The address and verification token rows are removed by ON DELETE CASCADE,
+// which erases the user's personal details as the audit log does not hold
+// them. When version is given the user must still be at that version.
+func (ur *UserRepository) DeleteUser(ctx context.Context, actor model.Actor, id string, version *int64) error {
+	tx, err := ur.pg.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Ensure rollback if commit is not successful
+	defer func() {
+		if p := recover(); p != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		} else if err != nil {
+			err := tx.Rollback()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var currentVersion int64
+	err = tx.GetContext(ctx, &currentVersion, `SELECT version FROM eagle.users WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrUserNotFound
+		}
+		return errors.Wrap(err, "failed to lock user")
+	}
+	if version != nil && *version != currentVersion {
+		err = model.ErrVersionMismatch
+		return err
+	}
+
+	// closed accounts are kept for their ledger rather than deleted, and do
+	// not stop the user being deleted
+	var accountCount int
+	err = tx.GetContext(ctx, &accountCount, `
+		SELECT COUNT(*)
+		FROM eagle.user_accounts ua
+		JOIN eagle.accounts a ON a.account_number = ua.account_number
+		WHERE ua.user_id = $1
+		AND a.status <> $2`, id, model.AccountClosedStatus)
+	if err != nil {
+		return errors.Wrap(err, "failed to count user accounts")
+	}
+	if accountCount > 0 {
+		err = model.ErrUserHasAccounts
+		return err
+	}
+
+	before, err := userProfile(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM eagle.users WHERE id = $1`, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete user")
+	}
+
+	err = recordChange(ctx, tx, actor, model.AuditActionUserDeleted, model.AuditEntityUser, id, userSnapshot(before), nil)
+	if err != nil {
+		return err
 	}
 
-	return ur.GetUserByID(string(userEntity.ID()))
+	if commitErr := tx.Commit(); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction: %w", commitErr)
+	}
+	return nil
 }
@@ -0,0 +1,244 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"eagle-bank.com/internal/adapter/storage/postgres/repository"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/service"
+	"eagle-bank.com/internal/testsupport"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createActiveUser adds a user who has verified their email and set a
+// password, returning their ID.
+func createActiveUser(t *testing.T, db *postgres.DBContext, email string) string {
+	t.Helper()
+	user, err := repository.NewUserRepository(db).CreateUser(context.Background(), model.AnonymousActor(""), &model.NewUser{
+		Name:              "Jane Doe",
+		Email:             email,
+		PhoneNumber:       "+447700900123",
+		Line1:             "1 High Street",
+		Town:              "London",
+		Postcode:          "SW1A 1AA",
+		VerificationToken: uuid.NewString(),
+	})
+	require.NoError(t, err)
+	_, err = db.DB.Exec(`UPDATE eagle.users SET status = $1, password_hash = 'x' WHERE id = $2`, model.UserStatusActive, user.ID)
+	require.NoError(t, err)
+	return user.ID
+}
+
+func TestUserRepository_LockUser(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+
+	t.Run("email with capitals is locked by its lowercased login key", func(t *testing.T) {
+		userID := createActiveUser(t, db, "Jane.Doe@Example.com")
+
+		err := userRepo.LockUser(ctx, model.SystemActor(""), "jane.doe@example.com", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		lockout, err := userRepo.GetUserLockoutByEmail(ctx, "jane.doe@example.com")
+		require.NoError(t, err)
+		require.NotNil(t, lockout)
+		assert.Equal(t, userID, lockout.UserID)
+	})
+}
+
+// openAccount opens an account for the user with a deposit and withdrawal in
+// its ledger, leaving it empty.
+func openAccount(t *testing.T, db *postgres.DBContext, userID string) string {
+	t.Helper()
+	ctx := context.Background()
+	accountNumber, err := repository.NewAccountNumberAllocator(db).AllocateAccountNumber(ctx)
+	require.NoError(t, err)
+	_, err = repository.NewAccountRepository(db).CreateAccount(ctx, model.UserActor(userID, ""), &model.NewAccount{
+		UserID:        userID,
+		Name:          "Savings",
+		Type:          "personal",
+		AccountNumber: accountNumber,
+	})
+	require.NoError(t, err)
+
+	transactionRepo := repository.NewTransactionRepository(db)
+	for _, transactionType := range []string{"deposit", "withdrawal"} {
+		_, err = transactionRepo.CreateTransaction(ctx, &model.NewTransaction{
+			AccountNumber: accountNumber,
+			UserID:        userID,
+			Amount:        decimal.NewFromInt(10),
+			Currency:      "GBP",
+			Type:          transactionType,
+			TransactionID: service.GenerateTransactionID(),
+		})
+		require.NoError(t, err)
+	}
+	return accountNumber
+}
+
+func TestUserRepository_DeleteUser(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+
+	t.Run("user whose accounts are all closed is deleted and the ledger kept", func(t *testing.T) {
+		userID := createActiveUser(t, db, "closed@example.com")
+		accountNumber := openAccount(t, db, userID)
+		require.NoError(t, repository.NewAccountRepository(db).CloseAccount(ctx, model.UserActor(userID, ""), accountNumber, nil))
+
+		require.NoError(t, userRepo.DeleteUser(ctx, model.UserActor(userID, ""), userID, nil))
+
+		_, err := userRepo.GetUserByID(ctx, userID)
+		require.ErrorIs(t, err, model.ErrUserNotFound)
+		transactions, err := repository.NewTransactionRepository(db).ListTransactions(ctx, accountNumber)
+		require.NoError(t, err)
+		assert.Len(t, transactions, 2)
+	})
+
+	t.Run("user with an open account is kept", func(t *testing.T) {
+		userID := createActiveUser(t, db, "open@example.com")
+		openAccount(t, db, userID)
+
+		err := userRepo.DeleteUser(ctx, model.UserActor(userID, ""), userID, nil)
+		require.ErrorIs(t, err, model.ErrUserHasAccounts)
+	})
+}
+
+func TestUserRepository_ChangeUserStatus(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	userRepo := repository.NewUserRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	ctx := context.Background()
+
+	t.Run("reactivation lifts the lockout and clears failed logins", func(t *testing.T) {
+		email := "Locked.Out@Example.com"
+		userID := createActiveUser(t, db, email)
+		_, err := loginAttemptRepo.RecordFailedLogin(ctx, model.LoginScopeEmail, "locked.out@example.com", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, userRepo.LockUser(ctx, model.SystemActor(""), email, time.Now().Add(time.Hour)))
+
+		err = userRepo.ChangeUserStatus(ctx, model.AdminActor(uuid.NewString(), ""), userID, model.UserStatusActive)
+		require.NoError(t, err)
+
+		user, err := userRepo.GetUserByID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, model.UserStatusActive, user.Status)
+		lockout, err := userRepo.GetUserLockoutByEmail(ctx, email)
+		require.NoError(t, err)
+		assert.Nil(t, lockout)
+		failed, err := loginAttemptRepo.GetFailedLogins(ctx, model.LoginScopeEmail, "locked.out@example.com", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, failed.Failures)
+	})
+
+	t.Run("failed reactivation keeps the failed logins", func(t *testing.T) {
+		email := "active@example.com"
+		userID := createActiveUser(t, db, email)
+		_, err := loginAttemptRepo.RecordFailedLogin(ctx, model.LoginScopeEmail, email, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		err = userRepo.ChangeUserStatus(ctx, model.AdminActor(uuid.NewString(), ""), userID, model.UserStatusActive)
+		require.ErrorIs(t, err, model.ErrInvalidUserTransition)
+
+		failed, err := loginAttemptRepo.GetFailedLogins(ctx, model.LoginScopeEmail, email, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, failed.Failures)
+	})
+}
+
+func TestUserRepository_ResetPassword(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	userRepo := repository.NewUserRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	t.Run("reset lifts the lockout and revokes existing sessions", func(t *testing.T) {
+		email := "locked@example.com"
+		userID := createActiveUser(t, db, email)
+		resetToken := uuid.NewString()
+		_, err := userRepo.ReplacePasswordResetToken(ctx, model.AnonymousActor(""), email, resetToken, time.Now())
+		require.NoError(t, err)
+		_, err = loginAttemptRepo.RecordFailedLogin(ctx, model.LoginScopeEmail, email, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, userRepo.LockUser(ctx, model.SystemActor(""), email, time.Now().Add(time.Hour)))
+		refreshToken := &model.RefreshToken{
+			ID:        uuid.NewString(),
+			FamilyID:  uuid.NewString(),
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+			CreatedAt: time.Now(),
+		}
+		require.NoError(t, refreshTokenRepo.CreateRefreshToken(ctx, refreshToken))
+
+		err = userRepo.ResetPassword(ctx, model.AnonymousActor(""), resetToken, []byte("new-hash"), email)
+		require.NoError(t, err)
+
+		user, err := userRepo.GetUserByID(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, model.UserStatusActive, user.Status)
+		lockout, err := userRepo.GetUserLockoutByEmail(ctx, email)
+		require.NoError(t, err)
+		assert.Nil(t, lockout)
+		failed, err := loginAttemptRepo.GetFailedLogins(ctx, model.LoginScopeEmail, email, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, failed.Failures)
+		generation, err := repository.NewTokenStore(db).UserGeneration(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), generation)
+		stored, err := refreshTokenRepo.GetRefreshToken(ctx, refreshToken.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.RevokedAt)
+	})
+
+	t.Run("used token changes nothing", func(t *testing.T) {
+		email := "reused@example.com"
+		userID := createActiveUser(t, db, email)
+		resetToken := uuid.NewString()
+		_, err := userRepo.ReplacePasswordResetToken(ctx, model.AnonymousActor(""), email, resetToken, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, userRepo.ResetPassword(ctx, model.AnonymousActor(""), resetToken, []byte("new-hash"), email))
+
+		err = userRepo.ResetPassword(ctx, model.AnonymousActor(""), resetToken, []byte("other-hash"), email)
+		require.ErrorIs(t, err, model.ErrInvalidPasswordResetToken)
+
+		generation, err := repository.NewTokenStore(db).UserGeneration(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), generation)
+	})
+}
+
+func TestUserRepository_ChangePassword(t *testing.T) {
+	db := testsupport.NewMigratedPostgres(t)
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+
+	userID := createActiveUser(t, db, "history@example.com")
+	for i := 0; i < model.PasswordHistoryDepth+2; i++ {
+		require.NoError(t, userRepo.ChangePassword(ctx, model.UserActor(userID, ""), userID, []byte(fmt.Sprintf("hash-%d", i))))
+	}
+
+	t.Run("history keeps only the passwords that may not be reused", func(t *testing.T) {
+		var kept int
+		require.NoError(t, db.DB.Get(&kept, `SELECT COUNT(*) FROM eagle.password_history WHERE user_id = $1`, userID))
+		assert.Equal(t, model.PasswordHistoryDepth-1, kept)
+
+		hashes, err := userRepo.GetPasswordHashes(ctx, userID, model.PasswordHistoryDepth)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hash-6", "hash-5", "hash-4", "hash-3", "hash-2"}, hashes)
+	})
+
+	t.Run("every change revokes the user's sessions", func(t *testing.T) {
+		generation, err := repository.NewTokenStore(db).UserGeneration(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(model.PasswordHistoryDepth+2), generation)
+	})
+}
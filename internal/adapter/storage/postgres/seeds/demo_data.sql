@@ -0,0 +1,28 @@
+/* demo customers and an administrator for local development; safe to apply more than once */
+
+INSERT INTO users (name, email, phone_number)
+VALUES
+    ('Alice Smith', 'alice@example.com', '+447123456789'),
+    ('Bob Johnson', 'bob@example.com', '+447234567890'),
+    ('Carol Davis', 'carol@example.com', '+447345678901')
+ON CONFLICT (email) DO NOTHING;
+
+INSERT INTO addresses (user_id, line1, line2, town, county, postcode)
+SELECT id, '123 Main Street', NULL, 'London', 'Greater London', 'SW1A 1AA'
+FROM users u WHERE email = 'alice@example.com'
+AND NOT EXISTS (SELECT 1 FROM addresses a WHERE a.user_id = u.id);
+
+INSERT INTO addresses (user_id, line1, line2, town, county, postcode)
+SELECT id, '456 Oak Avenue', 'Flat 2B', 'Manchester', 'Greater Manchester', 'M1 2AB'
+FROM users u WHERE email = 'bob@example.com'
+AND NOT EXISTS (SELECT 1 FROM addresses a WHERE a.user_id = u.id);
+
+INSERT INTO addresses (user_id, line1, line2, line3, town, county, postcode)
+SELECT id, '789 Pine Road', NULL, NULL, 'Bristol', 'City of Bristol', 'BS1 3CD'
+FROM users u WHERE email = 'carol@example.com'
+AND NOT EXISTS (SELECT 1 FROM addresses a WHERE a.user_id = u.id);
+
+/* demo administrator, password Admin-passw0rd */
+INSERT INTO admins (name, email, password_hash)
+VALUES ('Eagle Operations', 'ops@eagle-bank.com', '$2a$10$y88leD.j/mmimemG5gTmGubAkKFqWmB8mUFGQ.whdPTyWOYhit7hy')
+ON CONFLICT (email) DO NOTHING;
@@ -1,6 +1,21 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	EagleSortCode = "10-10-10"
+
+	AccountPersonalType = "personal"
+	AccountBusinessType = "business"
+
+	AccountOpenStatus   = "open"
+	AccountFrozenStatus = "frozen"
+	AccountClosedStatus = "closed"
+)
 
 type NewAccount struct {
 	UserID        string `json:"userId" valid:"required"`
@@ -10,14 +25,27 @@ type NewAccount struct {
 }
 
 type Account struct {
-	AccountNumber    string    `json:"accountNumber"`
-	SortCode         string    `json:"sortCode"`
-	Name             string    `json:"name"`
-	AccountType      string    `json:"accountType"`
-	Balance          int       `json:"balance"`
-	Currency         string    `json:"currency"`
-	CreatedTimestamp time.Time `json:"createdTimestamp"`
-	UpdatedTimestamp time.Time `json:"updatedTimestamp"`
+	AccountNumber    string          `json:"accountNumber"`
+	UserID           string          `json:"-"`
+	SortCode         string          `json:"sortCode"`
+	Name             string          `json:"name"`
+	AccountType      string          `json:"accountType"`
+	Status           string          `json:"-"`
+	Balance          decimal.Decimal `json:"balance"`
+	Currency         string          `json:"currency"`
+	CreatedTimestamp time.Time       `json:"createdTimestamp"`
+	UpdatedTimestamp time.Time       `json:"updatedTimestamp"`
+	// Version increases with every change and is sent as the ETag
+	Version int64 `json:"-"`
+}
+
+type UpdateAccount struct {
+	AccountNumber string  `json:"-"`
+	UserID        string  `json:"-"`
+	Name          *string `json:"name"`
+	Type          *string `json:"accountType"`
+	// Version the caller expects the account to be at, if they gave one
+	Version *int64 `json:"-"`
 }
 
 type UserAccount struct {
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"fmt"
+)
+
+const (
+	// AccountNumberPrefix starts every account number issued by the bank
+	AccountNumberPrefix = "01"
+	// MaxAccountSerial is the largest serial that fits between the prefix and
+	// the check digit of an eight digit account number
+	MaxAccountSerial = 99_999
+)
+
+// AccountNumberFromSerial formats a serial as an account number, the prefix
+// followed by the zero padded serial and a Luhn check digit, so that a
+// mistyped digit is caught before it reaches another customer's account.
+func AccountNumberFromSerial(serial int64) (string, error) {
+	if serial < 0 || serial > MaxAccountSerial {
+		return "", fmt.Errorf("account serial %d is out of range", serial)
+	}
+	payload := fmt.Sprintf("%s%05d", AccountNumberPrefix, serial)
+	return payload + string(luhnCheckDigit(payload)), nil
+}
+
+// luhnCheckDigit returns the digit that makes payload followed by it pass the
+// Luhn check. payload must only contain digits.
+func luhnCheckDigit(payload string) byte {
+	sum := 0
+	double := true
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
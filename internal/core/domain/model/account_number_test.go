@@ -0,0 +1,39 @@
+package model_test
+
+import (
+	"regexp"
+	"testing"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountNumberFromSerial(t *testing.T) {
+
+	// the format in openapi.yaml
+	pattern := regexp.MustCompile(`^01\d{6}$`)
+
+	tests := []struct {
+		serial        int64
+		accountNumber string
+	}{
+		{0, "01000009"},
+		{1, "01000017"},
+		{42, "01000421"},
+		{12345, "01123454"},
+		{model.MaxAccountSerial, "01999994"},
+	}
+
+	for _, tt := range tests {
+		accountNumber, err := model.AccountNumberFromSerial(tt.serial)
+		require.NoError(t, err)
+		assert.Regexp(t, pattern, accountNumber)
+		assert.Equal(t, tt.accountNumber, accountNumber)
+	}
+
+	_, err := model.AccountNumberFromSerial(model.MaxAccountSerial + 1)
+	assert.Error(t, err)
+	_, err = model.AccountNumberFromSerial(-1)
+	assert.Error(t, err)
+}
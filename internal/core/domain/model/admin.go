@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Admin is a member of bank staff using the operations endpoints. Admins are
+// separate principals from customers and cannot log in as one.
+type Admin struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserSearch filters users by a case-insensitive match on name or email and
+// by status. Empty fields match every user.
+type UserSearch struct {
+	Query  string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// AccessToken is a token issued without a refresh token.
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
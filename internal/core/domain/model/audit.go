@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditActorUser      = "user"
+	AuditActorSystem    = "system"
+	AuditActorAdmin     = "admin"
+	AuditActorAnonymous = "anonymous"
+
+	AuditEntityUser     = "user"
+	AuditEntityAccount  = "account"
+	AuditEntityAuditLog = "audit_log"
+
+	AuditActionUserCreated         = "user.created"
+	AuditActionUserUpdated         = "user.updated"
+	AuditActionUserDeleted         = "user.deleted"
+	AuditActionEmailVerified       = "user.email_verified"
+	AuditActionVerificationSent    = "user.verification_token_issued"
+	AuditActionPasswordSet         = "user.password_set"
+	AuditActionResetRequested      = "user.password_reset_requested"
+	AuditActionPasswordReset       = "user.password_reset"
+	AuditActionAccountCreated      = "account.created"
+	AuditActionAccountUpdated      = "account.updated"
+	AuditActionAccountClosed       = "account.closed"
+	AuditActionAuditLogViewed      = "audit_log.viewed"
+	AuditActionPasswordChanged     = "user.password_changed"
+	AuditActionUserLocked          = "user.locked"
+	AuditActionUserUnlocked        = "user.unlocked"
+	AuditActionUserSuspended       = "user.suspended"
+	AuditActionUserReactivated     = "user.reactivated"
+	AuditActionPasswordResetForced = "user.password_reset_forced"
+	AuditActionUsersSearched       = "user.searched"
+	AuditActionAccountsViewed      = "user.accounts_viewed"
+	AuditActionLedgerViewed        = "account.transactions_viewed"
+	AuditActionAccountFrozen       = "account.frozen"
+	AuditActionAccountUnfrozen     = "account.unfrozen"
+	AuditActionTOTPEnabled         = "user.totp_enabled"
+)
+
+// AuditRecord describes a change for compliance review. Before and After hold
+// JSON snapshots of the entity and are left empty for changes to secrets.
+type AuditRecord struct {
+	ID         string          `json:"id"`
+	ActorID    string          `json:"actorId"`
+	ActorType  string          `json:"actorType"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  *string         `json:"requestId,omitempty"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+// Actor identifies who made a change and the request it was made in.
+type Actor struct {
+	ID        string
+	Type      string
+	RequestID string
+}
+
+// UserActor is a logged in customer acting for themselves.
+func UserActor(userID string, requestID string) Actor {
+	return Actor{ID: userID, Type: AuditActorUser, RequestID: requestID}
+}
+
+func AdminActor(adminID string, requestID string) Actor {
+	return Actor{ID: adminID, Type: AuditActorAdmin, RequestID: requestID}
+}
+
+// AnonymousActor is a caller who has not logged in, such as someone signing
+// up or using a link sent by email. Knowing an email address or holding a
+// token sent to it does not show who made the request, so the change is not
+// attributed to the user it applies to.
+func AnonymousActor(requestID string) Actor {
+	return Actor{ID: AuditActorAnonymous, Type: AuditActorAnonymous, RequestID: requestID}
+}
+
+// SystemActor is the bank acting on its own account during a request, such
+// as locking a user after too many failed logins.
+func SystemActor(requestID string) Actor {
+	return Actor{ID: AuditActorSystem, Type: AuditActorSystem, RequestID: requestID}
+}
+
+// AuditFilter selects audit records for review. Empty fields match every
+// record.
+type AuditFilter struct {
+	EntityType string     `json:"entityType,omitempty"`
+	EntityID   string     `json:"entityId,omitempty"`
+	ActorID    string     `json:"actorId,omitempty"`
+	RequestID  string     `json:"requestId,omitempty"`
+	From       *time.Time `json:"from,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+	Limit      int        `json:"limit"`
+	Offset     int        `json:"offset"`
+}
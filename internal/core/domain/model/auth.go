@@ -8,3 +8,56 @@ type TokenPair struct {
 	AccessExpiry  time.Time
 	RefreshExpiry time.Time
 }
+
+// RefreshToken is the server side record of an issued refresh token. Tokens
+// issued by rotating one another share a FamilyID so that the whole chain can
+// be revoked if an already used token is presented again.
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	UserID    string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// Scopes are carried in the roles claim of access tokens and checked per route.
+const (
+	ScopeProfile       = "profile"
+	ScopeAccounts      = "accounts"
+	ScopeCreateAccount = "create_account"
+	ScopeDeposit       = "deposit"
+	ScopeWithdraw      = "withdraw"
+	ScopeSetPassword   = "set-password"
+	// ScopeMFA only allows the second step of a two-factor login
+	ScopeMFA = "mfa"
+	// ScopeAdmin allows the operations endpoints under /v1/admin
+	ScopeAdmin = "admin"
+)
+
+// CustomerScopes are granted to a customer on login
+var CustomerScopes = []string{
+	ScopeProfile,
+	ScopeAccounts,
+	ScopeCreateAccount,
+	ScopeDeposit,
+	ScopeWithdraw,
+}
+
+// JSONWebKey is a public key in the RFC 7517 format published at
+// /.well-known/jwks.json
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type EmailMessage struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Kinds of email sent on request, which are throttled per address
+const (
+	EmailKindVerification  = "verification"
+	EmailKindPasswordReset = "password_reset"
+)
+
+// EmailSend is the last email of a kind sent to an address.
+type EmailSend struct {
+	Kind    string
+	Address string
+	SentAt  time.Time
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Domain errors returned by services and repositories so that handlers can map
+// them onto the HTTP status codes described in openapi.yaml.
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrAccountNotEmpty = errors.New("account must have a zero balance to be closed")
+	ErrAccountFrozen   = errors.New("account is frozen")
+	// ErrAccountNumbersExhausted is returned once every account number has been issued
+	ErrAccountNumbersExhausted = errors.New("no account numbers left to allocate")
+
+	ErrInvalidAccountTransition = errors.New("account status change is not allowed")
+	ErrInvalidAccount           = errors.New("invalid account")
+	ErrTransactionNotFound      = errors.New("transaction not found")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrUserHasAccounts          = errors.New("user cannot be deleted until their bank accounts are closed")
+	ErrInvalidUser              = errors.New("invalid user")
+	ErrForbidden                = errors.New("forbidden")
+	ErrInvalidToken             = errors.New("invalid or expired token")
+	ErrRefreshTokenReused       = errors.New("refresh token has already been used")
+	ErrInsufficientFunds        = errors.New("insufficient funds to process transaction")
+	ErrInvalidTransaction       = errors.New("invalid transaction")
+	ErrInvalidTransfer          = errors.New("invalid transfer")
+
+	ErrVerificationTokenNotFound = errors.New("email verification token not found")
+	ErrVerificationTokenExpired  = errors.New("email verification token has expired")
+	ErrVerificationTokenUsed     = errors.New("email verification token has already been used")
+	ErrVerificationResendTooSoon = errors.New("a verification email was sent recently, please try again later")
+	ErrUserAlreadyVerified       = errors.New("user email address is already verified")
+
+	ErrInvalidPassword           = errors.New("invalid password")
+	ErrIncorrectPassword         = errors.New("current password is incorrect")
+	ErrPasswordReused            = errors.New("password must not match any of your recent passwords")
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	ErrPasswordResetTooSoon      = errors.New("a password reset email was sent recently, please try again later")
+
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrLoginThrottled     = errors.New("too many failed login attempts, try again later")
+	ErrUserLocked         = errors.New("user is locked after too many failed login attempts")
+	ErrUserSuspended      = errors.New("user is suspended")
+	ErrUserNotActive      = errors.New("user must be active")
+
+	ErrAdminNotFound         = errors.New("admin not found")
+	ErrInvalidAuditFilter    = errors.New("invalid audit filter")
+	ErrPasswordResetRequired = errors.New("password reset required, please use the link sent to your email")
+	// ErrResetEmailNotSent means a forced reset was committed but its email failed
+	ErrResetEmailNotSent = errors.New("password reset email could not be sent, the user can request another link")
+
+	ErrInvalidUserTransition = errors.New("invalid user status change")
+
+	ErrTOTPNotEnrolled    = errors.New("two-factor authentication is not set up")
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrInvalidTOTPCode    = errors.New("invalid verification code")
+
+	// ErrVersionMismatch means the resource changed after the caller read it
+	ErrVersionMismatch = errors.New("resource has been modified, fetch it again before retrying")
+)
+
+// PasswordPolicyError lists every rule a new password breaks, so that all of
+// them can be shown to the user at once.
+type PasswordPolicyError struct {
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrInvalidPassword.Error() + ": " + strings.Join(e.Violations, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrInvalidPassword
+}
+
+// RetryAfterError is returned when a request is refused for a period, so that
+// callers can be told when to try again.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
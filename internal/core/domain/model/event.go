@@ -0,0 +1,54 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	EventUserCreated       = "user.created"
+	EventEmailVerified     = "user.email_verified"
+	EventAccountOpened     = "account.opened"
+	EventTransactionPosted = "transaction.posted"
+)
+
+// Event is a domain event recorded in the outbox in the same database
+// transaction as the change it describes, then published at least once.
+type Event struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregateId"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	Attempts    int             `json:"-"`
+}
+
+// UserCreatedEvent carries only the user's ID; events leave the bank through
+// publishers we do not control, so consumers look up personal details.
+type UserCreatedEvent struct {
+	UserID string `json:"userId"`
+}
+
+type EmailVerifiedEvent struct {
+	UserID string `json:"userId"`
+}
+
+type AccountOpenedEvent struct {
+	AccountNumber string `json:"accountNumber"`
+	UserID        string `json:"userId"`
+	AccountType   string `json:"accountType"`
+	Currency      string `json:"currency"`
+}
+
+type TransactionPostedEvent struct {
+	TransactionID string          `json:"transactionId"`
+	AccountNumber string          `json:"accountNumber"`
+	UserID        string          `json:"userId"`
+	Type          string          `json:"type"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	BalanceAfter  decimal.Decimal `json:"balanceAfter"`
+	TransferID    *string         `json:"transferId,omitempty"`
+}
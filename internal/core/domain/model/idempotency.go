@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// IdempotencyRecord is the stored outcome of a request made with an
+// Idempotency-Key header. StatusCode is zero while the original request is
+// still being processed. Headers holds the response headers that are replayed
+// with the body, such as Location.
+type IdempotencyRecord struct {
+	Scope        string
+	Key          string
+	Method       string
+	Path         string
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	Headers      map[string]string
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Scopes that failed logins are counted against
+const (
+	LoginScopeEmail = "email"
+	LoginScopeIP    = "ip"
+	LoginScopeMFA   = "mfa"
+	LoginScopeAdmin = "admin"
+	// wrong current passwords given when changing password, per user ID
+	LoginScopePassword = "password"
+)
+
+// FailedLogins counts recent failed logins for an email address or client IP.
+type FailedLogins struct {
+	Failures      int
+	LastFailureAt time.Time
+}
+
+// UserLockout records a user suspended for too many failed logins, and the
+// status to restore once the lock is lifted.
+type UserLockout struct {
+	UserID         string
+	PreviousStatus string
+	LockedUntil    time.Time
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// TOTP is a user's time-based one-time password enrolment. Secret is
+// encrypted; EnabledAt is nil until the user proves their authenticator
+// works by entering a code.
+type TOTP struct {
+	UserID       string
+	Secret       []byte
+	EnabledAt    *time.Time
+	LastUsedStep *int64
+	CreatedAt    time.Time
+}
+
+// TOTPEnrolment is shown to the user once so they can add the secret to an
+// authenticator app.
+type TOTPEnrolment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauthUri"`
+}
+
+// MFAChallenge is issued by Login in place of a token pair when the user
+// must also present a second factor.
+type MFAChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TransactionDepositType    = "deposit"
+	TransactionWithdrawalType = "withdrawal"
+)
+
+type NewTransaction struct {
+	AccountNumber string          `json:"-"`
+	UserID        string          `json:"-"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency" valid:"required"`
+	Type          string          `json:"type" valid:"required"`
+	Reference     *string         `json:"reference"`
+	TransactionID string          `json:"-"`
+}
+
+type NewTransfer struct {
+	FromAccountNumber   string          `json:"-"`
+	ToSortCode          string          `json:"sortCode"`
+	ToAccountNumber     string          `json:"accountNumber"`
+	UserID              string          `json:"-"`
+	PayeeUserID         string          `json:"-"`
+	Amount              decimal.Decimal `json:"amount"`
+	Currency            string          `json:"currency"`
+	Reference           *string         `json:"reference"`
+	TransferID          string          `json:"-"`
+	DebitTransactionID  string          `json:"-"`
+	CreditTransactionID string          `json:"-"`
+}
+
+type Transaction struct {
+	ID               string          `json:"id"`
+	Amount           decimal.Decimal `json:"amount"`
+	Currency         string          `json:"currency"`
+	Type             string          `json:"type"`
+	Reference        *string         `json:"reference,omitempty"`
+	UserID           string          `json:"userId"`
+	TransferID       *string         `json:"transferId,omitempty"`
+	CreatedTimestamp time.Time       `json:"createdTimestamp"`
+}
+
+// Transfer is returned to the payer, so only the debit leg posted to their own
+// account is included; the credit leg belongs to the payee's ledger.
+type Transfer struct {
+	ID                string          `json:"id"`
+	FromAccountNumber string          `json:"fromAccountNumber"`
+	ToSortCode        string          `json:"toSortCode"`
+	ToAccountNumber   string          `json:"toAccountNumber"`
+	Amount            decimal.Decimal `json:"amount"`
+	Currency          string          `json:"currency"`
+	Reference         *string         `json:"reference,omitempty"`
+	Transaction       Transaction     `json:"transaction"`
+	CreatedTimestamp  time.Time       `json:"createdTimestamp"`
+}
@@ -2,6 +2,10 @@ package model
 
 import "github.com/asaskevich/govalidator"
 
+// PasswordHistoryDepth is how many recent passwords, including the current
+// one, may not be reused when changing password. Older ones are forgotten.
+const PasswordHistoryDepth = 5
+
 type NewUser struct {
 	Name        string  `json:"name" valid:"required"`
 	Email       string  `json:"email" valid:"required"`
@@ -12,6 +16,8 @@ type NewUser struct {
 	Town        string  `json:"town" valid:"required"`
 	County      *string `json:"county"`
 	Postcode    string  `json:"postcode" valid:"required"`
+
+	VerificationToken string `json:"-"`
 }
 
 type User struct {
@@ -27,6 +33,27 @@ type User struct {
 	Town        string  `json:"town"`
 	County      *string `json:"county"`
 	Postcode    string  `json:"postcode"`
+
+	// PasswordResetRequired is set by an administrator to stop the user logging
+	// in until they reset their password.
+	PasswordResetRequired bool `json:"-"`
+
+	// Version increases with every change and is sent as the ETag
+	Version int64 `json:"-"`
+}
+
+type UpdateUser struct {
+	ID          string  `json:"-"`
+	Name        *string `json:"name"`
+	PhoneNumber *string `json:"phoneNumber"`
+	Line1       *string `json:"line1"`
+	Line2       *string `json:"line2"`
+	Line3       *string `json:"line3"`
+	Town        *string `json:"town"`
+	County      *string `json:"county"`
+	Postcode    *string `json:"postcode"`
+	// Version the caller expects the user to be at, if they gave one
+	Version *int64 `json:"-"`
 }
 
 func (n *NewUser) Valid() (bool, error) {
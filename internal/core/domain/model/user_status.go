@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// User statuses, matching the user_status type in the database
+const (
+	UserStatusAwaitingVerification = "awaiting_verification"
+	UserStatusEmailVerified        = "email_verified"
+	UserStatusActive               = "active"
+	UserStatusSuspended            = "suspended"
+)
+
+// userTransitions lists the statuses a user may move to from each status:
+// verifying their email, setting a password, and being suspended and
+// reactivated by an administrator or a login lockout.
+var userTransitions = map[string][]string{
+	UserStatusAwaitingVerification: {UserStatusEmailVerified},
+	UserStatusEmailVerified:        {UserStatusActive},
+	UserStatusActive:               {UserStatusSuspended},
+	UserStatusSuspended:            {UserStatusActive},
+}
+
+// IsUserStatus reports whether status is one of the user statuses.
+func IsUserStatus(status string) bool {
+	_, ok := userTransitions[status]
+	return ok
+}
+
+// CheckUserTransition returns ErrInvalidUserTransition unless a user may move
+// from one status to the other.
+func CheckUserTransition(from string, to string) error {
+	if !slices.Contains(userTransitions[from], to) {
+		return errors.Wrap(ErrInvalidUserTransition, fmt.Sprintf("cannot move from %s to %s", from, to))
+	}
+	return nil
+}
+
+// IsActive reports whether the user has completed sign up and is not suspended.
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
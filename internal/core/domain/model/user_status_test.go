@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"testing"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUserTransition(t *testing.T) {
+
+	tests := []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{model.UserStatusAwaitingVerification, model.UserStatusEmailVerified, true},
+		{model.UserStatusEmailVerified, model.UserStatusActive, true},
+		{model.UserStatusActive, model.UserStatusSuspended, true},
+		{model.UserStatusSuspended, model.UserStatusActive, true},
+
+		// setting a password requires a verified email first
+		{model.UserStatusAwaitingVerification, model.UserStatusActive, false},
+		// a password can only be set once this way
+		{model.UserStatusActive, model.UserStatusActive, false},
+		{model.UserStatusActive, model.UserStatusEmailVerified, false},
+		{model.UserStatusEmailVerified, model.UserStatusSuspended, false},
+		{model.UserStatusSuspended, model.UserStatusAwaitingVerification, false},
+		{"unknown", model.UserStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := model.CheckUserTransition(tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, model.ErrInvalidUserTransition)
+			}
+		})
+	}
+}
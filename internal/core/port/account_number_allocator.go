@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/account_number_allocator.go . AccountNumberAllocator
+
+// AccountNumberAllocator hands out account numbers that have never been issued
+// before, each matching the 01xxxxxx format with a valid check digit.
+type AccountNumberAllocator interface {
+	AllocateAccountNumber(ctx context.Context) (string, error)
+}
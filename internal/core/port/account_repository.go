@@ -1,11 +1,19 @@
 package port
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 )
 
 //go:generate moq -pkg mocks -out ./mocks/account_repository.go . AccountRepository
 
+// AccountRepository mutations are recorded in the audit log as made by actor.
 type AccountRepository interface {
-	CreateAccount(newAccount *model.NewAccount) (*model.UserAccount, error)
+	CreateAccount(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error)
+	GetAccountByNumber(ctx context.Context, accountNumber string) (*model.UserAccount, error)
+	GetAccount(ctx context.Context, accountNumber string) (*model.Account, error)
+	ListAccountsByUserID(ctx context.Context, userID string) ([]model.Account, error)
+	UpdateAccount(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error)
+	CloseAccount(ctx context.Context, actor model.Actor, accountNumber string, version *int64) error
+	SetAccountStatus(ctx context.Context, actor model.Actor, accountNumber string, status string) error
 }
@@ -1,11 +1,16 @@
 package port
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 )
 
 //go:generate moq -pkg mocks -out ./mocks/account_service.go . AccountService
 
 type AccountService interface {
-	CreateAccount(newAccount *model.NewAccount) (*model.UserAccount, error)
+	CreateAccount(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error)
+	ListAccounts(ctx context.Context, userID string) ([]model.Account, error)
+	GetAccount(ctx context.Context, accountNumber string, userID string) (*model.Account, error)
+	UpdateAccount(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error)
+	CloseAccount(ctx context.Context, actor model.Actor, accountNumber string, userID string, version *int64) error
 }
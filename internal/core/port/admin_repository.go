@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/admin_repository.go . AdminRepository
+
+type AdminRepository interface {
+	// Login returns the ID of the administrator with these credentials.
+	// Disabled administrators cannot log in.
+	Login(ctx context.Context, email string, password string) (string, error)
+	GetAdminByID(ctx context.Context, id string) (*model.Admin, error)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/admin_service.go . AdminService
+
+// AdminService records every read of customer data in the audit log as well
+// as every change.
+type AdminService interface {
+	Login(ctx context.Context, email string, password string, ip string) (*model.Admin, error)
+	// GetAdmin returns ErrAdminNotFound once the admin has been disabled.
+	GetAdmin(ctx context.Context, adminID string) (*model.Admin, error)
+	SearchUsers(ctx context.Context, actor model.Actor, search model.UserSearch) ([]model.User, error)
+	ListUserAccounts(ctx context.Context, actor model.Actor, userID string) ([]model.Account, error)
+	ListAccountTransactions(ctx context.Context, actor model.Actor, accountNumber string) ([]model.Transaction, error)
+	FreezeAccount(ctx context.Context, actor model.Actor, accountNumber string) error
+	UnfreezeAccount(ctx context.Context, actor model.Actor, accountNumber string) error
+	// ForcePasswordReset blocks the user's logins and emails them a reset link.
+	ForcePasswordReset(ctx context.Context, actor model.Actor, userID string) error
+	ListAuditRecords(ctx context.Context, actor model.Actor, filter model.AuditFilter) ([]model.AuditRecord, error)
+}
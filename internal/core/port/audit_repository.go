@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/audit_repository.go . AuditRepository
+
+type AuditRepository interface {
+	// RecordAudit appends a record that is not part of a change, such as an
+	// administrator viewing customer data.
+	RecordAudit(ctx context.Context, record model.AuditRecord) error
+	ListAuditRecords(ctx context.Context, filter model.AuditFilter) ([]model.AuditRecord, error)
+}
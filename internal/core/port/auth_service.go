@@ -1,6 +1,8 @@
 package port
 
 import (
+	"context"
+
 	"eagle-bank.com/internal/core/domain/model"
 	"github.com/gin-gonic/gin"
 )
@@ -8,8 +10,15 @@ import (
 //go:generate moq -pkg mocks -out ./mocks/auth_service.go . AuthService
 
 type AuthService interface {
-	GenerateTokens(userID string, role []string) (*model.TokenPair, error)
+	GenerateTokens(ctx context.Context, userID string, role []string) (*model.TokenPair, error)
+	GenerateMFAToken(ctx context.Context, userID string) (*model.MFAChallenge, error)
+	GenerateAdminToken(adminID string) (*model.AccessToken, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*model.TokenPair, error)
 	ValidateToken(c *gin.Context) error
+	Logout(c *gin.Context, refreshToken string) error
+	RevokeUserSessions(ctx context.Context, userID string) error
 	ExtractTokenID(c *gin.Context) (string, error)
+	ExtractScopes(c *gin.Context) ([]string, error)
 	ValidateSetPasswordToken(c *gin.Context) error
+	JWKS() model.JSONWebKeySet
 }
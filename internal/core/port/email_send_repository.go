@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/email_send_repository.go . EmailSendRepository
+
+type EmailSendRepository interface {
+	// RecordEmailSend records an email of kind sent to address unless one was
+	// already sent since the given time, returning the last email sent and
+	// whether it is this one.
+	RecordEmailSend(ctx context.Context, kind string, address string, since time.Time) (*model.EmailSend, bool, error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/idempotency_repository.go . IdempotencyRepository
+
+type IdempotencyRepository interface {
+	// Reserve stores the record unless the key is already in use, returning the
+	// stored record and whether it was newly created by this call. A key left
+	// in flight for longer than a short lease may be reserved again.
+	Reserve(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error)
+	// Complete and Release act on the reservation returned by Reserve, and do
+	// nothing once it has been taken over by another request.
+	Complete(ctx context.Context, record *model.IdempotencyRecord) error
+	Release(ctx context.Context, record *model.IdempotencyRecord) error
+}
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/login_attempt_repository.go . LoginAttemptRepository
+
+type LoginAttemptRepository interface {
+	// GetFailedLogins returns the failures recorded for key since the given
+	// time, which is zero if there are none.
+	GetFailedLogins(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error)
+	// RecordFailedLogin adds a failure, first forgetting any recorded before
+	// since, and returns the new count.
+	RecordFailedLogin(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error)
+	ResetFailedLogins(ctx context.Context, scope string, key string) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/mailer.go . Mailer
+
+type Mailer interface {
+	Send(message *model.EmailMessage) error
+}
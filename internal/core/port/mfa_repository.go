@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/mfa_repository.go . MFARepository
+
+type MFARepository interface {
+	// SaveTOTPSecret starts or restarts an enrolment, failing with
+	// model.ErrTOTPAlreadyEnabled once one has been activated.
+	SaveTOTPSecret(ctx context.Context, userID string, secret []byte) error
+	GetTOTP(ctx context.Context, userID string) (*model.TOTP, error)
+	// ActivateTOTP enables the enrolment, consuming the code's time step and
+	// replacing the user's recovery codes.
+	ActivateTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	// UseTOTPStep records the time step of a code, returning false if that
+	// step or a later one has already been used.
+	UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	// UseRecoveryCode marks a recovery code used, returning false if it does
+	// not exist or was already used.
+	UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/mfa_service.go . MFAService
+
+type MFAService interface {
+	EnrolTOTP(ctx context.Context, userID string) (*model.TOTPEnrolment, error)
+	// ActivateTOTP returns the user's recovery codes, which are only shown once.
+	ActivateTOTP(ctx context.Context, userID string, code string) ([]string, error)
+	IsTOTPEnabled(ctx context.Context, userID string) (bool, error)
+	// VerifySecondFactor accepts either a current TOTP code or an unused
+	// recovery code.
+	VerifySecondFactor(ctx context.Context, userID string, code string, recoveryCode string) error
+}
@@ -0,0 +1,76 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that AccountNumberAllocatorMock does implement port.AccountNumberAllocator.
+// If this is not the case, regenerate this file with moq.
+var _ port.AccountNumberAllocator = &AccountNumberAllocatorMock{}
+
+// AccountNumberAllocatorMock is a mock implementation of port.AccountNumberAllocator.
+//
+//	func TestSomethingThatUsesAccountNumberAllocator(t *testing.T) {
+//
+//		// make and configure a mocked port.AccountNumberAllocator
+//		mockedAccountNumberAllocator := &AccountNumberAllocatorMock{
+//			AllocateAccountNumberFunc: func(ctx context.Context) (string, error) {
+//				panic("mock out the AllocateAccountNumber method")
+//			},
+//		}
+//
+//		// use mockedAccountNumberAllocator in code that requires port.AccountNumberAllocator
+//		// and then make assertions.
+//
+//	}
+type AccountNumberAllocatorMock struct {
+	// AllocateAccountNumberFunc mocks the AllocateAccountNumber method.
+	AllocateAccountNumberFunc func(ctx context.Context) (string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AllocateAccountNumber holds details about calls to the AllocateAccountNumber method.
+		AllocateAccountNumber []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockAllocateAccountNumber sync.RWMutex
+}
+
+// AllocateAccountNumber calls AllocateAccountNumberFunc.
+func (mock *AccountNumberAllocatorMock) AllocateAccountNumber(ctx context.Context) (string, error) {
+	if mock.AllocateAccountNumberFunc == nil {
+		panic("AccountNumberAllocatorMock.AllocateAccountNumberFunc: method is nil but AccountNumberAllocator.AllocateAccountNumber was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockAllocateAccountNumber.Lock()
+	mock.calls.AllocateAccountNumber = append(mock.calls.AllocateAccountNumber, callInfo)
+	mock.lockAllocateAccountNumber.Unlock()
+	return mock.AllocateAccountNumberFunc(ctx)
+}
+
+// AllocateAccountNumberCalls gets all the calls that were made to AllocateAccountNumber.
+// Check the length with:
+//
+//	len(mockedAccountNumberAllocator.AllocateAccountNumberCalls())
+func (mock *AccountNumberAllocatorMock) AllocateAccountNumberCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockAllocateAccountNumber.RLock()
+	calls = mock.calls.AllocateAccountNumber
+	mock.lockAllocateAccountNumber.RUnlock()
+	return calls
+}
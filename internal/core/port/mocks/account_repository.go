@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"sync"
@@ -19,9 +20,27 @@ var _ port.AccountRepository = &AccountRepositoryMock{}
 //
 //		// make and configure a mocked port.AccountRepository
 //		mockedAccountRepository := &AccountRepositoryMock{
-//			CreateAccountFunc: func(newAccount *model.NewAccount) (*model.UserAccount, error) {
+//			CloseAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string, version *int64) error {
+//				panic("mock out the CloseAccount method")
+//			},
+//			CreateAccountFunc: func(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
 //				panic("mock out the CreateAccount method")
 //			},
+//			GetAccountFunc: func(ctx context.Context, accountNumber string) (*model.Account, error) {
+//				panic("mock out the GetAccount method")
+//			},
+//			GetAccountByNumberFunc: func(ctx context.Context, accountNumber string) (*model.UserAccount, error) {
+//				panic("mock out the GetAccountByNumber method")
+//			},
+//			ListAccountsByUserIDFunc: func(ctx context.Context, userID string) ([]model.Account, error) {
+//				panic("mock out the ListAccountsByUserID method")
+//			},
+//			SetAccountStatusFunc: func(ctx context.Context, actor model.Actor, accountNumber string, status string) error {
+//				panic("mock out the SetAccountStatus method")
+//			},
+//			UpdateAccountFunc: func(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+//				panic("mock out the UpdateAccount method")
+//			},
 //		}
 //
 //		// use mockedAccountRepository in code that requires port.AccountRepository
@@ -29,34 +48,162 @@ var _ port.AccountRepository = &AccountRepositoryMock{}
 //
 //	}
 type AccountRepositoryMock struct {
+	// CloseAccountFunc mocks the CloseAccount method.
+	CloseAccountFunc func(ctx context.Context, actor model.Actor, accountNumber string, version *int64) error
+
 	// CreateAccountFunc mocks the CreateAccount method.
-	CreateAccountFunc func(newAccount *model.NewAccount) (*model.UserAccount, error)
+	CreateAccountFunc func(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error)
+
+	// GetAccountFunc mocks the GetAccount method.
+	GetAccountFunc func(ctx context.Context, accountNumber string) (*model.Account, error)
+
+	// GetAccountByNumberFunc mocks the GetAccountByNumber method.
+	GetAccountByNumberFunc func(ctx context.Context, accountNumber string) (*model.UserAccount, error)
+
+	// ListAccountsByUserIDFunc mocks the ListAccountsByUserID method.
+	ListAccountsByUserIDFunc func(ctx context.Context, userID string) ([]model.Account, error)
+
+	// SetAccountStatusFunc mocks the SetAccountStatus method.
+	SetAccountStatusFunc func(ctx context.Context, actor model.Actor, accountNumber string, status string) error
+
+	// UpdateAccountFunc mocks the UpdateAccount method.
+	UpdateAccountFunc func(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CloseAccount holds details about calls to the CloseAccount method.
+		CloseAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+			// Version is the version argument value.
+			Version *int64
+		}
 		// CreateAccount holds details about calls to the CreateAccount method.
 		CreateAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// NewAccount is the newAccount argument value.
 			NewAccount *model.NewAccount
 		}
+		// GetAccount holds details about calls to the GetAccount method.
+		GetAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+		}
+		// GetAccountByNumber holds details about calls to the GetAccountByNumber method.
+		GetAccountByNumber []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+		}
+		// ListAccountsByUserID holds details about calls to the ListAccountsByUserID method.
+		ListAccountsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// SetAccountStatus holds details about calls to the SetAccountStatus method.
+		SetAccountStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+			// Status is the status argument value.
+			Status string
+		}
+		// UpdateAccount holds details about calls to the UpdateAccount method.
+		UpdateAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Update is the update argument value.
+			Update *model.UpdateAccount
+		}
 	}
-	lockCreateAccount sync.RWMutex
+	lockCloseAccount         sync.RWMutex
+	lockCreateAccount        sync.RWMutex
+	lockGetAccount           sync.RWMutex
+	lockGetAccountByNumber   sync.RWMutex
+	lockListAccountsByUserID sync.RWMutex
+	lockSetAccountStatus     sync.RWMutex
+	lockUpdateAccount        sync.RWMutex
+}
+
+// CloseAccount calls CloseAccountFunc.
+func (mock *AccountRepositoryMock) CloseAccount(ctx context.Context, actor model.Actor, accountNumber string, version *int64) error {
+	if mock.CloseAccountFunc == nil {
+		panic("AccountRepositoryMock.CloseAccountFunc: method is nil but AccountRepository.CloseAccount was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+		Version       *int64
+	}{
+		Ctx:           ctx,
+		Actor:         actor,
+		AccountNumber: accountNumber,
+		Version:       version,
+	}
+	mock.lockCloseAccount.Lock()
+	mock.calls.CloseAccount = append(mock.calls.CloseAccount, callInfo)
+	mock.lockCloseAccount.Unlock()
+	return mock.CloseAccountFunc(ctx, actor, accountNumber, version)
+}
+
+// CloseAccountCalls gets all the calls that were made to CloseAccount.
+// Check the length with:
+//
+//	len(mockedAccountRepository.CloseAccountCalls())
+func (mock *AccountRepositoryMock) CloseAccountCalls() []struct {
+	Ctx           context.Context
+	Actor         model.Actor
+	AccountNumber string
+	Version       *int64
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+		Version       *int64
+	}
+	mock.lockCloseAccount.RLock()
+	calls = mock.calls.CloseAccount
+	mock.lockCloseAccount.RUnlock()
+	return calls
 }
 
 // CreateAccount calls CreateAccountFunc.
-func (mock *AccountRepositoryMock) CreateAccount(newAccount *model.NewAccount) (*model.UserAccount, error) {
+func (mock *AccountRepositoryMock) CreateAccount(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
 	if mock.CreateAccountFunc == nil {
 		panic("AccountRepositoryMock.CreateAccountFunc: method is nil but AccountRepository.CreateAccount was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		NewAccount *model.NewAccount
 	}{
+		Ctx:        ctx,
+		Actor:      actor,
 		NewAccount: newAccount,
 	}
 	mock.lockCreateAccount.Lock()
 	mock.calls.CreateAccount = append(mock.calls.CreateAccount, callInfo)
 	mock.lockCreateAccount.Unlock()
-	return mock.CreateAccountFunc(newAccount)
+	return mock.CreateAccountFunc(ctx, actor, newAccount)
 }
 
 // CreateAccountCalls gets all the calls that were made to CreateAccount.
@@ -64,9 +211,13 @@ func (mock *AccountRepositoryMock) CreateAccount(newAccount *model.NewAccount) (
 //
 //	len(mockedAccountRepository.CreateAccountCalls())
 func (mock *AccountRepositoryMock) CreateAccountCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
 	NewAccount *model.NewAccount
 } {
 	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		NewAccount *model.NewAccount
 	}
 	mock.lockCreateAccount.RLock()
@@ -74,3 +225,195 @@ func (mock *AccountRepositoryMock) CreateAccountCalls() []struct {
 	mock.lockCreateAccount.RUnlock()
 	return calls
 }
+
+// GetAccount calls GetAccountFunc.
+func (mock *AccountRepositoryMock) GetAccount(ctx context.Context, accountNumber string) (*model.Account, error) {
+	if mock.GetAccountFunc == nil {
+		panic("AccountRepositoryMock.GetAccountFunc: method is nil but AccountRepository.GetAccount was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		AccountNumber string
+	}{
+		Ctx:           ctx,
+		AccountNumber: accountNumber,
+	}
+	mock.lockGetAccount.Lock()
+	mock.calls.GetAccount = append(mock.calls.GetAccount, callInfo)
+	mock.lockGetAccount.Unlock()
+	return mock.GetAccountFunc(ctx, accountNumber)
+}
+
+// GetAccountCalls gets all the calls that were made to GetAccount.
+// Check the length with:
+//
+//	len(mockedAccountRepository.GetAccountCalls())
+func (mock *AccountRepositoryMock) GetAccountCalls() []struct {
+	Ctx           context.Context
+	AccountNumber string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		AccountNumber string
+	}
+	mock.lockGetAccount.RLock()
+	calls = mock.calls.GetAccount
+	mock.lockGetAccount.RUnlock()
+	return calls
+}
+
+// GetAccountByNumber calls GetAccountByNumberFunc.
+func (mock *AccountRepositoryMock) GetAccountByNumber(ctx context.Context, accountNumber string) (*model.UserAccount, error) {
+	if mock.GetAccountByNumberFunc == nil {
+		panic("AccountRepositoryMock.GetAccountByNumberFunc: method is nil but AccountRepository.GetAccountByNumber was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		AccountNumber string
+	}{
+		Ctx:           ctx,
+		AccountNumber: accountNumber,
+	}
+	mock.lockGetAccountByNumber.Lock()
+	mock.calls.GetAccountByNumber = append(mock.calls.GetAccountByNumber, callInfo)
+	mock.lockGetAccountByNumber.Unlock()
+	return mock.GetAccountByNumberFunc(ctx, accountNumber)
+}
+
+// GetAccountByNumberCalls gets all the calls that were made to GetAccountByNumber.
+// Check the length with:
+//
+//	len(mockedAccountRepository.GetAccountByNumberCalls())
+func (mock *AccountRepositoryMock) GetAccountByNumberCalls() []struct {
+	Ctx           context.Context
+	AccountNumber string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		AccountNumber string
+	}
+	mock.lockGetAccountByNumber.RLock()
+	calls = mock.calls.GetAccountByNumber
+	mock.lockGetAccountByNumber.RUnlock()
+	return calls
+}
+
+// ListAccountsByUserID calls ListAccountsByUserIDFunc.
+func (mock *AccountRepositoryMock) ListAccountsByUserID(ctx context.Context, userID string) ([]model.Account, error) {
+	if mock.ListAccountsByUserIDFunc == nil {
+		panic("AccountRepositoryMock.ListAccountsByUserIDFunc: method is nil but AccountRepository.ListAccountsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListAccountsByUserID.Lock()
+	mock.calls.ListAccountsByUserID = append(mock.calls.ListAccountsByUserID, callInfo)
+	mock.lockListAccountsByUserID.Unlock()
+	return mock.ListAccountsByUserIDFunc(ctx, userID)
+}
+
+// ListAccountsByUserIDCalls gets all the calls that were made to ListAccountsByUserID.
+// Check the length with:
+//
+//	len(mockedAccountRepository.ListAccountsByUserIDCalls())
+func (mock *AccountRepositoryMock) ListAccountsByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockListAccountsByUserID.RLock()
+	calls = mock.calls.ListAccountsByUserID
+	mock.lockListAccountsByUserID.RUnlock()
+	return calls
+}
+
+// SetAccountStatus calls SetAccountStatusFunc.
+func (mock *AccountRepositoryMock) SetAccountStatus(ctx context.Context, actor model.Actor, accountNumber string, status string) error {
+	if mock.SetAccountStatusFunc == nil {
+		panic("AccountRepositoryMock.SetAccountStatusFunc: method is nil but AccountRepository.SetAccountStatus was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+		Status        string
+	}{
+		Ctx:           ctx,
+		Actor:         actor,
+		AccountNumber: accountNumber,
+		Status:        status,
+	}
+	mock.lockSetAccountStatus.Lock()
+	mock.calls.SetAccountStatus = append(mock.calls.SetAccountStatus, callInfo)
+	mock.lockSetAccountStatus.Unlock()
+	return mock.SetAccountStatusFunc(ctx, actor, accountNumber, status)
+}
+
+// SetAccountStatusCalls gets all the calls that were made to SetAccountStatus.
+// Check the length with:
+//
+//	len(mockedAccountRepository.SetAccountStatusCalls())
+func (mock *AccountRepositoryMock) SetAccountStatusCalls() []struct {
+	Ctx           context.Context
+	Actor         model.Actor
+	AccountNumber string
+	Status        string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+		Status        string
+	}
+	mock.lockSetAccountStatus.RLock()
+	calls = mock.calls.SetAccountStatus
+	mock.lockSetAccountStatus.RUnlock()
+	return calls
+}
+
+// UpdateAccount calls UpdateAccountFunc.
+func (mock *AccountRepositoryMock) UpdateAccount(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+	if mock.UpdateAccountFunc == nil {
+		panic("AccountRepositoryMock.UpdateAccountFunc: method is nil but AccountRepository.UpdateAccount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Update *model.UpdateAccount
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		Update: update,
+	}
+	mock.lockUpdateAccount.Lock()
+	mock.calls.UpdateAccount = append(mock.calls.UpdateAccount, callInfo)
+	mock.lockUpdateAccount.Unlock()
+	return mock.UpdateAccountFunc(ctx, actor, update)
+}
+
+// UpdateAccountCalls gets all the calls that were made to UpdateAccount.
+// Check the length with:
+//
+//	len(mockedAccountRepository.UpdateAccountCalls())
+func (mock *AccountRepositoryMock) UpdateAccountCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	Update *model.UpdateAccount
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Update *model.UpdateAccount
+	}
+	mock.lockUpdateAccount.RLock()
+	calls = mock.calls.UpdateAccount
+	mock.lockUpdateAccount.RUnlock()
+	return calls
+}
@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"sync"
@@ -19,9 +20,21 @@ var _ port.AccountService = &AccountServiceMock{}
 //
 //		// make and configure a mocked port.AccountService
 //		mockedAccountService := &AccountServiceMock{
-//			CreateAccountFunc: func(newAccount *model.NewAccount) (*model.UserAccount, error) {
+//			CloseAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string, userID string, version *int64) error {
+//				panic("mock out the CloseAccount method")
+//			},
+//			CreateAccountFunc: func(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
 //				panic("mock out the CreateAccount method")
 //			},
+//			GetAccountFunc: func(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+//				panic("mock out the GetAccount method")
+//			},
+//			ListAccountsFunc: func(ctx context.Context, userID string) ([]model.Account, error) {
+//				panic("mock out the ListAccounts method")
+//			},
+//			UpdateAccountFunc: func(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+//				panic("mock out the UpdateAccount method")
+//			},
 //		}
 //
 //		// use mockedAccountService in code that requires port.AccountService
@@ -29,34 +42,144 @@ var _ port.AccountService = &AccountServiceMock{}
 //
 //	}
 type AccountServiceMock struct {
+	// CloseAccountFunc mocks the CloseAccount method.
+	CloseAccountFunc func(ctx context.Context, actor model.Actor, accountNumber string, userID string, version *int64) error
+
 	// CreateAccountFunc mocks the CreateAccount method.
-	CreateAccountFunc func(newAccount *model.NewAccount) (*model.UserAccount, error)
+	CreateAccountFunc func(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error)
+
+	// GetAccountFunc mocks the GetAccount method.
+	GetAccountFunc func(ctx context.Context, accountNumber string, userID string) (*model.Account, error)
+
+	// ListAccountsFunc mocks the ListAccounts method.
+	ListAccountsFunc func(ctx context.Context, userID string) ([]model.Account, error)
+
+	// UpdateAccountFunc mocks the UpdateAccount method.
+	UpdateAccountFunc func(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CloseAccount holds details about calls to the CloseAccount method.
+		CloseAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+			// UserID is the userID argument value.
+			UserID string
+			// Version is the version argument value.
+			Version *int64
+		}
 		// CreateAccount holds details about calls to the CreateAccount method.
 		CreateAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// NewAccount is the newAccount argument value.
 			NewAccount *model.NewAccount
 		}
+		// GetAccount holds details about calls to the GetAccount method.
+		GetAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// ListAccounts holds details about calls to the ListAccounts method.
+		ListAccounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// UpdateAccount holds details about calls to the UpdateAccount method.
+		UpdateAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Update is the update argument value.
+			Update *model.UpdateAccount
+		}
 	}
+	lockCloseAccount  sync.RWMutex
 	lockCreateAccount sync.RWMutex
+	lockGetAccount    sync.RWMutex
+	lockListAccounts  sync.RWMutex
+	lockUpdateAccount sync.RWMutex
+}
+
+// CloseAccount calls CloseAccountFunc.
+func (mock *AccountServiceMock) CloseAccount(ctx context.Context, actor model.Actor, accountNumber string, userID string, version *int64) error {
+	if mock.CloseAccountFunc == nil {
+		panic("AccountServiceMock.CloseAccountFunc: method is nil but AccountService.CloseAccount was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+		UserID        string
+		Version       *int64
+	}{
+		Ctx:           ctx,
+		Actor:         actor,
+		AccountNumber: accountNumber,
+		UserID:        userID,
+		Version:       version,
+	}
+	mock.lockCloseAccount.Lock()
+	mock.calls.CloseAccount = append(mock.calls.CloseAccount, callInfo)
+	mock.lockCloseAccount.Unlock()
+	return mock.CloseAccountFunc(ctx, actor, accountNumber, userID, version)
+}
+
+// CloseAccountCalls gets all the calls that were made to CloseAccount.
+// Check the length with:
+//
+//	len(mockedAccountService.CloseAccountCalls())
+func (mock *AccountServiceMock) CloseAccountCalls() []struct {
+	Ctx           context.Context
+	Actor         model.Actor
+	AccountNumber string
+	UserID        string
+	Version       *int64
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+		UserID        string
+		Version       *int64
+	}
+	mock.lockCloseAccount.RLock()
+	calls = mock.calls.CloseAccount
+	mock.lockCloseAccount.RUnlock()
+	return calls
 }
 
 // CreateAccount calls CreateAccountFunc.
-func (mock *AccountServiceMock) CreateAccount(newAccount *model.NewAccount) (*model.UserAccount, error) {
+func (mock *AccountServiceMock) CreateAccount(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
 	if mock.CreateAccountFunc == nil {
 		panic("AccountServiceMock.CreateAccountFunc: method is nil but AccountService.CreateAccount was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		NewAccount *model.NewAccount
 	}{
+		Ctx:        ctx,
+		Actor:      actor,
 		NewAccount: newAccount,
 	}
 	mock.lockCreateAccount.Lock()
 	mock.calls.CreateAccount = append(mock.calls.CreateAccount, callInfo)
 	mock.lockCreateAccount.Unlock()
-	return mock.CreateAccountFunc(newAccount)
+	return mock.CreateAccountFunc(ctx, actor, newAccount)
 }
 
 // CreateAccountCalls gets all the calls that were made to CreateAccount.
@@ -64,9 +187,13 @@ func (mock *AccountServiceMock) CreateAccount(newAccount *model.NewAccount) (*mo
 //
 //	len(mockedAccountService.CreateAccountCalls())
 func (mock *AccountServiceMock) CreateAccountCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
 	NewAccount *model.NewAccount
 } {
 	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		NewAccount *model.NewAccount
 	}
 	mock.lockCreateAccount.RLock()
@@ -74,3 +201,119 @@ func (mock *AccountServiceMock) CreateAccountCalls() []struct {
 	mock.lockCreateAccount.RUnlock()
 	return calls
 }
+
+// GetAccount calls GetAccountFunc.
+func (mock *AccountServiceMock) GetAccount(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+	if mock.GetAccountFunc == nil {
+		panic("AccountServiceMock.GetAccountFunc: method is nil but AccountService.GetAccount was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		AccountNumber string
+		UserID        string
+	}{
+		Ctx:           ctx,
+		AccountNumber: accountNumber,
+		UserID:        userID,
+	}
+	mock.lockGetAccount.Lock()
+	mock.calls.GetAccount = append(mock.calls.GetAccount, callInfo)
+	mock.lockGetAccount.Unlock()
+	return mock.GetAccountFunc(ctx, accountNumber, userID)
+}
+
+// GetAccountCalls gets all the calls that were made to GetAccount.
+// Check the length with:
+//
+//	len(mockedAccountService.GetAccountCalls())
+func (mock *AccountServiceMock) GetAccountCalls() []struct {
+	Ctx           context.Context
+	AccountNumber string
+	UserID        string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		AccountNumber string
+		UserID        string
+	}
+	mock.lockGetAccount.RLock()
+	calls = mock.calls.GetAccount
+	mock.lockGetAccount.RUnlock()
+	return calls
+}
+
+// ListAccounts calls ListAccountsFunc.
+func (mock *AccountServiceMock) ListAccounts(ctx context.Context, userID string) ([]model.Account, error) {
+	if mock.ListAccountsFunc == nil {
+		panic("AccountServiceMock.ListAccountsFunc: method is nil but AccountService.ListAccounts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListAccounts.Lock()
+	mock.calls.ListAccounts = append(mock.calls.ListAccounts, callInfo)
+	mock.lockListAccounts.Unlock()
+	return mock.ListAccountsFunc(ctx, userID)
+}
+
+// ListAccountsCalls gets all the calls that were made to ListAccounts.
+// Check the length with:
+//
+//	len(mockedAccountService.ListAccountsCalls())
+func (mock *AccountServiceMock) ListAccountsCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockListAccounts.RLock()
+	calls = mock.calls.ListAccounts
+	mock.lockListAccounts.RUnlock()
+	return calls
+}
+
+// UpdateAccount calls UpdateAccountFunc.
+func (mock *AccountServiceMock) UpdateAccount(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+	if mock.UpdateAccountFunc == nil {
+		panic("AccountServiceMock.UpdateAccountFunc: method is nil but AccountService.UpdateAccount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Update *model.UpdateAccount
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		Update: update,
+	}
+	mock.lockUpdateAccount.Lock()
+	mock.calls.UpdateAccount = append(mock.calls.UpdateAccount, callInfo)
+	mock.lockUpdateAccount.Unlock()
+	return mock.UpdateAccountFunc(ctx, actor, update)
+}
+
+// UpdateAccountCalls gets all the calls that were made to UpdateAccount.
+// Check the length with:
+//
+//	len(mockedAccountService.UpdateAccountCalls())
+func (mock *AccountServiceMock) UpdateAccountCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	Update *model.UpdateAccount
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Update *model.UpdateAccount
+	}
+	mock.lockUpdateAccount.RLock()
+	calls = mock.calls.UpdateAccount
+	mock.lockUpdateAccount.RUnlock()
+	return calls
+}
@@ -0,0 +1,139 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that AdminRepositoryMock does implement port.AdminRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.AdminRepository = &AdminRepositoryMock{}
+
+// AdminRepositoryMock is a mock implementation of port.AdminRepository.
+//
+//	func TestSomethingThatUsesAdminRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.AdminRepository
+//		mockedAdminRepository := &AdminRepositoryMock{
+//			GetAdminByIDFunc: func(ctx context.Context, id string) (*model.Admin, error) {
+//				panic("mock out the GetAdminByID method")
+//			},
+//			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+//				panic("mock out the Login method")
+//			},
+//		}
+//
+//		// use mockedAdminRepository in code that requires port.AdminRepository
+//		// and then make assertions.
+//
+//	}
+type AdminRepositoryMock struct {
+	// GetAdminByIDFunc mocks the GetAdminByID method.
+	GetAdminByIDFunc func(ctx context.Context, id string) (*model.Admin, error)
+
+	// LoginFunc mocks the Login method.
+	LoginFunc func(ctx context.Context, email string, password string) (string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAdminByID holds details about calls to the GetAdminByID method.
+		GetAdminByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// Login holds details about calls to the Login method.
+		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockGetAdminByID sync.RWMutex
+	lockLogin        sync.RWMutex
+}
+
+// GetAdminByID calls GetAdminByIDFunc.
+func (mock *AdminRepositoryMock) GetAdminByID(ctx context.Context, id string) (*model.Admin, error) {
+	if mock.GetAdminByIDFunc == nil {
+		panic("AdminRepositoryMock.GetAdminByIDFunc: method is nil but AdminRepository.GetAdminByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetAdminByID.Lock()
+	mock.calls.GetAdminByID = append(mock.calls.GetAdminByID, callInfo)
+	mock.lockGetAdminByID.Unlock()
+	return mock.GetAdminByIDFunc(ctx, id)
+}
+
+// GetAdminByIDCalls gets all the calls that were made to GetAdminByID.
+// Check the length with:
+//
+//	len(mockedAdminRepository.GetAdminByIDCalls())
+func (mock *AdminRepositoryMock) GetAdminByIDCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetAdminByID.RLock()
+	calls = mock.calls.GetAdminByID
+	mock.lockGetAdminByID.RUnlock()
+	return calls
+}
+
+// Login calls LoginFunc.
+func (mock *AdminRepositoryMock) Login(ctx context.Context, email string, password string) (string, error) {
+	if mock.LoginFunc == nil {
+		panic("AdminRepositoryMock.LoginFunc: method is nil but AdminRepository.Login was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Email    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Email:    email,
+		Password: password,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	return mock.LoginFunc(ctx, email, password)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedAdminRepository.LoginCalls())
+func (mock *AdminRepositoryMock) LoginCalls() []struct {
+	Ctx      context.Context
+	Email    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Email    string
+		Password string
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
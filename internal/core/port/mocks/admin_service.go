@@ -0,0 +1,537 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that AdminServiceMock does implement port.AdminService.
+// If this is not the case, regenerate this file with moq.
+var _ port.AdminService = &AdminServiceMock{}
+
+// AdminServiceMock is a mock implementation of port.AdminService.
+//
+//	func TestSomethingThatUsesAdminService(t *testing.T) {
+//
+//		// make and configure a mocked port.AdminService
+//		mockedAdminService := &AdminServiceMock{
+//			ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+//				panic("mock out the ForcePasswordReset method")
+//			},
+//			FreezeAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string) error {
+//				panic("mock out the FreezeAccount method")
+//			},
+//			GetAdminFunc: func(ctx context.Context, adminID string) (*model.Admin, error) {
+//				panic("mock out the GetAdmin method")
+//			},
+//			ListAccountTransactionsFunc: func(ctx context.Context, actor model.Actor, accountNumber string) ([]model.Transaction, error) {
+//				panic("mock out the ListAccountTransactions method")
+//			},
+//			ListAuditRecordsFunc: func(ctx context.Context, actor model.Actor, filter model.AuditFilter) ([]model.AuditRecord, error) {
+//				panic("mock out the ListAuditRecords method")
+//			},
+//			ListUserAccountsFunc: func(ctx context.Context, actor model.Actor, userID string) ([]model.Account, error) {
+//				panic("mock out the ListUserAccounts method")
+//			},
+//			LoginFunc: func(ctx context.Context, email string, password string, ip string) (*model.Admin, error) {
+//				panic("mock out the Login method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, actor model.Actor, search model.UserSearch) ([]model.User, error) {
+//				panic("mock out the SearchUsers method")
+//			},
+//			UnfreezeAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string) error {
+//				panic("mock out the UnfreezeAccount method")
+//			},
+//		}
+//
+//		// use mockedAdminService in code that requires port.AdminService
+//		// and then make assertions.
+//
+//	}
+type AdminServiceMock struct {
+	// ForcePasswordResetFunc mocks the ForcePasswordReset method.
+	ForcePasswordResetFunc func(ctx context.Context, actor model.Actor, userID string) error
+
+	// FreezeAccountFunc mocks the FreezeAccount method.
+	FreezeAccountFunc func(ctx context.Context, actor model.Actor, accountNumber string) error
+
+	// GetAdminFunc mocks the GetAdmin method.
+	GetAdminFunc func(ctx context.Context, adminID string) (*model.Admin, error)
+
+	// ListAccountTransactionsFunc mocks the ListAccountTransactions method.
+	ListAccountTransactionsFunc func(ctx context.Context, actor model.Actor, accountNumber string) ([]model.Transaction, error)
+
+	// ListAuditRecordsFunc mocks the ListAuditRecords method.
+	ListAuditRecordsFunc func(ctx context.Context, actor model.Actor, filter model.AuditFilter) ([]model.AuditRecord, error)
+
+	// ListUserAccountsFunc mocks the ListUserAccounts method.
+	ListUserAccountsFunc func(ctx context.Context, actor model.Actor, userID string) ([]model.Account, error)
+
+	// LoginFunc mocks the Login method.
+	LoginFunc func(ctx context.Context, email string, password string, ip string) (*model.Admin, error)
+
+	// SearchUsersFunc mocks the SearchUsers method.
+	SearchUsersFunc func(ctx context.Context, actor model.Actor, search model.UserSearch) ([]model.User, error)
+
+	// UnfreezeAccountFunc mocks the UnfreezeAccount method.
+	UnfreezeAccountFunc func(ctx context.Context, actor model.Actor, accountNumber string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ForcePasswordReset holds details about calls to the ForcePasswordReset method.
+		ForcePasswordReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// FreezeAccount holds details about calls to the FreezeAccount method.
+		FreezeAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+		}
+		// GetAdmin holds details about calls to the GetAdmin method.
+		GetAdmin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AdminID is the adminID argument value.
+			AdminID string
+		}
+		// ListAccountTransactions holds details about calls to the ListAccountTransactions method.
+		ListAccountTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+		}
+		// ListAuditRecords holds details about calls to the ListAuditRecords method.
+		ListAuditRecords []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Filter is the filter argument value.
+			Filter model.AuditFilter
+		}
+		// ListUserAccounts holds details about calls to the ListUserAccounts method.
+		ListUserAccounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// Login holds details about calls to the Login method.
+		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+			// Password is the password argument value.
+			Password string
+			// IP is the ip argument value.
+			IP string
+		}
+		// SearchUsers holds details about calls to the SearchUsers method.
+		SearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Search is the search argument value.
+			Search model.UserSearch
+		}
+		// UnfreezeAccount holds details about calls to the UnfreezeAccount method.
+		UnfreezeAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+		}
+	}
+	lockForcePasswordReset      sync.RWMutex
+	lockFreezeAccount           sync.RWMutex
+	lockGetAdmin                sync.RWMutex
+	lockListAccountTransactions sync.RWMutex
+	lockListAuditRecords        sync.RWMutex
+	lockListUserAccounts        sync.RWMutex
+	lockLogin                   sync.RWMutex
+	lockSearchUsers             sync.RWMutex
+	lockUnfreezeAccount         sync.RWMutex
+}
+
+// ForcePasswordReset calls ForcePasswordResetFunc.
+func (mock *AdminServiceMock) ForcePasswordReset(ctx context.Context, actor model.Actor, userID string) error {
+	if mock.ForcePasswordResetFunc == nil {
+		panic("AdminServiceMock.ForcePasswordResetFunc: method is nil but AdminService.ForcePasswordReset was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+	}
+	mock.lockForcePasswordReset.Lock()
+	mock.calls.ForcePasswordReset = append(mock.calls.ForcePasswordReset, callInfo)
+	mock.lockForcePasswordReset.Unlock()
+	return mock.ForcePasswordResetFunc(ctx, actor, userID)
+}
+
+// ForcePasswordResetCalls gets all the calls that were made to ForcePasswordReset.
+// Check the length with:
+//
+//	len(mockedAdminService.ForcePasswordResetCalls())
+func (mock *AdminServiceMock) ForcePasswordResetCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}
+	mock.lockForcePasswordReset.RLock()
+	calls = mock.calls.ForcePasswordReset
+	mock.lockForcePasswordReset.RUnlock()
+	return calls
+}
+
+// FreezeAccount calls FreezeAccountFunc.
+func (mock *AdminServiceMock) FreezeAccount(ctx context.Context, actor model.Actor, accountNumber string) error {
+	if mock.FreezeAccountFunc == nil {
+		panic("AdminServiceMock.FreezeAccountFunc: method is nil but AdminService.FreezeAccount was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+	}{
+		Ctx:           ctx,
+		Actor:         actor,
+		AccountNumber: accountNumber,
+	}
+	mock.lockFreezeAccount.Lock()
+	mock.calls.FreezeAccount = append(mock.calls.FreezeAccount, callInfo)
+	mock.lockFreezeAccount.Unlock()
+	return mock.FreezeAccountFunc(ctx, actor, accountNumber)
+}
+
+// FreezeAccountCalls gets all the calls that were made to FreezeAccount.
+// Check the length with:
+//
+//	len(mockedAdminService.FreezeAccountCalls())
+func (mock *AdminServiceMock) FreezeAccountCalls() []struct {
+	Ctx           context.Context
+	Actor         model.Actor
+	AccountNumber string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+	}
+	mock.lockFreezeAccount.RLock()
+	calls = mock.calls.FreezeAccount
+	mock.lockFreezeAccount.RUnlock()
+	return calls
+}
+
+// GetAdmin calls GetAdminFunc.
+func (mock *AdminServiceMock) GetAdmin(ctx context.Context, adminID string) (*model.Admin, error) {
+	if mock.GetAdminFunc == nil {
+		panic("AdminServiceMock.GetAdminFunc: method is nil but AdminService.GetAdmin was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AdminID string
+	}{
+		Ctx:     ctx,
+		AdminID: adminID,
+	}
+	mock.lockGetAdmin.Lock()
+	mock.calls.GetAdmin = append(mock.calls.GetAdmin, callInfo)
+	mock.lockGetAdmin.Unlock()
+	return mock.GetAdminFunc(ctx, adminID)
+}
+
+// GetAdminCalls gets all the calls that were made to GetAdmin.
+// Check the length with:
+//
+//	len(mockedAdminService.GetAdminCalls())
+func (mock *AdminServiceMock) GetAdminCalls() []struct {
+	Ctx     context.Context
+	AdminID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AdminID string
+	}
+	mock.lockGetAdmin.RLock()
+	calls = mock.calls.GetAdmin
+	mock.lockGetAdmin.RUnlock()
+	return calls
+}
+
+// ListAccountTransactions calls ListAccountTransactionsFunc.
+func (mock *AdminServiceMock) ListAccountTransactions(ctx context.Context, actor model.Actor, accountNumber string) ([]model.Transaction, error) {
+	if mock.ListAccountTransactionsFunc == nil {
+		panic("AdminServiceMock.ListAccountTransactionsFunc: method is nil but AdminService.ListAccountTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+	}{
+		Ctx:           ctx,
+		Actor:         actor,
+		AccountNumber: accountNumber,
+	}
+	mock.lockListAccountTransactions.Lock()
+	mock.calls.ListAccountTransactions = append(mock.calls.ListAccountTransactions, callInfo)
+	mock.lockListAccountTransactions.Unlock()
+	return mock.ListAccountTransactionsFunc(ctx, actor, accountNumber)
+}
+
+// ListAccountTransactionsCalls gets all the calls that were made to ListAccountTransactions.
+// Check the length with:
+//
+//	len(mockedAdminService.ListAccountTransactionsCalls())
+func (mock *AdminServiceMock) ListAccountTransactionsCalls() []struct {
+	Ctx           context.Context
+	Actor         model.Actor
+	AccountNumber string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+	}
+	mock.lockListAccountTransactions.RLock()
+	calls = mock.calls.ListAccountTransactions
+	mock.lockListAccountTransactions.RUnlock()
+	return calls
+}
+
+// ListAuditRecords calls ListAuditRecordsFunc.
+func (mock *AdminServiceMock) ListAuditRecords(ctx context.Context, actor model.Actor, filter model.AuditFilter) ([]model.AuditRecord, error) {
+	if mock.ListAuditRecordsFunc == nil {
+		panic("AdminServiceMock.ListAuditRecordsFunc: method is nil but AdminService.ListAuditRecords was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Filter model.AuditFilter
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		Filter: filter,
+	}
+	mock.lockListAuditRecords.Lock()
+	mock.calls.ListAuditRecords = append(mock.calls.ListAuditRecords, callInfo)
+	mock.lockListAuditRecords.Unlock()
+	return mock.ListAuditRecordsFunc(ctx, actor, filter)
+}
+
+// ListAuditRecordsCalls gets all the calls that were made to ListAuditRecords.
+// Check the length with:
+//
+//	len(mockedAdminService.ListAuditRecordsCalls())
+func (mock *AdminServiceMock) ListAuditRecordsCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	Filter model.AuditFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Filter model.AuditFilter
+	}
+	mock.lockListAuditRecords.RLock()
+	calls = mock.calls.ListAuditRecords
+	mock.lockListAuditRecords.RUnlock()
+	return calls
+}
+
+// ListUserAccounts calls ListUserAccountsFunc.
+func (mock *AdminServiceMock) ListUserAccounts(ctx context.Context, actor model.Actor, userID string) ([]model.Account, error) {
+	if mock.ListUserAccountsFunc == nil {
+		panic("AdminServiceMock.ListUserAccountsFunc: method is nil but AdminService.ListUserAccounts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+	}
+	mock.lockListUserAccounts.Lock()
+	mock.calls.ListUserAccounts = append(mock.calls.ListUserAccounts, callInfo)
+	mock.lockListUserAccounts.Unlock()
+	return mock.ListUserAccountsFunc(ctx, actor, userID)
+}
+
+// ListUserAccountsCalls gets all the calls that were made to ListUserAccounts.
+// Check the length with:
+//
+//	len(mockedAdminService.ListUserAccountsCalls())
+func (mock *AdminServiceMock) ListUserAccountsCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}
+	mock.lockListUserAccounts.RLock()
+	calls = mock.calls.ListUserAccounts
+	mock.lockListUserAccounts.RUnlock()
+	return calls
+}
+
+// Login calls LoginFunc.
+func (mock *AdminServiceMock) Login(ctx context.Context, email string, password string, ip string) (*model.Admin, error) {
+	if mock.LoginFunc == nil {
+		panic("AdminServiceMock.LoginFunc: method is nil but AdminService.Login was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Email    string
+		Password string
+		IP       string
+	}{
+		Ctx:      ctx,
+		Email:    email,
+		Password: password,
+		IP:       ip,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	return mock.LoginFunc(ctx, email, password, ip)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedAdminService.LoginCalls())
+func (mock *AdminServiceMock) LoginCalls() []struct {
+	Ctx      context.Context
+	Email    string
+	Password string
+	IP       string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Email    string
+		Password string
+		IP       string
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
+
+// SearchUsers calls SearchUsersFunc.
+func (mock *AdminServiceMock) SearchUsers(ctx context.Context, actor model.Actor, search model.UserSearch) ([]model.User, error) {
+	if mock.SearchUsersFunc == nil {
+		panic("AdminServiceMock.SearchUsersFunc: method is nil but AdminService.SearchUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Search model.UserSearch
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		Search: search,
+	}
+	mock.lockSearchUsers.Lock()
+	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
+	mock.lockSearchUsers.Unlock()
+	return mock.SearchUsersFunc(ctx, actor, search)
+}
+
+// SearchUsersCalls gets all the calls that were made to SearchUsers.
+// Check the length with:
+//
+//	len(mockedAdminService.SearchUsersCalls())
+func (mock *AdminServiceMock) SearchUsersCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	Search model.UserSearch
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Search model.UserSearch
+	}
+	mock.lockSearchUsers.RLock()
+	calls = mock.calls.SearchUsers
+	mock.lockSearchUsers.RUnlock()
+	return calls
+}
+
+// UnfreezeAccount calls UnfreezeAccountFunc.
+func (mock *AdminServiceMock) UnfreezeAccount(ctx context.Context, actor model.Actor, accountNumber string) error {
+	if mock.UnfreezeAccountFunc == nil {
+		panic("AdminServiceMock.UnfreezeAccountFunc: method is nil but AdminService.UnfreezeAccount was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+	}{
+		Ctx:           ctx,
+		Actor:         actor,
+		AccountNumber: accountNumber,
+	}
+	mock.lockUnfreezeAccount.Lock()
+	mock.calls.UnfreezeAccount = append(mock.calls.UnfreezeAccount, callInfo)
+	mock.lockUnfreezeAccount.Unlock()
+	return mock.UnfreezeAccountFunc(ctx, actor, accountNumber)
+}
+
+// UnfreezeAccountCalls gets all the calls that were made to UnfreezeAccount.
+// Check the length with:
+//
+//	len(mockedAdminService.UnfreezeAccountCalls())
+func (mock *AdminServiceMock) UnfreezeAccountCalls() []struct {
+	Ctx           context.Context
+	Actor         model.Actor
+	AccountNumber string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Actor         model.Actor
+		AccountNumber string
+	}
+	mock.lockUnfreezeAccount.RLock()
+	calls = mock.calls.UnfreezeAccount
+	mock.lockUnfreezeAccount.RUnlock()
+	return calls
+}
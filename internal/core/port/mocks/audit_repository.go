@@ -0,0 +1,133 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that AuditRepositoryMock does implement port.AuditRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.AuditRepository = &AuditRepositoryMock{}
+
+// AuditRepositoryMock is a mock implementation of port.AuditRepository.
+//
+//	func TestSomethingThatUsesAuditRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.AuditRepository
+//		mockedAuditRepository := &AuditRepositoryMock{
+//			ListAuditRecordsFunc: func(ctx context.Context, filter model.AuditFilter) ([]model.AuditRecord, error) {
+//				panic("mock out the ListAuditRecords method")
+//			},
+//			RecordAuditFunc: func(ctx context.Context, record model.AuditRecord) error {
+//				panic("mock out the RecordAudit method")
+//			},
+//		}
+//
+//		// use mockedAuditRepository in code that requires port.AuditRepository
+//		// and then make assertions.
+//
+//	}
+type AuditRepositoryMock struct {
+	// ListAuditRecordsFunc mocks the ListAuditRecords method.
+	ListAuditRecordsFunc func(ctx context.Context, filter model.AuditFilter) ([]model.AuditRecord, error)
+
+	// RecordAuditFunc mocks the RecordAudit method.
+	RecordAuditFunc func(ctx context.Context, record model.AuditRecord) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListAuditRecords holds details about calls to the ListAuditRecords method.
+		ListAuditRecords []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter model.AuditFilter
+		}
+		// RecordAudit holds details about calls to the RecordAudit method.
+		RecordAudit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record model.AuditRecord
+		}
+	}
+	lockListAuditRecords sync.RWMutex
+	lockRecordAudit      sync.RWMutex
+}
+
+// ListAuditRecords calls ListAuditRecordsFunc.
+func (mock *AuditRepositoryMock) ListAuditRecords(ctx context.Context, filter model.AuditFilter) ([]model.AuditRecord, error) {
+	if mock.ListAuditRecordsFunc == nil {
+		panic("AuditRepositoryMock.ListAuditRecordsFunc: method is nil but AuditRepository.ListAuditRecords was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter model.AuditFilter
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockListAuditRecords.Lock()
+	mock.calls.ListAuditRecords = append(mock.calls.ListAuditRecords, callInfo)
+	mock.lockListAuditRecords.Unlock()
+	return mock.ListAuditRecordsFunc(ctx, filter)
+}
+
+// ListAuditRecordsCalls gets all the calls that were made to ListAuditRecords.
+// Check the length with:
+//
+//	len(mockedAuditRepository.ListAuditRecordsCalls())
+func (mock *AuditRepositoryMock) ListAuditRecordsCalls() []struct {
+	Ctx    context.Context
+	Filter model.AuditFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter model.AuditFilter
+	}
+	mock.lockListAuditRecords.RLock()
+	calls = mock.calls.ListAuditRecords
+	mock.lockListAuditRecords.RUnlock()
+	return calls
+}
+
+// RecordAudit calls RecordAuditFunc.
+func (mock *AuditRepositoryMock) RecordAudit(ctx context.Context, record model.AuditRecord) error {
+	if mock.RecordAuditFunc == nil {
+		panic("AuditRepositoryMock.RecordAuditFunc: method is nil but AuditRepository.RecordAudit was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record model.AuditRecord
+	}{
+		Ctx:    ctx,
+		Record: record,
+	}
+	mock.lockRecordAudit.Lock()
+	mock.calls.RecordAudit = append(mock.calls.RecordAudit, callInfo)
+	mock.lockRecordAudit.Unlock()
+	return mock.RecordAuditFunc(ctx, record)
+}
+
+// RecordAuditCalls gets all the calls that were made to RecordAudit.
+// Check the length with:
+//
+//	len(mockedAuditRepository.RecordAuditCalls())
+func (mock *AuditRepositoryMock) RecordAuditCalls() []struct {
+	Ctx    context.Context
+	Record model.AuditRecord
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record model.AuditRecord
+	}
+	mock.lockRecordAudit.RLock()
+	calls = mock.calls.RecordAudit
+	mock.lockRecordAudit.RUnlock()
+	return calls
+}
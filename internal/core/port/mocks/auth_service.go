@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"github.com/gin-gonic/gin"
@@ -20,12 +21,33 @@ var _ port.AuthService = &AuthServiceMock{}
 //
 //		// make and configure a mocked port.AuthService
 //		mockedAuthService := &AuthServiceMock{
+//			ExtractScopesFunc: func(c *gin.Context) ([]string, error) {
+//				panic("mock out the ExtractScopes method")
+//			},
 //			ExtractTokenIDFunc: func(c *gin.Context) (string, error) {
 //				panic("mock out the ExtractTokenID method")
 //			},
-//			GenerateTokensFunc: func(userID string, role []string) (*model.TokenPair, error) {
+//			GenerateAdminTokenFunc: func(adminID string) (*model.AccessToken, error) {
+//				panic("mock out the GenerateAdminToken method")
+//			},
+//			GenerateMFATokenFunc: func(ctx context.Context, userID string) (*model.MFAChallenge, error) {
+//				panic("mock out the GenerateMFAToken method")
+//			},
+//			GenerateTokensFunc: func(ctx context.Context, userID string, role []string) (*model.TokenPair, error) {
 //				panic("mock out the GenerateTokens method")
 //			},
+//			JWKSFunc: func() model.JSONWebKeySet {
+//				panic("mock out the JWKS method")
+//			},
+//			LogoutFunc: func(c *gin.Context, refreshToken string) error {
+//				panic("mock out the Logout method")
+//			},
+//			RefreshTokensFunc: func(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+//				panic("mock out the RefreshTokens method")
+//			},
+//			RevokeUserSessionsFunc: func(ctx context.Context, userID string) error {
+//				panic("mock out the RevokeUserSessions method")
+//			},
 //			ValidateSetPasswordTokenFunc: func(c *gin.Context) error {
 //				panic("mock out the ValidateSetPasswordToken method")
 //			},
@@ -39,11 +61,32 @@ var _ port.AuthService = &AuthServiceMock{}
 //
 //	}
 type AuthServiceMock struct {
+	// ExtractScopesFunc mocks the ExtractScopes method.
+	ExtractScopesFunc func(c *gin.Context) ([]string, error)
+
 	// ExtractTokenIDFunc mocks the ExtractTokenID method.
 	ExtractTokenIDFunc func(c *gin.Context) (string, error)
 
+	// GenerateAdminTokenFunc mocks the GenerateAdminToken method.
+	GenerateAdminTokenFunc func(adminID string) (*model.AccessToken, error)
+
+	// GenerateMFATokenFunc mocks the GenerateMFAToken method.
+	GenerateMFATokenFunc func(ctx context.Context, userID string) (*model.MFAChallenge, error)
+
 	// GenerateTokensFunc mocks the GenerateTokens method.
-	GenerateTokensFunc func(userID string, role []string) (*model.TokenPair, error)
+	GenerateTokensFunc func(ctx context.Context, userID string, role []string) (*model.TokenPair, error)
+
+	// JWKSFunc mocks the JWKS method.
+	JWKSFunc func() model.JSONWebKeySet
+
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(c *gin.Context, refreshToken string) error
+
+	// RefreshTokensFunc mocks the RefreshTokens method.
+	RefreshTokensFunc func(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+
+	// RevokeUserSessionsFunc mocks the RevokeUserSessions method.
+	RevokeUserSessionsFunc func(ctx context.Context, userID string) error
 
 	// ValidateSetPasswordTokenFunc mocks the ValidateSetPasswordToken method.
 	ValidateSetPasswordTokenFunc func(c *gin.Context) error
@@ -53,18 +96,61 @@ type AuthServiceMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ExtractScopes holds details about calls to the ExtractScopes method.
+		ExtractScopes []struct {
+			// C is the c argument value.
+			C *gin.Context
+		}
 		// ExtractTokenID holds details about calls to the ExtractTokenID method.
 		ExtractTokenID []struct {
 			// C is the c argument value.
 			C *gin.Context
 		}
+		// GenerateAdminToken holds details about calls to the GenerateAdminToken method.
+		GenerateAdminToken []struct {
+			// AdminID is the adminID argument value.
+			AdminID string
+		}
+		// GenerateMFAToken holds details about calls to the GenerateMFAToken method.
+		GenerateMFAToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// GenerateTokens holds details about calls to the GenerateTokens method.
 		GenerateTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// UserID is the userID argument value.
 			UserID string
 			// Role is the role argument value.
 			Role []string
 		}
+		// JWKS holds details about calls to the JWKS method.
+		JWKS []struct {
+		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// C is the c argument value.
+			C *gin.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// RefreshTokens holds details about calls to the RefreshTokens method.
+		RefreshTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// RevokeUserSessions holds details about calls to the RevokeUserSessions method.
+		RevokeUserSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// ValidateSetPasswordToken holds details about calls to the ValidateSetPasswordToken method.
 		ValidateSetPasswordToken []struct {
 			// C is the c argument value.
@@ -76,12 +162,51 @@ type AuthServiceMock struct {
 			C *gin.Context
 		}
 	}
+	lockExtractScopes            sync.RWMutex
 	lockExtractTokenID           sync.RWMutex
+	lockGenerateAdminToken       sync.RWMutex
+	lockGenerateMFAToken         sync.RWMutex
 	lockGenerateTokens           sync.RWMutex
+	lockJWKS                     sync.RWMutex
+	lockLogout                   sync.RWMutex
+	lockRefreshTokens            sync.RWMutex
+	lockRevokeUserSessions       sync.RWMutex
 	lockValidateSetPasswordToken sync.RWMutex
 	lockValidateToken            sync.RWMutex
 }
 
+// ExtractScopes calls ExtractScopesFunc.
+func (mock *AuthServiceMock) ExtractScopes(c *gin.Context) ([]string, error) {
+	if mock.ExtractScopesFunc == nil {
+		panic("AuthServiceMock.ExtractScopesFunc: method is nil but AuthService.ExtractScopes was just called")
+	}
+	callInfo := struct {
+		C *gin.Context
+	}{
+		C: c,
+	}
+	mock.lockExtractScopes.Lock()
+	mock.calls.ExtractScopes = append(mock.calls.ExtractScopes, callInfo)
+	mock.lockExtractScopes.Unlock()
+	return mock.ExtractScopesFunc(c)
+}
+
+// ExtractScopesCalls gets all the calls that were made to ExtractScopes.
+// Check the length with:
+//
+//	len(mockedAuthService.ExtractScopesCalls())
+func (mock *AuthServiceMock) ExtractScopesCalls() []struct {
+	C *gin.Context
+} {
+	var calls []struct {
+		C *gin.Context
+	}
+	mock.lockExtractScopes.RLock()
+	calls = mock.calls.ExtractScopes
+	mock.lockExtractScopes.RUnlock()
+	return calls
+}
+
 // ExtractTokenID calls ExtractTokenIDFunc.
 func (mock *AuthServiceMock) ExtractTokenID(c *gin.Context) (string, error) {
 	if mock.ExtractTokenIDFunc == nil {
@@ -114,22 +239,92 @@ func (mock *AuthServiceMock) ExtractTokenIDCalls() []struct {
 	return calls
 }
 
+// GenerateAdminToken calls GenerateAdminTokenFunc.
+func (mock *AuthServiceMock) GenerateAdminToken(adminID string) (*model.AccessToken, error) {
+	if mock.GenerateAdminTokenFunc == nil {
+		panic("AuthServiceMock.GenerateAdminTokenFunc: method is nil but AuthService.GenerateAdminToken was just called")
+	}
+	callInfo := struct {
+		AdminID string
+	}{
+		AdminID: adminID,
+	}
+	mock.lockGenerateAdminToken.Lock()
+	mock.calls.GenerateAdminToken = append(mock.calls.GenerateAdminToken, callInfo)
+	mock.lockGenerateAdminToken.Unlock()
+	return mock.GenerateAdminTokenFunc(adminID)
+}
+
+// GenerateAdminTokenCalls gets all the calls that were made to GenerateAdminToken.
+// Check the length with:
+//
+//	len(mockedAuthService.GenerateAdminTokenCalls())
+func (mock *AuthServiceMock) GenerateAdminTokenCalls() []struct {
+	AdminID string
+} {
+	var calls []struct {
+		AdminID string
+	}
+	mock.lockGenerateAdminToken.RLock()
+	calls = mock.calls.GenerateAdminToken
+	mock.lockGenerateAdminToken.RUnlock()
+	return calls
+}
+
+// GenerateMFAToken calls GenerateMFATokenFunc.
+func (mock *AuthServiceMock) GenerateMFAToken(ctx context.Context, userID string) (*model.MFAChallenge, error) {
+	if mock.GenerateMFATokenFunc == nil {
+		panic("AuthServiceMock.GenerateMFATokenFunc: method is nil but AuthService.GenerateMFAToken was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGenerateMFAToken.Lock()
+	mock.calls.GenerateMFAToken = append(mock.calls.GenerateMFAToken, callInfo)
+	mock.lockGenerateMFAToken.Unlock()
+	return mock.GenerateMFATokenFunc(ctx, userID)
+}
+
+// GenerateMFATokenCalls gets all the calls that were made to GenerateMFAToken.
+// Check the length with:
+//
+//	len(mockedAuthService.GenerateMFATokenCalls())
+func (mock *AuthServiceMock) GenerateMFATokenCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockGenerateMFAToken.RLock()
+	calls = mock.calls.GenerateMFAToken
+	mock.lockGenerateMFAToken.RUnlock()
+	return calls
+}
+
 // GenerateTokens calls GenerateTokensFunc.
-func (mock *AuthServiceMock) GenerateTokens(userID string, role []string) (*model.TokenPair, error) {
+func (mock *AuthServiceMock) GenerateTokens(ctx context.Context, userID string, role []string) (*model.TokenPair, error) {
 	if mock.GenerateTokensFunc == nil {
 		panic("AuthServiceMock.GenerateTokensFunc: method is nil but AuthService.GenerateTokens was just called")
 	}
 	callInfo := struct {
+		Ctx    context.Context
 		UserID string
 		Role   []string
 	}{
+		Ctx:    ctx,
 		UserID: userID,
 		Role:   role,
 	}
 	mock.lockGenerateTokens.Lock()
 	mock.calls.GenerateTokens = append(mock.calls.GenerateTokens, callInfo)
 	mock.lockGenerateTokens.Unlock()
-	return mock.GenerateTokensFunc(userID, role)
+	return mock.GenerateTokensFunc(ctx, userID, role)
 }
 
 // GenerateTokensCalls gets all the calls that were made to GenerateTokens.
@@ -137,10 +332,12 @@ func (mock *AuthServiceMock) GenerateTokens(userID string, role []string) (*mode
 //
 //	len(mockedAuthService.GenerateTokensCalls())
 func (mock *AuthServiceMock) GenerateTokensCalls() []struct {
+	Ctx    context.Context
 	UserID string
 	Role   []string
 } {
 	var calls []struct {
+		Ctx    context.Context
 		UserID string
 		Role   []string
 	}
@@ -150,6 +347,141 @@ func (mock *AuthServiceMock) GenerateTokensCalls() []struct {
 	return calls
 }
 
+// JWKS calls JWKSFunc.
+func (mock *AuthServiceMock) JWKS() model.JSONWebKeySet {
+	if mock.JWKSFunc == nil {
+		panic("AuthServiceMock.JWKSFunc: method is nil but AuthService.JWKS was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockJWKS.Lock()
+	mock.calls.JWKS = append(mock.calls.JWKS, callInfo)
+	mock.lockJWKS.Unlock()
+	return mock.JWKSFunc()
+}
+
+// JWKSCalls gets all the calls that were made to JWKS.
+// Check the length with:
+//
+//	len(mockedAuthService.JWKSCalls())
+func (mock *AuthServiceMock) JWKSCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockJWKS.RLock()
+	calls = mock.calls.JWKS
+	mock.lockJWKS.RUnlock()
+	return calls
+}
+
+// Logout calls LogoutFunc.
+func (mock *AuthServiceMock) Logout(c *gin.Context, refreshToken string) error {
+	if mock.LogoutFunc == nil {
+		panic("AuthServiceMock.LogoutFunc: method is nil but AuthService.Logout was just called")
+	}
+	callInfo := struct {
+		C            *gin.Context
+		RefreshToken string
+	}{
+		C:            c,
+		RefreshToken: refreshToken,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	return mock.LogoutFunc(c, refreshToken)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthService.LogoutCalls())
+func (mock *AuthServiceMock) LogoutCalls() []struct {
+	C            *gin.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		C            *gin.Context
+		RefreshToken string
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// RefreshTokens calls RefreshTokensFunc.
+func (mock *AuthServiceMock) RefreshTokens(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	if mock.RefreshTokensFunc == nil {
+		panic("AuthServiceMock.RefreshTokensFunc: method is nil but AuthService.RefreshTokens was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockRefreshTokens.Lock()
+	mock.calls.RefreshTokens = append(mock.calls.RefreshTokens, callInfo)
+	mock.lockRefreshTokens.Unlock()
+	return mock.RefreshTokensFunc(ctx, refreshToken)
+}
+
+// RefreshTokensCalls gets all the calls that were made to RefreshTokens.
+// Check the length with:
+//
+//	len(mockedAuthService.RefreshTokensCalls())
+func (mock *AuthServiceMock) RefreshTokensCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockRefreshTokens.RLock()
+	calls = mock.calls.RefreshTokens
+	mock.lockRefreshTokens.RUnlock()
+	return calls
+}
+
+// RevokeUserSessions calls RevokeUserSessionsFunc.
+func (mock *AuthServiceMock) RevokeUserSessions(ctx context.Context, userID string) error {
+	if mock.RevokeUserSessionsFunc == nil {
+		panic("AuthServiceMock.RevokeUserSessionsFunc: method is nil but AuthService.RevokeUserSessions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUserSessions.Lock()
+	mock.calls.RevokeUserSessions = append(mock.calls.RevokeUserSessions, callInfo)
+	mock.lockRevokeUserSessions.Unlock()
+	return mock.RevokeUserSessionsFunc(ctx, userID)
+}
+
+// RevokeUserSessionsCalls gets all the calls that were made to RevokeUserSessions.
+// Check the length with:
+//
+//	len(mockedAuthService.RevokeUserSessionsCalls())
+func (mock *AuthServiceMock) RevokeUserSessionsCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockRevokeUserSessions.RLock()
+	calls = mock.calls.RevokeUserSessions
+	mock.lockRevokeUserSessions.RUnlock()
+	return calls
+}
+
 // ValidateSetPasswordToken calls ValidateSetPasswordTokenFunc.
 func (mock *AuthServiceMock) ValidateSetPasswordToken(c *gin.Context) error {
 	if mock.ValidateSetPasswordTokenFunc == nil {
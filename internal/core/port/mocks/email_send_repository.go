@@ -0,0 +1,96 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+	"time"
+)
+
+// Ensure, that EmailSendRepositoryMock does implement port.EmailSendRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.EmailSendRepository = &EmailSendRepositoryMock{}
+
+// EmailSendRepositoryMock is a mock implementation of port.EmailSendRepository.
+//
+//	func TestSomethingThatUsesEmailSendRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.EmailSendRepository
+//		mockedEmailSendRepository := &EmailSendRepositoryMock{
+//			RecordEmailSendFunc: func(ctx context.Context, kind string, address string, since time.Time) (*model.EmailSend, bool, error) {
+//				panic("mock out the RecordEmailSend method")
+//			},
+//		}
+//
+//		// use mockedEmailSendRepository in code that requires port.EmailSendRepository
+//		// and then make assertions.
+//
+//	}
+type EmailSendRepositoryMock struct {
+	// RecordEmailSendFunc mocks the RecordEmailSend method.
+	RecordEmailSendFunc func(ctx context.Context, kind string, address string, since time.Time) (*model.EmailSend, bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RecordEmailSend holds details about calls to the RecordEmailSend method.
+		RecordEmailSend []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind string
+			// Address is the address argument value.
+			Address string
+			// Since is the since argument value.
+			Since time.Time
+		}
+	}
+	lockRecordEmailSend sync.RWMutex
+}
+
+// RecordEmailSend calls RecordEmailSendFunc.
+func (mock *EmailSendRepositoryMock) RecordEmailSend(ctx context.Context, kind string, address string, since time.Time) (*model.EmailSend, bool, error) {
+	if mock.RecordEmailSendFunc == nil {
+		panic("EmailSendRepositoryMock.RecordEmailSendFunc: method is nil but EmailSendRepository.RecordEmailSend was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Kind    string
+		Address string
+		Since   time.Time
+	}{
+		Ctx:     ctx,
+		Kind:    kind,
+		Address: address,
+		Since:   since,
+	}
+	mock.lockRecordEmailSend.Lock()
+	mock.calls.RecordEmailSend = append(mock.calls.RecordEmailSend, callInfo)
+	mock.lockRecordEmailSend.Unlock()
+	return mock.RecordEmailSendFunc(ctx, kind, address, since)
+}
+
+// RecordEmailSendCalls gets all the calls that were made to RecordEmailSend.
+// Check the length with:
+//
+//	len(mockedEmailSendRepository.RecordEmailSendCalls())
+func (mock *EmailSendRepositoryMock) RecordEmailSendCalls() []struct {
+	Ctx     context.Context
+	Kind    string
+	Address string
+	Since   time.Time
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Kind    string
+		Address string
+		Since   time.Time
+	}
+	mock.lockRecordEmailSend.RLock()
+	calls = mock.calls.RecordEmailSend
+	mock.lockRecordEmailSend.RUnlock()
+	return calls
+}
@@ -0,0 +1,183 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that IdempotencyRepositoryMock does implement port.IdempotencyRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.IdempotencyRepository = &IdempotencyRepositoryMock{}
+
+// IdempotencyRepositoryMock is a mock implementation of port.IdempotencyRepository.
+//
+//	func TestSomethingThatUsesIdempotencyRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.IdempotencyRepository
+//		mockedIdempotencyRepository := &IdempotencyRepositoryMock{
+//			CompleteFunc: func(ctx context.Context, record *model.IdempotencyRecord) error {
+//				panic("mock out the Complete method")
+//			},
+//			ReleaseFunc: func(ctx context.Context, record *model.IdempotencyRecord) error {
+//				panic("mock out the Release method")
+//			},
+//			ReserveFunc: func(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error) {
+//				panic("mock out the Reserve method")
+//			},
+//		}
+//
+//		// use mockedIdempotencyRepository in code that requires port.IdempotencyRepository
+//		// and then make assertions.
+//
+//	}
+type IdempotencyRepositoryMock struct {
+	// CompleteFunc mocks the Complete method.
+	CompleteFunc func(ctx context.Context, record *model.IdempotencyRecord) error
+
+	// ReleaseFunc mocks the Release method.
+	ReleaseFunc func(ctx context.Context, record *model.IdempotencyRecord) error
+
+	// ReserveFunc mocks the Reserve method.
+	ReserveFunc func(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Complete holds details about calls to the Complete method.
+		Complete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *model.IdempotencyRecord
+		}
+		// Release holds details about calls to the Release method.
+		Release []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *model.IdempotencyRecord
+		}
+		// Reserve holds details about calls to the Reserve method.
+		Reserve []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record *model.IdempotencyRecord
+		}
+	}
+	lockComplete sync.RWMutex
+	lockRelease  sync.RWMutex
+	lockReserve  sync.RWMutex
+}
+
+// Complete calls CompleteFunc.
+func (mock *IdempotencyRepositoryMock) Complete(ctx context.Context, record *model.IdempotencyRecord) error {
+	if mock.CompleteFunc == nil {
+		panic("IdempotencyRepositoryMock.CompleteFunc: method is nil but IdempotencyRepository.Complete was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record *model.IdempotencyRecord
+	}{
+		Ctx:    ctx,
+		Record: record,
+	}
+	mock.lockComplete.Lock()
+	mock.calls.Complete = append(mock.calls.Complete, callInfo)
+	mock.lockComplete.Unlock()
+	return mock.CompleteFunc(ctx, record)
+}
+
+// CompleteCalls gets all the calls that were made to Complete.
+// Check the length with:
+//
+//	len(mockedIdempotencyRepository.CompleteCalls())
+func (mock *IdempotencyRepositoryMock) CompleteCalls() []struct {
+	Ctx    context.Context
+	Record *model.IdempotencyRecord
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record *model.IdempotencyRecord
+	}
+	mock.lockComplete.RLock()
+	calls = mock.calls.Complete
+	mock.lockComplete.RUnlock()
+	return calls
+}
+
+// Release calls ReleaseFunc.
+func (mock *IdempotencyRepositoryMock) Release(ctx context.Context, record *model.IdempotencyRecord) error {
+	if mock.ReleaseFunc == nil {
+		panic("IdempotencyRepositoryMock.ReleaseFunc: method is nil but IdempotencyRepository.Release was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record *model.IdempotencyRecord
+	}{
+		Ctx:    ctx,
+		Record: record,
+	}
+	mock.lockRelease.Lock()
+	mock.calls.Release = append(mock.calls.Release, callInfo)
+	mock.lockRelease.Unlock()
+	return mock.ReleaseFunc(ctx, record)
+}
+
+// ReleaseCalls gets all the calls that were made to Release.
+// Check the length with:
+//
+//	len(mockedIdempotencyRepository.ReleaseCalls())
+func (mock *IdempotencyRepositoryMock) ReleaseCalls() []struct {
+	Ctx    context.Context
+	Record *model.IdempotencyRecord
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record *model.IdempotencyRecord
+	}
+	mock.lockRelease.RLock()
+	calls = mock.calls.Release
+	mock.lockRelease.RUnlock()
+	return calls
+}
+
+// Reserve calls ReserveFunc.
+func (mock *IdempotencyRepositoryMock) Reserve(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error) {
+	if mock.ReserveFunc == nil {
+		panic("IdempotencyRepositoryMock.ReserveFunc: method is nil but IdempotencyRepository.Reserve was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record *model.IdempotencyRecord
+	}{
+		Ctx:    ctx,
+		Record: record,
+	}
+	mock.lockReserve.Lock()
+	mock.calls.Reserve = append(mock.calls.Reserve, callInfo)
+	mock.lockReserve.Unlock()
+	return mock.ReserveFunc(ctx, record)
+}
+
+// ReserveCalls gets all the calls that were made to Reserve.
+// Check the length with:
+//
+//	len(mockedIdempotencyRepository.ReserveCalls())
+func (mock *IdempotencyRepositoryMock) ReserveCalls() []struct {
+	Ctx    context.Context
+	Record *model.IdempotencyRecord
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record *model.IdempotencyRecord
+	}
+	mock.lockReserve.RLock()
+	calls = mock.calls.Reserve
+	mock.lockReserve.RUnlock()
+	return calls
+}
@@ -0,0 +1,214 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+	"time"
+)
+
+// Ensure, that LoginAttemptRepositoryMock does implement port.LoginAttemptRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.LoginAttemptRepository = &LoginAttemptRepositoryMock{}
+
+// LoginAttemptRepositoryMock is a mock implementation of port.LoginAttemptRepository.
+//
+//	func TestSomethingThatUsesLoginAttemptRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.LoginAttemptRepository
+//		mockedLoginAttemptRepository := &LoginAttemptRepositoryMock{
+//			GetFailedLoginsFunc: func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+//				panic("mock out the GetFailedLogins method")
+//			},
+//			RecordFailedLoginFunc: func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+//				panic("mock out the RecordFailedLogin method")
+//			},
+//			ResetFailedLoginsFunc: func(ctx context.Context, scope string, key string) error {
+//				panic("mock out the ResetFailedLogins method")
+//			},
+//		}
+//
+//		// use mockedLoginAttemptRepository in code that requires port.LoginAttemptRepository
+//		// and then make assertions.
+//
+//	}
+type LoginAttemptRepositoryMock struct {
+	// GetFailedLoginsFunc mocks the GetFailedLogins method.
+	GetFailedLoginsFunc func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error)
+
+	// RecordFailedLoginFunc mocks the RecordFailedLogin method.
+	RecordFailedLoginFunc func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error)
+
+	// ResetFailedLoginsFunc mocks the ResetFailedLogins method.
+	ResetFailedLoginsFunc func(ctx context.Context, scope string, key string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetFailedLogins holds details about calls to the GetFailedLogins method.
+		GetFailedLogins []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Scope is the scope argument value.
+			Scope string
+			// Key is the key argument value.
+			Key string
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// RecordFailedLogin holds details about calls to the RecordFailedLogin method.
+		RecordFailedLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Scope is the scope argument value.
+			Scope string
+			// Key is the key argument value.
+			Key string
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// ResetFailedLogins holds details about calls to the ResetFailedLogins method.
+		ResetFailedLogins []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Scope is the scope argument value.
+			Scope string
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockGetFailedLogins   sync.RWMutex
+	lockRecordFailedLogin sync.RWMutex
+	lockResetFailedLogins sync.RWMutex
+}
+
+// GetFailedLogins calls GetFailedLoginsFunc.
+func (mock *LoginAttemptRepositoryMock) GetFailedLogins(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+	if mock.GetFailedLoginsFunc == nil {
+		panic("LoginAttemptRepositoryMock.GetFailedLoginsFunc: method is nil but LoginAttemptRepository.GetFailedLogins was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Scope string
+		Key   string
+		Since time.Time
+	}{
+		Ctx:   ctx,
+		Scope: scope,
+		Key:   key,
+		Since: since,
+	}
+	mock.lockGetFailedLogins.Lock()
+	mock.calls.GetFailedLogins = append(mock.calls.GetFailedLogins, callInfo)
+	mock.lockGetFailedLogins.Unlock()
+	return mock.GetFailedLoginsFunc(ctx, scope, key, since)
+}
+
+// GetFailedLoginsCalls gets all the calls that were made to GetFailedLogins.
+// Check the length with:
+//
+//	len(mockedLoginAttemptRepository.GetFailedLoginsCalls())
+func (mock *LoginAttemptRepositoryMock) GetFailedLoginsCalls() []struct {
+	Ctx   context.Context
+	Scope string
+	Key   string
+	Since time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Scope string
+		Key   string
+		Since time.Time
+	}
+	mock.lockGetFailedLogins.RLock()
+	calls = mock.calls.GetFailedLogins
+	mock.lockGetFailedLogins.RUnlock()
+	return calls
+}
+
+// RecordFailedLogin calls RecordFailedLoginFunc.
+func (mock *LoginAttemptRepositoryMock) RecordFailedLogin(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+	if mock.RecordFailedLoginFunc == nil {
+		panic("LoginAttemptRepositoryMock.RecordFailedLoginFunc: method is nil but LoginAttemptRepository.RecordFailedLogin was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Scope string
+		Key   string
+		Since time.Time
+	}{
+		Ctx:   ctx,
+		Scope: scope,
+		Key:   key,
+		Since: since,
+	}
+	mock.lockRecordFailedLogin.Lock()
+	mock.calls.RecordFailedLogin = append(mock.calls.RecordFailedLogin, callInfo)
+	mock.lockRecordFailedLogin.Unlock()
+	return mock.RecordFailedLoginFunc(ctx, scope, key, since)
+}
+
+// RecordFailedLoginCalls gets all the calls that were made to RecordFailedLogin.
+// Check the length with:
+//
+//	len(mockedLoginAttemptRepository.RecordFailedLoginCalls())
+func (mock *LoginAttemptRepositoryMock) RecordFailedLoginCalls() []struct {
+	Ctx   context.Context
+	Scope string
+	Key   string
+	Since time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Scope string
+		Key   string
+		Since time.Time
+	}
+	mock.lockRecordFailedLogin.RLock()
+	calls = mock.calls.RecordFailedLogin
+	mock.lockRecordFailedLogin.RUnlock()
+	return calls
+}
+
+// ResetFailedLogins calls ResetFailedLoginsFunc.
+func (mock *LoginAttemptRepositoryMock) ResetFailedLogins(ctx context.Context, scope string, key string) error {
+	if mock.ResetFailedLoginsFunc == nil {
+		panic("LoginAttemptRepositoryMock.ResetFailedLoginsFunc: method is nil but LoginAttemptRepository.ResetFailedLogins was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Scope string
+		Key   string
+	}{
+		Ctx:   ctx,
+		Scope: scope,
+		Key:   key,
+	}
+	mock.lockResetFailedLogins.Lock()
+	mock.calls.ResetFailedLogins = append(mock.calls.ResetFailedLogins, callInfo)
+	mock.lockResetFailedLogins.Unlock()
+	return mock.ResetFailedLoginsFunc(ctx, scope, key)
+}
+
+// ResetFailedLoginsCalls gets all the calls that were made to ResetFailedLogins.
+// Check the length with:
+//
+//	len(mockedLoginAttemptRepository.ResetFailedLoginsCalls())
+func (mock *LoginAttemptRepositoryMock) ResetFailedLoginsCalls() []struct {
+	Ctx   context.Context
+	Scope string
+	Key   string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Scope string
+		Key   string
+	}
+	mock.lockResetFailedLogins.RLock()
+	calls = mock.calls.ResetFailedLogins
+	mock.lockResetFailedLogins.RUnlock()
+	return calls
+}
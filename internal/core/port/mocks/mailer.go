@@ -0,0 +1,76 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that MailerMock does implement port.Mailer.
+// If this is not the case, regenerate this file with moq.
+var _ port.Mailer = &MailerMock{}
+
+// MailerMock is a mock implementation of port.Mailer.
+//
+//	func TestSomethingThatUsesMailer(t *testing.T) {
+//
+//		// make and configure a mocked port.Mailer
+//		mockedMailer := &MailerMock{
+//			SendFunc: func(message *model.EmailMessage) error {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedMailer in code that requires port.Mailer
+//		// and then make assertions.
+//
+//	}
+type MailerMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(message *model.EmailMessage) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Message is the message argument value.
+			Message *model.EmailMessage
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *MailerMock) Send(message *model.EmailMessage) error {
+	if mock.SendFunc == nil {
+		panic("MailerMock.SendFunc: method is nil but Mailer.Send was just called")
+	}
+	callInfo := struct {
+		Message *model.EmailMessage
+	}{
+		Message: message,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(message)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedMailer.SendCalls())
+func (mock *MailerMock) SendCalls() []struct {
+	Message *model.EmailMessage
+} {
+	var calls []struct {
+		Message *model.EmailMessage
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
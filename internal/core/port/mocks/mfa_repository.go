@@ -0,0 +1,313 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that MFARepositoryMock does implement port.MFARepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.MFARepository = &MFARepositoryMock{}
+
+// MFARepositoryMock is a mock implementation of port.MFARepository.
+//
+//	func TestSomethingThatUsesMFARepository(t *testing.T) {
+//
+//		// make and configure a mocked port.MFARepository
+//		mockedMFARepository := &MFARepositoryMock{
+//			ActivateTOTPFunc: func(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+//				panic("mock out the ActivateTOTP method")
+//			},
+//			GetTOTPFunc: func(ctx context.Context, userID string) (*model.TOTP, error) {
+//				panic("mock out the GetTOTP method")
+//			},
+//			SaveTOTPSecretFunc: func(ctx context.Context, userID string, secret []byte) error {
+//				panic("mock out the SaveTOTPSecret method")
+//			},
+//			UseRecoveryCodeFunc: func(ctx context.Context, userID string, codeHash string) (bool, error) {
+//				panic("mock out the UseRecoveryCode method")
+//			},
+//			UseTOTPStepFunc: func(ctx context.Context, userID string, step int64) (bool, error) {
+//				panic("mock out the UseTOTPStep method")
+//			},
+//		}
+//
+//		// use mockedMFARepository in code that requires port.MFARepository
+//		// and then make assertions.
+//
+//	}
+type MFARepositoryMock struct {
+	// ActivateTOTPFunc mocks the ActivateTOTP method.
+	ActivateTOTPFunc func(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+
+	// GetTOTPFunc mocks the GetTOTP method.
+	GetTOTPFunc func(ctx context.Context, userID string) (*model.TOTP, error)
+
+	// SaveTOTPSecretFunc mocks the SaveTOTPSecret method.
+	SaveTOTPSecretFunc func(ctx context.Context, userID string, secret []byte) error
+
+	// UseRecoveryCodeFunc mocks the UseRecoveryCode method.
+	UseRecoveryCodeFunc func(ctx context.Context, userID string, codeHash string) (bool, error)
+
+	// UseTOTPStepFunc mocks the UseTOTPStep method.
+	UseTOTPStepFunc func(ctx context.Context, userID string, step int64) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ActivateTOTP holds details about calls to the ActivateTOTP method.
+		ActivateTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Step is the step argument value.
+			Step int64
+			// RecoveryCodeHashes is the recoveryCodeHashes argument value.
+			RecoveryCodeHashes []string
+		}
+		// GetTOTP holds details about calls to the GetTOTP method.
+		GetTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// SaveTOTPSecret holds details about calls to the SaveTOTPSecret method.
+		SaveTOTPSecret []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Secret is the secret argument value.
+			Secret []byte
+		}
+		// UseRecoveryCode holds details about calls to the UseRecoveryCode method.
+		UseRecoveryCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// CodeHash is the codeHash argument value.
+			CodeHash string
+		}
+		// UseTOTPStep holds details about calls to the UseTOTPStep method.
+		UseTOTPStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Step is the step argument value.
+			Step int64
+		}
+	}
+	lockActivateTOTP    sync.RWMutex
+	lockGetTOTP         sync.RWMutex
+	lockSaveTOTPSecret  sync.RWMutex
+	lockUseRecoveryCode sync.RWMutex
+	lockUseTOTPStep     sync.RWMutex
+}
+
+// ActivateTOTP calls ActivateTOTPFunc.
+func (mock *MFARepositoryMock) ActivateTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	if mock.ActivateTOTPFunc == nil {
+		panic("MFARepositoryMock.ActivateTOTPFunc: method is nil but MFARepository.ActivateTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx                context.Context
+		UserID             string
+		Step               int64
+		RecoveryCodeHashes []string
+	}{
+		Ctx:                ctx,
+		UserID:             userID,
+		Step:               step,
+		RecoveryCodeHashes: recoveryCodeHashes,
+	}
+	mock.lockActivateTOTP.Lock()
+	mock.calls.ActivateTOTP = append(mock.calls.ActivateTOTP, callInfo)
+	mock.lockActivateTOTP.Unlock()
+	return mock.ActivateTOTPFunc(ctx, userID, step, recoveryCodeHashes)
+}
+
+// ActivateTOTPCalls gets all the calls that were made to ActivateTOTP.
+// Check the length with:
+//
+//	len(mockedMFARepository.ActivateTOTPCalls())
+func (mock *MFARepositoryMock) ActivateTOTPCalls() []struct {
+	Ctx                context.Context
+	UserID             string
+	Step               int64
+	RecoveryCodeHashes []string
+} {
+	var calls []struct {
+		Ctx                context.Context
+		UserID             string
+		Step               int64
+		RecoveryCodeHashes []string
+	}
+	mock.lockActivateTOTP.RLock()
+	calls = mock.calls.ActivateTOTP
+	mock.lockActivateTOTP.RUnlock()
+	return calls
+}
+
+// GetTOTP calls GetTOTPFunc.
+func (mock *MFARepositoryMock) GetTOTP(ctx context.Context, userID string) (*model.TOTP, error) {
+	if mock.GetTOTPFunc == nil {
+		panic("MFARepositoryMock.GetTOTPFunc: method is nil but MFARepository.GetTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetTOTP.Lock()
+	mock.calls.GetTOTP = append(mock.calls.GetTOTP, callInfo)
+	mock.lockGetTOTP.Unlock()
+	return mock.GetTOTPFunc(ctx, userID)
+}
+
+// GetTOTPCalls gets all the calls that were made to GetTOTP.
+// Check the length with:
+//
+//	len(mockedMFARepository.GetTOTPCalls())
+func (mock *MFARepositoryMock) GetTOTPCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockGetTOTP.RLock()
+	calls = mock.calls.GetTOTP
+	mock.lockGetTOTP.RUnlock()
+	return calls
+}
+
+// SaveTOTPSecret calls SaveTOTPSecretFunc.
+func (mock *MFARepositoryMock) SaveTOTPSecret(ctx context.Context, userID string, secret []byte) error {
+	if mock.SaveTOTPSecretFunc == nil {
+		panic("MFARepositoryMock.SaveTOTPSecretFunc: method is nil but MFARepository.SaveTOTPSecret was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Secret []byte
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Secret: secret,
+	}
+	mock.lockSaveTOTPSecret.Lock()
+	mock.calls.SaveTOTPSecret = append(mock.calls.SaveTOTPSecret, callInfo)
+	mock.lockSaveTOTPSecret.Unlock()
+	return mock.SaveTOTPSecretFunc(ctx, userID, secret)
+}
+
+// SaveTOTPSecretCalls gets all the calls that were made to SaveTOTPSecret.
+// Check the length with:
+//
+//	len(mockedMFARepository.SaveTOTPSecretCalls())
+func (mock *MFARepositoryMock) SaveTOTPSecretCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Secret []byte
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Secret []byte
+	}
+	mock.lockSaveTOTPSecret.RLock()
+	calls = mock.calls.SaveTOTPSecret
+	mock.lockSaveTOTPSecret.RUnlock()
+	return calls
+}
+
+// UseRecoveryCode calls UseRecoveryCodeFunc.
+func (mock *MFARepositoryMock) UseRecoveryCode(ctx context.Context, userID string, codeHash string) (bool, error) {
+	if mock.UseRecoveryCodeFunc == nil {
+		panic("MFARepositoryMock.UseRecoveryCodeFunc: method is nil but MFARepository.UseRecoveryCode was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   string
+		CodeHash string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		CodeHash: codeHash,
+	}
+	mock.lockUseRecoveryCode.Lock()
+	mock.calls.UseRecoveryCode = append(mock.calls.UseRecoveryCode, callInfo)
+	mock.lockUseRecoveryCode.Unlock()
+	return mock.UseRecoveryCodeFunc(ctx, userID, codeHash)
+}
+
+// UseRecoveryCodeCalls gets all the calls that were made to UseRecoveryCode.
+// Check the length with:
+//
+//	len(mockedMFARepository.UseRecoveryCodeCalls())
+func (mock *MFARepositoryMock) UseRecoveryCodeCalls() []struct {
+	Ctx      context.Context
+	UserID   string
+	CodeHash string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   string
+		CodeHash string
+	}
+	mock.lockUseRecoveryCode.RLock()
+	calls = mock.calls.UseRecoveryCode
+	mock.lockUseRecoveryCode.RUnlock()
+	return calls
+}
+
+// UseTOTPStep calls UseTOTPStepFunc.
+func (mock *MFARepositoryMock) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	if mock.UseTOTPStepFunc == nil {
+		panic("MFARepositoryMock.UseTOTPStepFunc: method is nil but MFARepository.UseTOTPStep was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Step   int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Step:   step,
+	}
+	mock.lockUseTOTPStep.Lock()
+	mock.calls.UseTOTPStep = append(mock.calls.UseTOTPStep, callInfo)
+	mock.lockUseTOTPStep.Unlock()
+	return mock.UseTOTPStepFunc(ctx, userID, step)
+}
+
+// UseTOTPStepCalls gets all the calls that were made to UseTOTPStep.
+// Check the length with:
+//
+//	len(mockedMFARepository.UseTOTPStepCalls())
+func (mock *MFARepositoryMock) UseTOTPStepCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Step   int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Step   int64
+	}
+	mock.lockUseTOTPStep.RLock()
+	calls = mock.calls.UseTOTPStep
+	mock.lockUseTOTPStep.RUnlock()
+	return calls
+}
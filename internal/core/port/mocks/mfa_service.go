@@ -0,0 +1,251 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that MFAServiceMock does implement port.MFAService.
+// If this is not the case, regenerate this file with moq.
+var _ port.MFAService = &MFAServiceMock{}
+
+// MFAServiceMock is a mock implementation of port.MFAService.
+//
+//	func TestSomethingThatUsesMFAService(t *testing.T) {
+//
+//		// make and configure a mocked port.MFAService
+//		mockedMFAService := &MFAServiceMock{
+//			ActivateTOTPFunc: func(ctx context.Context, userID string, code string) ([]string, error) {
+//				panic("mock out the ActivateTOTP method")
+//			},
+//			EnrolTOTPFunc: func(ctx context.Context, userID string) (*model.TOTPEnrolment, error) {
+//				panic("mock out the EnrolTOTP method")
+//			},
+//			IsTOTPEnabledFunc: func(ctx context.Context, userID string) (bool, error) {
+//				panic("mock out the IsTOTPEnabled method")
+//			},
+//			VerifySecondFactorFunc: func(ctx context.Context, userID string, code string, recoveryCode string) error {
+//				panic("mock out the VerifySecondFactor method")
+//			},
+//		}
+//
+//		// use mockedMFAService in code that requires port.MFAService
+//		// and then make assertions.
+//
+//	}
+type MFAServiceMock struct {
+	// ActivateTOTPFunc mocks the ActivateTOTP method.
+	ActivateTOTPFunc func(ctx context.Context, userID string, code string) ([]string, error)
+
+	// EnrolTOTPFunc mocks the EnrolTOTP method.
+	EnrolTOTPFunc func(ctx context.Context, userID string) (*model.TOTPEnrolment, error)
+
+	// IsTOTPEnabledFunc mocks the IsTOTPEnabled method.
+	IsTOTPEnabledFunc func(ctx context.Context, userID string) (bool, error)
+
+	// VerifySecondFactorFunc mocks the VerifySecondFactor method.
+	VerifySecondFactorFunc func(ctx context.Context, userID string, code string, recoveryCode string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ActivateTOTP holds details about calls to the ActivateTOTP method.
+		ActivateTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Code is the code argument value.
+			Code string
+		}
+		// EnrolTOTP holds details about calls to the EnrolTOTP method.
+		EnrolTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// IsTOTPEnabled holds details about calls to the IsTOTPEnabled method.
+		IsTOTPEnabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// VerifySecondFactor holds details about calls to the VerifySecondFactor method.
+		VerifySecondFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Code is the code argument value.
+			Code string
+			// RecoveryCode is the recoveryCode argument value.
+			RecoveryCode string
+		}
+	}
+	lockActivateTOTP       sync.RWMutex
+	lockEnrolTOTP          sync.RWMutex
+	lockIsTOTPEnabled      sync.RWMutex
+	lockVerifySecondFactor sync.RWMutex
+}
+
+// ActivateTOTP calls ActivateTOTPFunc.
+func (mock *MFAServiceMock) ActivateTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	if mock.ActivateTOTPFunc == nil {
+		panic("MFAServiceMock.ActivateTOTPFunc: method is nil but MFAService.ActivateTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockActivateTOTP.Lock()
+	mock.calls.ActivateTOTP = append(mock.calls.ActivateTOTP, callInfo)
+	mock.lockActivateTOTP.Unlock()
+	return mock.ActivateTOTPFunc(ctx, userID, code)
+}
+
+// ActivateTOTPCalls gets all the calls that were made to ActivateTOTP.
+// Check the length with:
+//
+//	len(mockedMFAService.ActivateTOTPCalls())
+func (mock *MFAServiceMock) ActivateTOTPCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Code   string
+	}
+	mock.lockActivateTOTP.RLock()
+	calls = mock.calls.ActivateTOTP
+	mock.lockActivateTOTP.RUnlock()
+	return calls
+}
+
+// EnrolTOTP calls EnrolTOTPFunc.
+func (mock *MFAServiceMock) EnrolTOTP(ctx context.Context, userID string) (*model.TOTPEnrolment, error) {
+	if mock.EnrolTOTPFunc == nil {
+		panic("MFAServiceMock.EnrolTOTPFunc: method is nil but MFAService.EnrolTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockEnrolTOTP.Lock()
+	mock.calls.EnrolTOTP = append(mock.calls.EnrolTOTP, callInfo)
+	mock.lockEnrolTOTP.Unlock()
+	return mock.EnrolTOTPFunc(ctx, userID)
+}
+
+// EnrolTOTPCalls gets all the calls that were made to EnrolTOTP.
+// Check the length with:
+//
+//	len(mockedMFAService.EnrolTOTPCalls())
+func (mock *MFAServiceMock) EnrolTOTPCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockEnrolTOTP.RLock()
+	calls = mock.calls.EnrolTOTP
+	mock.lockEnrolTOTP.RUnlock()
+	return calls
+}
+
+// IsTOTPEnabled calls IsTOTPEnabledFunc.
+func (mock *MFAServiceMock) IsTOTPEnabled(ctx context.Context, userID string) (bool, error) {
+	if mock.IsTOTPEnabledFunc == nil {
+		panic("MFAServiceMock.IsTOTPEnabledFunc: method is nil but MFAService.IsTOTPEnabled was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockIsTOTPEnabled.Lock()
+	mock.calls.IsTOTPEnabled = append(mock.calls.IsTOTPEnabled, callInfo)
+	mock.lockIsTOTPEnabled.Unlock()
+	return mock.IsTOTPEnabledFunc(ctx, userID)
+}
+
+// IsTOTPEnabledCalls gets all the calls that were made to IsTOTPEnabled.
+// Check the length with:
+//
+//	len(mockedMFAService.IsTOTPEnabledCalls())
+func (mock *MFAServiceMock) IsTOTPEnabledCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockIsTOTPEnabled.RLock()
+	calls = mock.calls.IsTOTPEnabled
+	mock.lockIsTOTPEnabled.RUnlock()
+	return calls
+}
+
+// VerifySecondFactor calls VerifySecondFactorFunc.
+func (mock *MFAServiceMock) VerifySecondFactor(ctx context.Context, userID string, code string, recoveryCode string) error {
+	if mock.VerifySecondFactorFunc == nil {
+		panic("MFAServiceMock.VerifySecondFactorFunc: method is nil but MFAService.VerifySecondFactor was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		UserID       string
+		Code         string
+		RecoveryCode string
+	}{
+		Ctx:          ctx,
+		UserID:       userID,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+	}
+	mock.lockVerifySecondFactor.Lock()
+	mock.calls.VerifySecondFactor = append(mock.calls.VerifySecondFactor, callInfo)
+	mock.lockVerifySecondFactor.Unlock()
+	return mock.VerifySecondFactorFunc(ctx, userID, code, recoveryCode)
+}
+
+// VerifySecondFactorCalls gets all the calls that were made to VerifySecondFactor.
+// Check the length with:
+//
+//	len(mockedMFAService.VerifySecondFactorCalls())
+func (mock *MFAServiceMock) VerifySecondFactorCalls() []struct {
+	Ctx          context.Context
+	UserID       string
+	Code         string
+	RecoveryCode string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		UserID       string
+		Code         string
+		RecoveryCode string
+	}
+	mock.lockVerifySecondFactor.RLock()
+	calls = mock.calls.VerifySecondFactor
+	mock.lockVerifySecondFactor.RUnlock()
+	return calls
+}
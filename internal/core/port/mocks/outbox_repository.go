@@ -0,0 +1,202 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+	"time"
+)
+
+// Ensure, that OutboxRepositoryMock does implement port.OutboxRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.OutboxRepository = &OutboxRepositoryMock{}
+
+// OutboxRepositoryMock is a mock implementation of port.OutboxRepository.
+//
+//	func TestSomethingThatUsesOutboxRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.OutboxRepository
+//		mockedOutboxRepository := &OutboxRepositoryMock{
+//			ClaimEventsFunc: func(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+//				panic("mock out the ClaimEvents method")
+//			},
+//			MarkFailedFunc: func(ctx context.Context, id string, cause error, nextAttemptAt time.Time) error {
+//				panic("mock out the MarkFailed method")
+//			},
+//			MarkPublishedFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the MarkPublished method")
+//			},
+//		}
+//
+//		// use mockedOutboxRepository in code that requires port.OutboxRepository
+//		// and then make assertions.
+//
+//	}
+type OutboxRepositoryMock struct {
+	// ClaimEventsFunc mocks the ClaimEvents method.
+	ClaimEventsFunc func(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error)
+
+	// MarkFailedFunc mocks the MarkFailed method.
+	MarkFailedFunc func(ctx context.Context, id string, cause error, nextAttemptAt time.Time) error
+
+	// MarkPublishedFunc mocks the MarkPublished method.
+	MarkPublishedFunc func(ctx context.Context, id string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ClaimEvents holds details about calls to the ClaimEvents method.
+		ClaimEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+			// Lease is the lease argument value.
+			Lease time.Duration
+		}
+		// MarkFailed holds details about calls to the MarkFailed method.
+		MarkFailed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Cause is the cause argument value.
+			Cause error
+			// NextAttemptAt is the nextAttemptAt argument value.
+			NextAttemptAt time.Time
+		}
+		// MarkPublished holds details about calls to the MarkPublished method.
+		MarkPublished []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+	}
+	lockClaimEvents   sync.RWMutex
+	lockMarkFailed    sync.RWMutex
+	lockMarkPublished sync.RWMutex
+}
+
+// ClaimEvents calls ClaimEventsFunc.
+func (mock *OutboxRepositoryMock) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+	if mock.ClaimEventsFunc == nil {
+		panic("OutboxRepositoryMock.ClaimEventsFunc: method is nil but OutboxRepository.ClaimEvents was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+		Lease time.Duration
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+		Lease: lease,
+	}
+	mock.lockClaimEvents.Lock()
+	mock.calls.ClaimEvents = append(mock.calls.ClaimEvents, callInfo)
+	mock.lockClaimEvents.Unlock()
+	return mock.ClaimEventsFunc(ctx, limit, lease)
+}
+
+// ClaimEventsCalls gets all the calls that were made to ClaimEvents.
+// Check the length with:
+//
+//	len(mockedOutboxRepository.ClaimEventsCalls())
+func (mock *OutboxRepositoryMock) ClaimEventsCalls() []struct {
+	Ctx   context.Context
+	Limit int
+	Lease time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+		Lease time.Duration
+	}
+	mock.lockClaimEvents.RLock()
+	calls = mock.calls.ClaimEvents
+	mock.lockClaimEvents.RUnlock()
+	return calls
+}
+
+// MarkFailed calls MarkFailedFunc.
+func (mock *OutboxRepositoryMock) MarkFailed(ctx context.Context, id string, cause error, nextAttemptAt time.Time) error {
+	if mock.MarkFailedFunc == nil {
+		panic("OutboxRepositoryMock.MarkFailedFunc: method is nil but OutboxRepository.MarkFailed was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ID            string
+		Cause         error
+		NextAttemptAt time.Time
+	}{
+		Ctx:           ctx,
+		ID:            id,
+		Cause:         cause,
+		NextAttemptAt: nextAttemptAt,
+	}
+	mock.lockMarkFailed.Lock()
+	mock.calls.MarkFailed = append(mock.calls.MarkFailed, callInfo)
+	mock.lockMarkFailed.Unlock()
+	return mock.MarkFailedFunc(ctx, id, cause, nextAttemptAt)
+}
+
+// MarkFailedCalls gets all the calls that were made to MarkFailed.
+// Check the length with:
+//
+//	len(mockedOutboxRepository.MarkFailedCalls())
+func (mock *OutboxRepositoryMock) MarkFailedCalls() []struct {
+	Ctx           context.Context
+	ID            string
+	Cause         error
+	NextAttemptAt time.Time
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ID            string
+		Cause         error
+		NextAttemptAt time.Time
+	}
+	mock.lockMarkFailed.RLock()
+	calls = mock.calls.MarkFailed
+	mock.lockMarkFailed.RUnlock()
+	return calls
+}
+
+// MarkPublished calls MarkPublishedFunc.
+func (mock *OutboxRepositoryMock) MarkPublished(ctx context.Context, id string) error {
+	if mock.MarkPublishedFunc == nil {
+		panic("OutboxRepositoryMock.MarkPublishedFunc: method is nil but OutboxRepository.MarkPublished was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockMarkPublished.Lock()
+	mock.calls.MarkPublished = append(mock.calls.MarkPublished, callInfo)
+	mock.lockMarkPublished.Unlock()
+	return mock.MarkPublishedFunc(ctx, id)
+}
+
+// MarkPublishedCalls gets all the calls that were made to MarkPublished.
+// Check the length with:
+//
+//	len(mockedOutboxRepository.MarkPublishedCalls())
+func (mock *OutboxRepositoryMock) MarkPublishedCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockMarkPublished.RLock()
+	calls = mock.calls.MarkPublished
+	mock.lockMarkPublished.RUnlock()
+	return calls
+}
@@ -0,0 +1,76 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that PublisherMock does implement port.Publisher.
+// If this is not the case, regenerate this file with moq.
+var _ port.Publisher = &PublisherMock{}
+
+// PublisherMock is a mock implementation of port.Publisher.
+//
+//	func TestSomethingThatUsesPublisher(t *testing.T) {
+//
+//		// make and configure a mocked port.Publisher
+//		mockedPublisher := &PublisherMock{
+//			PublishFunc: func(event model.Event) error {
+//				panic("mock out the Publish method")
+//			},
+//		}
+//
+//		// use mockedPublisher in code that requires port.Publisher
+//		// and then make assertions.
+//
+//	}
+type PublisherMock struct {
+	// PublishFunc mocks the Publish method.
+	PublishFunc func(event model.Event) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Publish holds details about calls to the Publish method.
+		Publish []struct {
+			// Event is the event argument value.
+			Event model.Event
+		}
+	}
+	lockPublish sync.RWMutex
+}
+
+// Publish calls PublishFunc.
+func (mock *PublisherMock) Publish(event model.Event) error {
+	if mock.PublishFunc == nil {
+		panic("PublisherMock.PublishFunc: method is nil but Publisher.Publish was just called")
+	}
+	callInfo := struct {
+		Event model.Event
+	}{
+		Event: event,
+	}
+	mock.lockPublish.Lock()
+	mock.calls.Publish = append(mock.calls.Publish, callInfo)
+	mock.lockPublish.Unlock()
+	return mock.PublishFunc(event)
+}
+
+// PublishCalls gets all the calls that were made to Publish.
+// Check the length with:
+//
+//	len(mockedPublisher.PublishCalls())
+func (mock *PublisherMock) PublishCalls() []struct {
+	Event model.Event
+} {
+	var calls []struct {
+		Event model.Event
+	}
+	mock.lockPublish.RLock()
+	calls = mock.calls.Publish
+	mock.lockPublish.RUnlock()
+	return calls
+}
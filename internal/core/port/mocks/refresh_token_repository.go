@@ -0,0 +1,283 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that RefreshTokenRepositoryMock does implement port.RefreshTokenRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.RefreshTokenRepository = &RefreshTokenRepositoryMock{}
+
+// RefreshTokenRepositoryMock is a mock implementation of port.RefreshTokenRepository.
+//
+//	func TestSomethingThatUsesRefreshTokenRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.RefreshTokenRepository
+//		mockedRefreshTokenRepository := &RefreshTokenRepositoryMock{
+//			CreateRefreshTokenFunc: func(ctx context.Context, token *model.RefreshToken) error {
+//				panic("mock out the CreateRefreshToken method")
+//			},
+//			GetRefreshTokenFunc: func(ctx context.Context, id string) (*model.RefreshToken, error) {
+//				panic("mock out the GetRefreshToken method")
+//			},
+//			RevokeRefreshTokenFamilyFunc: func(ctx context.Context, familyID string) error {
+//				panic("mock out the RevokeRefreshTokenFamily method")
+//			},
+//			RevokeUserRefreshTokensFunc: func(ctx context.Context, userID string) error {
+//				panic("mock out the RevokeUserRefreshTokens method")
+//			},
+//			UseRefreshTokenFunc: func(ctx context.Context, id string) (bool, error) {
+//				panic("mock out the UseRefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenRepository in code that requires port.RefreshTokenRepository
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenRepositoryMock struct {
+	// CreateRefreshTokenFunc mocks the CreateRefreshToken method.
+	CreateRefreshTokenFunc func(ctx context.Context, token *model.RefreshToken) error
+
+	// GetRefreshTokenFunc mocks the GetRefreshToken method.
+	GetRefreshTokenFunc func(ctx context.Context, id string) (*model.RefreshToken, error)
+
+	// RevokeRefreshTokenFamilyFunc mocks the RevokeRefreshTokenFamily method.
+	RevokeRefreshTokenFamilyFunc func(ctx context.Context, familyID string) error
+
+	// RevokeUserRefreshTokensFunc mocks the RevokeUserRefreshTokens method.
+	RevokeUserRefreshTokensFunc func(ctx context.Context, userID string) error
+
+	// UseRefreshTokenFunc mocks the UseRefreshToken method.
+	UseRefreshTokenFunc func(ctx context.Context, id string) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateRefreshToken holds details about calls to the CreateRefreshToken method.
+		CreateRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token *model.RefreshToken
+		}
+		// GetRefreshToken holds details about calls to the GetRefreshToken method.
+		GetRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// RevokeRefreshTokenFamily holds details about calls to the RevokeRefreshTokenFamily method.
+		RevokeRefreshTokenFamily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FamilyID is the familyID argument value.
+			FamilyID string
+		}
+		// RevokeUserRefreshTokens holds details about calls to the RevokeUserRefreshTokens method.
+		RevokeUserRefreshTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// UseRefreshToken holds details about calls to the UseRefreshToken method.
+		UseRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+	}
+	lockCreateRefreshToken       sync.RWMutex
+	lockGetRefreshToken          sync.RWMutex
+	lockRevokeRefreshTokenFamily sync.RWMutex
+	lockRevokeUserRefreshTokens  sync.RWMutex
+	lockUseRefreshToken          sync.RWMutex
+}
+
+// CreateRefreshToken calls CreateRefreshTokenFunc.
+func (mock *RefreshTokenRepositoryMock) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	if mock.CreateRefreshTokenFunc == nil {
+		panic("RefreshTokenRepositoryMock.CreateRefreshTokenFunc: method is nil but RefreshTokenRepository.CreateRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token *model.RefreshToken
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockCreateRefreshToken.Lock()
+	mock.calls.CreateRefreshToken = append(mock.calls.CreateRefreshToken, callInfo)
+	mock.lockCreateRefreshToken.Unlock()
+	return mock.CreateRefreshTokenFunc(ctx, token)
+}
+
+// CreateRefreshTokenCalls gets all the calls that were made to CreateRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.CreateRefreshTokenCalls())
+func (mock *RefreshTokenRepositoryMock) CreateRefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Token *model.RefreshToken
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token *model.RefreshToken
+	}
+	mock.lockCreateRefreshToken.RLock()
+	calls = mock.calls.CreateRefreshToken
+	mock.lockCreateRefreshToken.RUnlock()
+	return calls
+}
+
+// GetRefreshToken calls GetRefreshTokenFunc.
+func (mock *RefreshTokenRepositoryMock) GetRefreshToken(ctx context.Context, id string) (*model.RefreshToken, error) {
+	if mock.GetRefreshTokenFunc == nil {
+		panic("RefreshTokenRepositoryMock.GetRefreshTokenFunc: method is nil but RefreshTokenRepository.GetRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetRefreshToken.Lock()
+	mock.calls.GetRefreshToken = append(mock.calls.GetRefreshToken, callInfo)
+	mock.lockGetRefreshToken.Unlock()
+	return mock.GetRefreshTokenFunc(ctx, id)
+}
+
+// GetRefreshTokenCalls gets all the calls that were made to GetRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.GetRefreshTokenCalls())
+func (mock *RefreshTokenRepositoryMock) GetRefreshTokenCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetRefreshToken.RLock()
+	calls = mock.calls.GetRefreshToken
+	mock.lockGetRefreshToken.RUnlock()
+	return calls
+}
+
+// RevokeRefreshTokenFamily calls RevokeRefreshTokenFamilyFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	if mock.RevokeRefreshTokenFamilyFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeRefreshTokenFamilyFunc: method is nil but RefreshTokenRepository.RevokeRefreshTokenFamily was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FamilyID string
+	}{
+		Ctx:      ctx,
+		FamilyID: familyID,
+	}
+	mock.lockRevokeRefreshTokenFamily.Lock()
+	mock.calls.RevokeRefreshTokenFamily = append(mock.calls.RevokeRefreshTokenFamily, callInfo)
+	mock.lockRevokeRefreshTokenFamily.Unlock()
+	return mock.RevokeRefreshTokenFamilyFunc(ctx, familyID)
+}
+
+// RevokeRefreshTokenFamilyCalls gets all the calls that were made to RevokeRefreshTokenFamily.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeRefreshTokenFamilyCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeRefreshTokenFamilyCalls() []struct {
+	Ctx      context.Context
+	FamilyID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FamilyID string
+	}
+	mock.lockRevokeRefreshTokenFamily.RLock()
+	calls = mock.calls.RevokeRefreshTokenFamily
+	mock.lockRevokeRefreshTokenFamily.RUnlock()
+	return calls
+}
+
+// RevokeUserRefreshTokens calls RevokeUserRefreshTokensFunc.
+func (mock *RefreshTokenRepositoryMock) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	if mock.RevokeUserRefreshTokensFunc == nil {
+		panic("RefreshTokenRepositoryMock.RevokeUserRefreshTokensFunc: method is nil but RefreshTokenRepository.RevokeUserRefreshTokens was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUserRefreshTokens.Lock()
+	mock.calls.RevokeUserRefreshTokens = append(mock.calls.RevokeUserRefreshTokens, callInfo)
+	mock.lockRevokeUserRefreshTokens.Unlock()
+	return mock.RevokeUserRefreshTokensFunc(ctx, userID)
+}
+
+// RevokeUserRefreshTokensCalls gets all the calls that were made to RevokeUserRefreshTokens.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.RevokeUserRefreshTokensCalls())
+func (mock *RefreshTokenRepositoryMock) RevokeUserRefreshTokensCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockRevokeUserRefreshTokens.RLock()
+	calls = mock.calls.RevokeUserRefreshTokens
+	mock.lockRevokeUserRefreshTokens.RUnlock()
+	return calls
+}
+
+// UseRefreshToken calls UseRefreshTokenFunc.
+func (mock *RefreshTokenRepositoryMock) UseRefreshToken(ctx context.Context, id string) (bool, error) {
+	if mock.UseRefreshTokenFunc == nil {
+		panic("RefreshTokenRepositoryMock.UseRefreshTokenFunc: method is nil but RefreshTokenRepository.UseRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockUseRefreshToken.Lock()
+	mock.calls.UseRefreshToken = append(mock.calls.UseRefreshToken, callInfo)
+	mock.lockUseRefreshToken.Unlock()
+	return mock.UseRefreshTokenFunc(ctx, id)
+}
+
+// UseRefreshTokenCalls gets all the calls that were made to UseRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRepository.UseRefreshTokenCalls())
+func (mock *RefreshTokenRepositoryMock) UseRefreshTokenCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockUseRefreshToken.RLock()
+	calls = mock.calls.UseRefreshToken
+	mock.lockUseRefreshToken.RUnlock()
+	return calls
+}
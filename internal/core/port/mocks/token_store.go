@@ -0,0 +1,239 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+	"time"
+)
+
+// Ensure, that TokenStoreMock does implement port.TokenStore.
+// If this is not the case, regenerate this file with moq.
+var _ port.TokenStore = &TokenStoreMock{}
+
+// TokenStoreMock is a mock implementation of port.TokenStore.
+//
+//	func TestSomethingThatUsesTokenStore(t *testing.T) {
+//
+//		// make and configure a mocked port.TokenStore
+//		mockedTokenStore := &TokenStoreMock{
+//			IsRevokedFunc: func(ctx context.Context, tokenID string) (bool, error) {
+//				panic("mock out the IsRevoked method")
+//			},
+//			RevokeFunc: func(ctx context.Context, tokenID string, expiresAt time.Time) error {
+//				panic("mock out the Revoke method")
+//			},
+//			RevokeUserFunc: func(ctx context.Context, userID string) error {
+//				panic("mock out the RevokeUser method")
+//			},
+//			UserGenerationFunc: func(ctx context.Context, userID string) (int64, error) {
+//				panic("mock out the UserGeneration method")
+//			},
+//		}
+//
+//		// use mockedTokenStore in code that requires port.TokenStore
+//		// and then make assertions.
+//
+//	}
+type TokenStoreMock struct {
+	// IsRevokedFunc mocks the IsRevoked method.
+	IsRevokedFunc func(ctx context.Context, tokenID string) (bool, error)
+
+	// RevokeFunc mocks the Revoke method.
+	RevokeFunc func(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// RevokeUserFunc mocks the RevokeUser method.
+	RevokeUserFunc func(ctx context.Context, userID string) error
+
+	// UserGenerationFunc mocks the UserGeneration method.
+	UserGenerationFunc func(ctx context.Context, userID string) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IsRevoked holds details about calls to the IsRevoked method.
+		IsRevoked []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenID is the tokenID argument value.
+			TokenID string
+		}
+		// Revoke holds details about calls to the Revoke method.
+		Revoke []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenID is the tokenID argument value.
+			TokenID string
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt time.Time
+		}
+		// RevokeUser holds details about calls to the RevokeUser method.
+		RevokeUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// UserGeneration holds details about calls to the UserGeneration method.
+		UserGeneration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+	}
+	lockIsRevoked      sync.RWMutex
+	lockRevoke         sync.RWMutex
+	lockRevokeUser     sync.RWMutex
+	lockUserGeneration sync.RWMutex
+}
+
+// IsRevoked calls IsRevokedFunc.
+func (mock *TokenStoreMock) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	if mock.IsRevokedFunc == nil {
+		panic("TokenStoreMock.IsRevokedFunc: method is nil but TokenStore.IsRevoked was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		TokenID string
+	}{
+		Ctx:     ctx,
+		TokenID: tokenID,
+	}
+	mock.lockIsRevoked.Lock()
+	mock.calls.IsRevoked = append(mock.calls.IsRevoked, callInfo)
+	mock.lockIsRevoked.Unlock()
+	return mock.IsRevokedFunc(ctx, tokenID)
+}
+
+// IsRevokedCalls gets all the calls that were made to IsRevoked.
+// Check the length with:
+//
+//	len(mockedTokenStore.IsRevokedCalls())
+func (mock *TokenStoreMock) IsRevokedCalls() []struct {
+	Ctx     context.Context
+	TokenID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		TokenID string
+	}
+	mock.lockIsRevoked.RLock()
+	calls = mock.calls.IsRevoked
+	mock.lockIsRevoked.RUnlock()
+	return calls
+}
+
+// Revoke calls RevokeFunc.
+func (mock *TokenStoreMock) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if mock.RevokeFunc == nil {
+		panic("TokenStoreMock.RevokeFunc: method is nil but TokenStore.Revoke was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		TokenID   string
+		ExpiresAt time.Time
+	}{
+		Ctx:       ctx,
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockRevoke.Lock()
+	mock.calls.Revoke = append(mock.calls.Revoke, callInfo)
+	mock.lockRevoke.Unlock()
+	return mock.RevokeFunc(ctx, tokenID, expiresAt)
+}
+
+// RevokeCalls gets all the calls that were made to Revoke.
+// Check the length with:
+//
+//	len(mockedTokenStore.RevokeCalls())
+func (mock *TokenStoreMock) RevokeCalls() []struct {
+	Ctx       context.Context
+	TokenID   string
+	ExpiresAt time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		TokenID   string
+		ExpiresAt time.Time
+	}
+	mock.lockRevoke.RLock()
+	calls = mock.calls.Revoke
+	mock.lockRevoke.RUnlock()
+	return calls
+}
+
+// RevokeUser calls RevokeUserFunc.
+func (mock *TokenStoreMock) RevokeUser(ctx context.Context, userID string) error {
+	if mock.RevokeUserFunc == nil {
+		panic("TokenStoreMock.RevokeUserFunc: method is nil but TokenStore.RevokeUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUser.Lock()
+	mock.calls.RevokeUser = append(mock.calls.RevokeUser, callInfo)
+	mock.lockRevokeUser.Unlock()
+	return mock.RevokeUserFunc(ctx, userID)
+}
+
+// RevokeUserCalls gets all the calls that were made to RevokeUser.
+// Check the length with:
+//
+//	len(mockedTokenStore.RevokeUserCalls())
+func (mock *TokenStoreMock) RevokeUserCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockRevokeUser.RLock()
+	calls = mock.calls.RevokeUser
+	mock.lockRevokeUser.RUnlock()
+	return calls
+}
+
+// UserGeneration calls UserGenerationFunc.
+func (mock *TokenStoreMock) UserGeneration(ctx context.Context, userID string) (int64, error) {
+	if mock.UserGenerationFunc == nil {
+		panic("TokenStoreMock.UserGenerationFunc: method is nil but TokenStore.UserGeneration was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockUserGeneration.Lock()
+	mock.calls.UserGeneration = append(mock.calls.UserGeneration, callInfo)
+	mock.lockUserGeneration.Unlock()
+	return mock.UserGenerationFunc(ctx, userID)
+}
+
+// UserGenerationCalls gets all the calls that were made to UserGeneration.
+// Check the length with:
+//
+//	len(mockedTokenStore.UserGenerationCalls())
+func (mock *TokenStoreMock) UserGenerationCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockUserGeneration.RLock()
+	calls = mock.calls.UserGeneration
+	mock.lockUserGeneration.RUnlock()
+	return calls
+}
@@ -0,0 +1,239 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"sync"
+)
+
+// Ensure, that TransactionRepositoryMock does implement port.TransactionRepository.
+// If this is not the case, regenerate this file with moq.
+var _ port.TransactionRepository = &TransactionRepositoryMock{}
+
+// TransactionRepositoryMock is a mock implementation of port.TransactionRepository.
+//
+//	func TestSomethingThatUsesTransactionRepository(t *testing.T) {
+//
+//		// make and configure a mocked port.TransactionRepository
+//		mockedTransactionRepository := &TransactionRepositoryMock{
+//			CreateTransactionFunc: func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+//				panic("mock out the CreateTransaction method")
+//			},
+//			CreateTransferFunc: func(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error) {
+//				panic("mock out the CreateTransfer method")
+//			},
+//			GetTransactionFunc: func(ctx context.Context, accountNumber string, transactionID string) (*model.Transaction, error) {
+//				panic("mock out the GetTransaction method")
+//			},
+//			ListTransactionsFunc: func(ctx context.Context, accountNumber string) ([]model.Transaction, error) {
+//				panic("mock out the ListTransactions method")
+//			},
+//		}
+//
+//		// use mockedTransactionRepository in code that requires port.TransactionRepository
+//		// and then make assertions.
+//
+//	}
+type TransactionRepositoryMock struct {
+	// CreateTransactionFunc mocks the CreateTransaction method.
+	CreateTransactionFunc func(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error)
+
+	// CreateTransferFunc mocks the CreateTransfer method.
+	CreateTransferFunc func(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error)
+
+	// GetTransactionFunc mocks the GetTransaction method.
+	GetTransactionFunc func(ctx context.Context, accountNumber string, transactionID string) (*model.Transaction, error)
+
+	// ListTransactionsFunc mocks the ListTransactions method.
+	ListTransactionsFunc func(ctx context.Context, accountNumber string) ([]model.Transaction, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateTransaction holds details about calls to the CreateTransaction method.
+		CreateTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NewTransaction is the newTransaction argument value.
+			NewTransaction *model.NewTransaction
+		}
+		// CreateTransfer holds details about calls to the CreateTransfer method.
+		CreateTransfer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// NewTransfer is the newTransfer argument value.
+			NewTransfer *model.NewTransfer
+		}
+		// GetTransaction holds details about calls to the GetTransaction method.
+		GetTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+			// TransactionID is the transactionID argument value.
+			TransactionID string
+		}
+		// ListTransactions holds details about calls to the ListTransactions method.
+		ListTransactions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccountNumber is the accountNumber argument value.
+			AccountNumber string
+		}
+	}
+	lockCreateTransaction sync.RWMutex
+	lockCreateTransfer    sync.RWMutex
+	lockGetTransaction    sync.RWMutex
+	lockListTransactions  sync.RWMutex
+}
+
+// CreateTransaction calls CreateTransactionFunc.
+func (mock *TransactionRepositoryMock) CreateTransaction(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+	if mock.CreateTransactionFunc == nil {
+		panic("TransactionRepositoryMock.CreateTransactionFunc: method is nil but TransactionRepository.CreateTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		NewTransaction *model.NewTransaction
+	}{
+		Ctx:            ctx,
+		NewTransaction: newTransaction,
+	}
+	mock.lockCreateTransaction.Lock()
+	mock.calls.CreateTransaction = append(mock.calls.CreateTransaction, callInfo)
+	mock.lockCreateTransaction.Unlock()
+	return mock.CreateTransactionFunc(ctx, newTransaction)
+}
+
+// CreateTransactionCalls gets all the calls that were made to CreateTransaction.
+// Check the length with:
+//
+//	len(mockedTransactionRepository.CreateTransactionCalls())
+func (mock *TransactionRepositoryMock) CreateTransactionCalls() []struct {
+	Ctx            context.Context
+	NewTransaction *model.NewTransaction
+} {
+	var calls []struct {
+		Ctx            context.Context
+		NewTransaction *model.NewTransaction
+	}
+	mock.lockCreateTransaction.RLock()
+	calls = mock.calls.CreateTransaction
+	mock.lockCreateTransaction.RUnlock()
+	return calls
+}
+
+// CreateTransfer calls CreateTransferFunc.
+func (mock *TransactionRepositoryMock) CreateTransfer(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error) {
+	if mock.CreateTransferFunc == nil {
+		panic("TransactionRepositoryMock.CreateTransferFunc: method is nil but TransactionRepository.CreateTransfer was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		NewTransfer *model.NewTransfer
+	}{
+		Ctx:         ctx,
+		NewTransfer: newTransfer,
+	}
+	mock.lockCreateTransfer.Lock()
+	mock.calls.CreateTransfer = append(mock.calls.CreateTransfer, callInfo)
+	mock.lockCreateTransfer.Unlock()
+	return mock.CreateTransferFunc(ctx, newTransfer)
+}
+
+// CreateTransferCalls gets all the calls that were made to CreateTransfer.
+// Check the length with:
+//
+//	len(mockedTransactionRepository.CreateTransferCalls())
+func (mock *TransactionRepositoryMock) CreateTransferCalls() []struct {
+	Ctx         context.Context
+	NewTransfer *model.NewTransfer
+} {
+	var calls []struct {
+		Ctx         context.Context
+		NewTransfer *model.NewTransfer
+	}
+	mock.lockCreateTransfer.RLock()
+	calls = mock.calls.CreateTransfer
+	mock.lockCreateTransfer.RUnlock()
+	return calls
+}
+
+// GetTransaction calls GetTransactionFunc.
+func (mock *TransactionRepositoryMock) GetTransaction(ctx context.Context, accountNumber string, transactionID string) (*model.Transaction, error) {
+	if mock.GetTransactionFunc == nil {
+		panic("TransactionRepositoryMock.GetTransactionFunc: method is nil but TransactionRepository.GetTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		AccountNumber string
+		TransactionID string
+	}{
+		Ctx:           ctx,
+		AccountNumber: accountNumber,
+		TransactionID: transactionID,
+	}
+	mock.lockGetTransaction.Lock()
+	mock.calls.GetTransaction = append(mock.calls.GetTransaction, callInfo)
+	mock.lockGetTransaction.Unlock()
+	return mock.GetTransactionFunc(ctx, accountNumber, transactionID)
+}
+
+// GetTransactionCalls gets all the calls that were made to GetTransaction.
+// Check the length with:
+//
+//	len(mockedTransactionRepository.GetTransactionCalls())
+func (mock *TransactionRepositoryMock) GetTransactionCalls() []struct {
+	Ctx           context.Context
+	AccountNumber string
+	TransactionID string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		AccountNumber string
+		TransactionID string
+	}
+	mock.lockGetTransaction.RLock()
+	calls = mock.calls.GetTransaction
+	mock.lockGetTransaction.RUnlock()
+	return calls
+}
+
+// ListTransactions calls ListTransactionsFunc.
+func (mock *TransactionRepositoryMock) ListTransactions(ctx context.Context, accountNumber string) ([]model.Transaction, error) {
+	if mock.ListTransactionsFunc == nil {
+		panic("TransactionRepositoryMock.ListTransactionsFunc: method is nil but TransactionRepository.ListTransactions was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		AccountNumber string
+	}{
+		Ctx:           ctx,
+		AccountNumber: accountNumber,
+	}
+	mock.lockListTransactions.Lock()
+	mock.calls.ListTransactions = append(mock.calls.ListTransactions, callInfo)
+	mock.lockListTransactions.Unlock()
+	return mock.ListTransactionsFunc(ctx, accountNumber)
+}
+
+// ListTransactionsCalls gets all the calls that were made to ListTransactions.
+// Check the length with:
+//
+//	len(mockedTransactionRepository.ListTransactionsCalls())
+func (mock *TransactionRepositoryMock) ListTransactionsCalls() []struct {
+	Ctx           context.Context
+	AccountNumber string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		AccountNumber string
+	}
+	mock.lockListTransactions.RLock()
+	calls = mock.calls.ListTransactions
+	mock.lockListTransactions.RUnlock()
+	return calls
+}
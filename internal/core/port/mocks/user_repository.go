@@ -4,10 +4,12 @@
 package mocks
 
 import (
+	"context"
 	"eagle-bank.com/internal/adapter/storage/postgres/repository/entity"
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"sync"
+	"time"
 )
 
 // Ensure, that UserRepositoryMock does implement port.UserRepository.
@@ -20,28 +22,67 @@ var _ port.UserRepository = &UserRepositoryMock{}
 //
 //		// make and configure a mocked port.UserRepository
 //		mockedUserRepository := &UserRepositoryMock{
-//			CreateUserFunc: func(newUser *model.NewUser) (*model.User, error) {
+//			ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, hash []byte) error {
+//				panic("mock out the ChangePassword method")
+//			},
+//			ChangeUserStatusFunc: func(ctx context.Context, actor model.Actor, userID string, status string) error {
+//				panic("mock out the ChangeUserStatus method")
+//			},
+//			CreateUserFunc: func(ctx context.Context, actor model.Actor, newUser *model.NewUser) (*model.User, error) {
 //				panic("mock out the CreateUser method")
 //			},
-//			GetUserByEmailFunc: func(email string) (*entity.UserDAO, error) {
+//			DeleteUserFunc: func(ctx context.Context, actor model.Actor, id string, version *int64) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error) {
+//				panic("mock out the ForcePasswordReset method")
+//			},
+//			GetPasswordHashesFunc: func(ctx context.Context, userID string, limit int) ([]string, error) {
+//				panic("mock out the GetPasswordHashes method")
+//			},
+//			GetUserByEmailFunc: func(ctx context.Context, email string) (*entity.UserDAO, error) {
 //				panic("mock out the GetUserByEmail method")
 //			},
-//			GetUserByEmailVerificationTokenFunc: func(emailToken string) (*model.User, error) {
+//			GetUserByEmailVerificationTokenFunc: func(ctx context.Context, emailToken string) (*model.User, error) {
 //				panic("mock out the GetUserByEmailVerificationToken method")
 //			},
-//			GetUserByIDFunc: func(id string) (*model.User, error) {
+//			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
 //				panic("mock out the GetUserByID method")
 //			},
-//			LoginFunc: func(email string, password string) (string, error) {
+//			GetUserByPasswordResetTokenFunc: func(ctx context.Context, resetToken string) (*model.User, error) {
+//				panic("mock out the GetUserByPasswordResetToken method")
+//			},
+//			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+//				panic("mock out the GetUserLockoutByEmail method")
+//			},
+//			LockUserFunc: func(ctx context.Context, actor model.Actor, email string, until time.Time) error {
+//				panic("mock out the LockUser method")
+//			},
+//			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
 //				panic("mock out the Login method")
 //			},
-//			SetPasswordFunc: func(user *model.User, hash []byte) error {
+//			ReplacePasswordResetTokenFunc: func(ctx context.Context, actor model.Actor, email string, resetToken string, notIssuedSince time.Time) (*model.User, error) {
+//				panic("mock out the ReplacePasswordResetToken method")
+//			},
+//			ReplaceVerificationTokenFunc: func(ctx context.Context, actor model.Actor, email string, emailToken string, notIssuedSince time.Time) (*model.User, error) {
+//				panic("mock out the ReplaceVerificationToken method")
+//			},
+//			ResetPasswordFunc: func(ctx context.Context, actor model.Actor, resetToken string, hash []byte, loginKey string) error {
+//				panic("mock out the ResetPassword method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, search model.UserSearch) ([]model.User, error) {
+//				panic("mock out the SearchUsers method")
+//			},
+//			SetPasswordFunc: func(ctx context.Context, actor model.Actor, user *model.User, hash []byte) error {
 //				panic("mock out the SetPassword method")
 //			},
-//			UpdateUserFunc: func(user *model.User) (*model.User, error) {
+//			UnlockUserFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+//				panic("mock out the UnlockUser method")
+//			},
+//			UpdateUserFunc: func(ctx context.Context, actor model.Actor, user *model.User) (*model.User, error) {
 //				panic("mock out the UpdateUser method")
 //			},
-//			VerifyEmailFunc: func(emailToken string) error {
+//			VerifyEmailFunc: func(ctx context.Context, actor model.Actor, emailToken string) error {
 //				panic("mock out the VerifyEmail method")
 //			},
 //		}
@@ -51,101 +92,402 @@ var _ port.UserRepository = &UserRepositoryMock{}
 //
 //	}
 type UserRepositoryMock struct {
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, actor model.Actor, userID string, hash []byte) error
+
+	// ChangeUserStatusFunc mocks the ChangeUserStatus method.
+	ChangeUserStatusFunc func(ctx context.Context, actor model.Actor, userID string, status string) error
+
 	// CreateUserFunc mocks the CreateUser method.
-	CreateUserFunc func(newUser *model.NewUser) (*model.User, error)
+	CreateUserFunc func(ctx context.Context, actor model.Actor, newUser *model.NewUser) (*model.User, error)
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, actor model.Actor, id string, version *int64) error
+
+	// ForcePasswordResetFunc mocks the ForcePasswordReset method.
+	ForcePasswordResetFunc func(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error)
+
+	// GetPasswordHashesFunc mocks the GetPasswordHashes method.
+	GetPasswordHashesFunc func(ctx context.Context, userID string, limit int) ([]string, error)
 
 	// GetUserByEmailFunc mocks the GetUserByEmail method.
-	GetUserByEmailFunc func(email string) (*entity.UserDAO, error)
+	GetUserByEmailFunc func(ctx context.Context, email string) (*entity.UserDAO, error)
 
 	// GetUserByEmailVerificationTokenFunc mocks the GetUserByEmailVerificationToken method.
-	GetUserByEmailVerificationTokenFunc func(emailToken string) (*model.User, error)
+	GetUserByEmailVerificationTokenFunc func(ctx context.Context, emailToken string) (*model.User, error)
 
 	// GetUserByIDFunc mocks the GetUserByID method.
-	GetUserByIDFunc func(id string) (*model.User, error)
+	GetUserByIDFunc func(ctx context.Context, id string) (*model.User, error)
+
+	// GetUserByPasswordResetTokenFunc mocks the GetUserByPasswordResetToken method.
+	GetUserByPasswordResetTokenFunc func(ctx context.Context, resetToken string) (*model.User, error)
+
+	// GetUserLockoutByEmailFunc mocks the GetUserLockoutByEmail method.
+	GetUserLockoutByEmailFunc func(ctx context.Context, email string) (*model.UserLockout, error)
+
+	// LockUserFunc mocks the LockUser method.
+	LockUserFunc func(ctx context.Context, actor model.Actor, email string, until time.Time) error
 
 	// LoginFunc mocks the Login method.
-	LoginFunc func(email string, password string) (string, error)
+	LoginFunc func(ctx context.Context, email string, password string) (string, error)
+
+	// ReplacePasswordResetTokenFunc mocks the ReplacePasswordResetToken method.
+	ReplacePasswordResetTokenFunc func(ctx context.Context, actor model.Actor, email string, resetToken string, notIssuedSince time.Time) (*model.User, error)
+
+	// ReplaceVerificationTokenFunc mocks the ReplaceVerificationToken method.
+	ReplaceVerificationTokenFunc func(ctx context.Context, actor model.Actor, email string, emailToken string, notIssuedSince time.Time) (*model.User, error)
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, actor model.Actor, resetToken string, hash []byte, loginKey string) error
+
+	// SearchUsersFunc mocks the SearchUsers method.
+	SearchUsersFunc func(ctx context.Context, search model.UserSearch) ([]model.User, error)
 
 	// SetPasswordFunc mocks the SetPassword method.
-	SetPasswordFunc func(user *model.User, hash []byte) error
+	SetPasswordFunc func(ctx context.Context, actor model.Actor, user *model.User, hash []byte) error
+
+	// UnlockUserFunc mocks the UnlockUser method.
+	UnlockUserFunc func(ctx context.Context, actor model.Actor, userID string) error
 
 	// UpdateUserFunc mocks the UpdateUser method.
-	UpdateUserFunc func(user *model.User) (*model.User, error)
+	UpdateUserFunc func(ctx context.Context, actor model.Actor, user *model.User) (*model.User, error)
 
 	// VerifyEmailFunc mocks the VerifyEmail method.
-	VerifyEmailFunc func(emailToken string) error
+	VerifyEmailFunc func(ctx context.Context, actor model.Actor, emailToken string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+			// Hash is the hash argument value.
+			Hash []byte
+		}
+		// ChangeUserStatus holds details about calls to the ChangeUserStatus method.
+		ChangeUserStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+			// Status is the status argument value.
+			Status string
+		}
 		// CreateUser holds details about calls to the CreateUser method.
 		CreateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// NewUser is the newUser argument value.
 			NewUser *model.NewUser
 		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// ID is the id argument value.
+			ID string
+			// Version is the version argument value.
+			Version *int64
+		}
+		// ForcePasswordReset holds details about calls to the ForcePasswordReset method.
+		ForcePasswordReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+			// ResetToken is the resetToken argument value.
+			ResetToken string
+		}
+		// GetPasswordHashes holds details about calls to the GetPasswordHashes method.
+		GetPasswordHashes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetUserByEmail holds details about calls to the GetUserByEmail method.
 		GetUserByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Email is the email argument value.
 			Email string
 		}
 		// GetUserByEmailVerificationToken holds details about calls to the GetUserByEmailVerificationToken method.
 		GetUserByEmailVerificationToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// EmailToken is the emailToken argument value.
 			EmailToken string
 		}
 		// GetUserByID holds details about calls to the GetUserByID method.
 		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the id argument value.
 			ID string
 		}
+		// GetUserByPasswordResetToken holds details about calls to the GetUserByPasswordResetToken method.
+		GetUserByPasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ResetToken is the resetToken argument value.
+			ResetToken string
+		}
+		// GetUserLockoutByEmail holds details about calls to the GetUserLockoutByEmail method.
+		GetUserLockoutByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+		// LockUser holds details about calls to the LockUser method.
+		LockUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Email is the email argument value.
+			Email string
+			// Until is the until argument value.
+			Until time.Time
+		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Email is the email argument value.
 			Email string
 			// Password is the password argument value.
 			Password string
 		}
+		// ReplacePasswordResetToken holds details about calls to the ReplacePasswordResetToken method.
+		ReplacePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Email is the email argument value.
+			Email string
+			// ResetToken is the resetToken argument value.
+			ResetToken string
+			// NotIssuedSince is the notIssuedSince argument value.
+			NotIssuedSince time.Time
+		}
+		// ReplaceVerificationToken holds details about calls to the ReplaceVerificationToken method.
+		ReplaceVerificationToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Email is the email argument value.
+			Email string
+			// EmailToken is the emailToken argument value.
+			EmailToken string
+			// NotIssuedSince is the notIssuedSince argument value.
+			NotIssuedSince time.Time
+		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// ResetToken is the resetToken argument value.
+			ResetToken string
+			// Hash is the hash argument value.
+			Hash []byte
+			// LoginKey is the loginKey argument value.
+			LoginKey string
+		}
+		// SearchUsers holds details about calls to the SearchUsers method.
+		SearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Search is the search argument value.
+			Search model.UserSearch
+		}
 		// SetPassword holds details about calls to the SetPassword method.
 		SetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// User is the user argument value.
 			User *model.User
 			// Hash is the hash argument value.
 			Hash []byte
 		}
+		// UnlockUser holds details about calls to the UnlockUser method.
+		UnlockUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// UpdateUser holds details about calls to the UpdateUser method.
 		UpdateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// User is the user argument value.
 			User *model.User
 		}
 		// VerifyEmail holds details about calls to the VerifyEmail method.
 		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// EmailToken is the emailToken argument value.
 			EmailToken string
 		}
 	}
+	lockChangePassword                  sync.RWMutex
+	lockChangeUserStatus                sync.RWMutex
 	lockCreateUser                      sync.RWMutex
+	lockDeleteUser                      sync.RWMutex
+	lockForcePasswordReset              sync.RWMutex
+	lockGetPasswordHashes               sync.RWMutex
 	lockGetUserByEmail                  sync.RWMutex
 	lockGetUserByEmailVerificationToken sync.RWMutex
 	lockGetUserByID                     sync.RWMutex
+	lockGetUserByPasswordResetToken     sync.RWMutex
+	lockGetUserLockoutByEmail           sync.RWMutex
+	lockLockUser                        sync.RWMutex
 	lockLogin                           sync.RWMutex
+	lockReplacePasswordResetToken       sync.RWMutex
+	lockReplaceVerificationToken        sync.RWMutex
+	lockResetPassword                   sync.RWMutex
+	lockSearchUsers                     sync.RWMutex
 	lockSetPassword                     sync.RWMutex
+	lockUnlockUser                      sync.RWMutex
 	lockUpdateUser                      sync.RWMutex
 	lockVerifyEmail                     sync.RWMutex
 }
 
+// ChangePassword calls ChangePasswordFunc.
+func (mock *UserRepositoryMock) ChangePassword(ctx context.Context, actor model.Actor, userID string, hash []byte) error {
+	if mock.ChangePasswordFunc == nil {
+		panic("UserRepositoryMock.ChangePasswordFunc: method is nil but UserRepository.ChangePassword was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+		Hash   []byte
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+		Hash:   hash,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	return mock.ChangePasswordFunc(ctx, actor, userID, hash)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedUserRepository.ChangePasswordCalls())
+func (mock *UserRepositoryMock) ChangePasswordCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+	Hash   []byte
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+		Hash   []byte
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
+// ChangeUserStatus calls ChangeUserStatusFunc.
+func (mock *UserRepositoryMock) ChangeUserStatus(ctx context.Context, actor model.Actor, userID string, status string) error {
+	if mock.ChangeUserStatusFunc == nil {
+		panic("UserRepositoryMock.ChangeUserStatusFunc: method is nil but UserRepository.ChangeUserStatus was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+		Status string
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+		Status: status,
+	}
+	mock.lockChangeUserStatus.Lock()
+	mock.calls.ChangeUserStatus = append(mock.calls.ChangeUserStatus, callInfo)
+	mock.lockChangeUserStatus.Unlock()
+	return mock.ChangeUserStatusFunc(ctx, actor, userID, status)
+}
+
+// ChangeUserStatusCalls gets all the calls that were made to ChangeUserStatus.
+// Check the length with:
+//
+//	len(mockedUserRepository.ChangeUserStatusCalls())
+func (mock *UserRepositoryMock) ChangeUserStatusCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+	Status string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+		Status string
+	}
+	mock.lockChangeUserStatus.RLock()
+	calls = mock.calls.ChangeUserStatus
+	mock.lockChangeUserStatus.RUnlock()
+	return calls
+}
+
 // CreateUser calls CreateUserFunc.
-func (mock *UserRepositoryMock) CreateUser(newUser *model.NewUser) (*model.User, error) {
+func (mock *UserRepositoryMock) CreateUser(ctx context.Context, actor model.Actor, newUser *model.NewUser) (*model.User, error) {
 	if mock.CreateUserFunc == nil {
 		panic("UserRepositoryMock.CreateUserFunc: method is nil but UserRepository.CreateUser was just called")
 	}
 	callInfo := struct {
+		Ctx     context.Context
+		Actor   model.Actor
 		NewUser *model.NewUser
 	}{
+		Ctx:     ctx,
+		Actor:   actor,
 		NewUser: newUser,
 	}
 	mock.lockCreateUser.Lock()
 	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
 	mock.lockCreateUser.Unlock()
-	return mock.CreateUserFunc(newUser)
+	return mock.CreateUserFunc(ctx, actor, newUser)
 }
 
 // CreateUserCalls gets all the calls that were made to CreateUser.
@@ -153,9 +495,13 @@ func (mock *UserRepositoryMock) CreateUser(newUser *model.NewUser) (*model.User,
 //
 //	len(mockedUserRepository.CreateUserCalls())
 func (mock *UserRepositoryMock) CreateUserCalls() []struct {
+	Ctx     context.Context
+	Actor   model.Actor
 	NewUser *model.NewUser
 } {
 	var calls []struct {
+		Ctx     context.Context
+		Actor   model.Actor
 		NewUser *model.NewUser
 	}
 	mock.lockCreateUser.RLock()
@@ -164,20 +510,150 @@ func (mock *UserRepositoryMock) CreateUserCalls() []struct {
 	return calls
 }
 
+// DeleteUser calls DeleteUserFunc.
+func (mock *UserRepositoryMock) DeleteUser(ctx context.Context, actor model.Actor, id string, version *int64) error {
+	if mock.DeleteUserFunc == nil {
+		panic("UserRepositoryMock.DeleteUserFunc: method is nil but UserRepository.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Actor   model.Actor
+		ID      string
+		Version *int64
+	}{
+		Ctx:     ctx,
+		Actor:   actor,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, actor, id, version)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.DeleteUserCalls())
+func (mock *UserRepositoryMock) DeleteUserCalls() []struct {
+	Ctx     context.Context
+	Actor   model.Actor
+	ID      string
+	Version *int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Actor   model.Actor
+		ID      string
+		Version *int64
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// ForcePasswordReset calls ForcePasswordResetFunc.
+func (mock *UserRepositoryMock) ForcePasswordReset(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error) {
+	if mock.ForcePasswordResetFunc == nil {
+		panic("UserRepositoryMock.ForcePasswordResetFunc: method is nil but UserRepository.ForcePasswordReset was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
+		UserID     string
+		ResetToken string
+	}{
+		Ctx:        ctx,
+		Actor:      actor,
+		UserID:     userID,
+		ResetToken: resetToken,
+	}
+	mock.lockForcePasswordReset.Lock()
+	mock.calls.ForcePasswordReset = append(mock.calls.ForcePasswordReset, callInfo)
+	mock.lockForcePasswordReset.Unlock()
+	return mock.ForcePasswordResetFunc(ctx, actor, userID, resetToken)
+}
+
+// ForcePasswordResetCalls gets all the calls that were made to ForcePasswordReset.
+// Check the length with:
+//
+//	len(mockedUserRepository.ForcePasswordResetCalls())
+func (mock *UserRepositoryMock) ForcePasswordResetCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
+	UserID     string
+	ResetToken string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
+		UserID     string
+		ResetToken string
+	}
+	mock.lockForcePasswordReset.RLock()
+	calls = mock.calls.ForcePasswordReset
+	mock.lockForcePasswordReset.RUnlock()
+	return calls
+}
+
+// GetPasswordHashes calls GetPasswordHashesFunc.
+func (mock *UserRepositoryMock) GetPasswordHashes(ctx context.Context, userID string, limit int) ([]string, error) {
+	if mock.GetPasswordHashesFunc == nil {
+		panic("UserRepositoryMock.GetPasswordHashesFunc: method is nil but UserRepository.GetPasswordHashes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Limit:  limit,
+	}
+	mock.lockGetPasswordHashes.Lock()
+	mock.calls.GetPasswordHashes = append(mock.calls.GetPasswordHashes, callInfo)
+	mock.lockGetPasswordHashes.Unlock()
+	return mock.GetPasswordHashesFunc(ctx, userID, limit)
+}
+
+// GetPasswordHashesCalls gets all the calls that were made to GetPasswordHashes.
+// Check the length with:
+//
+//	len(mockedUserRepository.GetPasswordHashesCalls())
+func (mock *UserRepositoryMock) GetPasswordHashesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Limit  int
+	}
+	mock.lockGetPasswordHashes.RLock()
+	calls = mock.calls.GetPasswordHashes
+	mock.lockGetPasswordHashes.RUnlock()
+	return calls
+}
+
 // GetUserByEmail calls GetUserByEmailFunc.
-func (mock *UserRepositoryMock) GetUserByEmail(email string) (*entity.UserDAO, error) {
+func (mock *UserRepositoryMock) GetUserByEmail(ctx context.Context, email string) (*entity.UserDAO, error) {
 	if mock.GetUserByEmailFunc == nil {
 		panic("UserRepositoryMock.GetUserByEmailFunc: method is nil but UserRepository.GetUserByEmail was just called")
 	}
 	callInfo := struct {
+		Ctx   context.Context
 		Email string
 	}{
+		Ctx:   ctx,
 		Email: email,
 	}
 	mock.lockGetUserByEmail.Lock()
 	mock.calls.GetUserByEmail = append(mock.calls.GetUserByEmail, callInfo)
 	mock.lockGetUserByEmail.Unlock()
-	return mock.GetUserByEmailFunc(email)
+	return mock.GetUserByEmailFunc(ctx, email)
 }
 
 // GetUserByEmailCalls gets all the calls that were made to GetUserByEmail.
@@ -185,9 +661,11 @@ func (mock *UserRepositoryMock) GetUserByEmail(email string) (*entity.UserDAO, e
 //
 //	len(mockedUserRepository.GetUserByEmailCalls())
 func (mock *UserRepositoryMock) GetUserByEmailCalls() []struct {
+	Ctx   context.Context
 	Email string
 } {
 	var calls []struct {
+		Ctx   context.Context
 		Email string
 	}
 	mock.lockGetUserByEmail.RLock()
@@ -197,19 +675,21 @@ func (mock *UserRepositoryMock) GetUserByEmailCalls() []struct {
 }
 
 // GetUserByEmailVerificationToken calls GetUserByEmailVerificationTokenFunc.
-func (mock *UserRepositoryMock) GetUserByEmailVerificationToken(emailToken string) (*model.User, error) {
+func (mock *UserRepositoryMock) GetUserByEmailVerificationToken(ctx context.Context, emailToken string) (*model.User, error) {
 	if mock.GetUserByEmailVerificationTokenFunc == nil {
 		panic("UserRepositoryMock.GetUserByEmailVerificationTokenFunc: method is nil but UserRepository.GetUserByEmailVerificationToken was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
 		EmailToken string
 	}{
+		Ctx:        ctx,
 		EmailToken: emailToken,
 	}
 	mock.lockGetUserByEmailVerificationToken.Lock()
 	mock.calls.GetUserByEmailVerificationToken = append(mock.calls.GetUserByEmailVerificationToken, callInfo)
 	mock.lockGetUserByEmailVerificationToken.Unlock()
-	return mock.GetUserByEmailVerificationTokenFunc(emailToken)
+	return mock.GetUserByEmailVerificationTokenFunc(ctx, emailToken)
 }
 
 // GetUserByEmailVerificationTokenCalls gets all the calls that were made to GetUserByEmailVerificationToken.
@@ -217,9 +697,11 @@ func (mock *UserRepositoryMock) GetUserByEmailVerificationToken(emailToken strin
 //
 //	len(mockedUserRepository.GetUserByEmailVerificationTokenCalls())
 func (mock *UserRepositoryMock) GetUserByEmailVerificationTokenCalls() []struct {
+	Ctx        context.Context
 	EmailToken string
 } {
 	var calls []struct {
+		Ctx        context.Context
 		EmailToken string
 	}
 	mock.lockGetUserByEmailVerificationToken.RLock()
@@ -229,19 +711,21 @@ func (mock *UserRepositoryMock) GetUserByEmailVerificationTokenCalls() []struct
 }
 
 // GetUserByID calls GetUserByIDFunc.
-func (mock *UserRepositoryMock) GetUserByID(id string) (*model.User, error) {
+func (mock *UserRepositoryMock) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	if mock.GetUserByIDFunc == nil {
 		panic("UserRepositoryMock.GetUserByIDFunc: method is nil but UserRepository.GetUserByID was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}{
-		ID: id,
+		Ctx: ctx,
+		ID:  id,
 	}
 	mock.lockGetUserByID.Lock()
 	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
 	mock.lockGetUserByID.Unlock()
-	return mock.GetUserByIDFunc(id)
+	return mock.GetUserByIDFunc(ctx, id)
 }
 
 // GetUserByIDCalls gets all the calls that were made to GetUserByID.
@@ -249,10 +733,12 @@ func (mock *UserRepositoryMock) GetUserByID(id string) (*model.User, error) {
 //
 //	len(mockedUserRepository.GetUserByIDCalls())
 func (mock *UserRepositoryMock) GetUserByIDCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	mock.lockGetUserByID.RLock()
 	calls = mock.calls.GetUserByID
@@ -260,22 +746,140 @@ func (mock *UserRepositoryMock) GetUserByIDCalls() []struct {
 	return calls
 }
 
+// GetUserByPasswordResetToken calls GetUserByPasswordResetTokenFunc.
+func (mock *UserRepositoryMock) GetUserByPasswordResetToken(ctx context.Context, resetToken string) (*model.User, error) {
+	if mock.GetUserByPasswordResetTokenFunc == nil {
+		panic("UserRepositoryMock.GetUserByPasswordResetTokenFunc: method is nil but UserRepository.GetUserByPasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ResetToken string
+	}{
+		Ctx:        ctx,
+		ResetToken: resetToken,
+	}
+	mock.lockGetUserByPasswordResetToken.Lock()
+	mock.calls.GetUserByPasswordResetToken = append(mock.calls.GetUserByPasswordResetToken, callInfo)
+	mock.lockGetUserByPasswordResetToken.Unlock()
+	return mock.GetUserByPasswordResetTokenFunc(ctx, resetToken)
+}
+
+// GetUserByPasswordResetTokenCalls gets all the calls that were made to GetUserByPasswordResetToken.
+// Check the length with:
+//
+//	len(mockedUserRepository.GetUserByPasswordResetTokenCalls())
+func (mock *UserRepositoryMock) GetUserByPasswordResetTokenCalls() []struct {
+	Ctx        context.Context
+	ResetToken string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ResetToken string
+	}
+	mock.lockGetUserByPasswordResetToken.RLock()
+	calls = mock.calls.GetUserByPasswordResetToken
+	mock.lockGetUserByPasswordResetToken.RUnlock()
+	return calls
+}
+
+// GetUserLockoutByEmail calls GetUserLockoutByEmailFunc.
+func (mock *UserRepositoryMock) GetUserLockoutByEmail(ctx context.Context, email string) (*model.UserLockout, error) {
+	if mock.GetUserLockoutByEmailFunc == nil {
+		panic("UserRepositoryMock.GetUserLockoutByEmailFunc: method is nil but UserRepository.GetUserLockoutByEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockGetUserLockoutByEmail.Lock()
+	mock.calls.GetUserLockoutByEmail = append(mock.calls.GetUserLockoutByEmail, callInfo)
+	mock.lockGetUserLockoutByEmail.Unlock()
+	return mock.GetUserLockoutByEmailFunc(ctx, email)
+}
+
+// GetUserLockoutByEmailCalls gets all the calls that were made to GetUserLockoutByEmail.
+// Check the length with:
+//
+//	len(mockedUserRepository.GetUserLockoutByEmailCalls())
+func (mock *UserRepositoryMock) GetUserLockoutByEmailCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockGetUserLockoutByEmail.RLock()
+	calls = mock.calls.GetUserLockoutByEmail
+	mock.lockGetUserLockoutByEmail.RUnlock()
+	return calls
+}
+
+// LockUser calls LockUserFunc.
+func (mock *UserRepositoryMock) LockUser(ctx context.Context, actor model.Actor, email string, until time.Time) error {
+	if mock.LockUserFunc == nil {
+		panic("UserRepositoryMock.LockUserFunc: method is nil but UserRepository.LockUser was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Actor model.Actor
+		Email string
+		Until time.Time
+	}{
+		Ctx:   ctx,
+		Actor: actor,
+		Email: email,
+		Until: until,
+	}
+	mock.lockLockUser.Lock()
+	mock.calls.LockUser = append(mock.calls.LockUser, callInfo)
+	mock.lockLockUser.Unlock()
+	return mock.LockUserFunc(ctx, actor, email, until)
+}
+
+// LockUserCalls gets all the calls that were made to LockUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.LockUserCalls())
+func (mock *UserRepositoryMock) LockUserCalls() []struct {
+	Ctx   context.Context
+	Actor model.Actor
+	Email string
+	Until time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Actor model.Actor
+		Email string
+		Until time.Time
+	}
+	mock.lockLockUser.RLock()
+	calls = mock.calls.LockUser
+	mock.lockLockUser.RUnlock()
+	return calls
+}
+
 // Login calls LoginFunc.
-func (mock *UserRepositoryMock) Login(email string, password string) (string, error) {
+func (mock *UserRepositoryMock) Login(ctx context.Context, email string, password string) (string, error) {
 	if mock.LoginFunc == nil {
 		panic("UserRepositoryMock.LoginFunc: method is nil but UserRepository.Login was just called")
 	}
 	callInfo := struct {
+		Ctx      context.Context
 		Email    string
 		Password string
 	}{
+		Ctx:      ctx,
 		Email:    email,
 		Password: password,
 	}
 	mock.lockLogin.Lock()
 	mock.calls.Login = append(mock.calls.Login, callInfo)
 	mock.lockLogin.Unlock()
-	return mock.LoginFunc(email, password)
+	return mock.LoginFunc(ctx, email, password)
 }
 
 // LoginCalls gets all the calls that were made to Login.
@@ -283,10 +887,12 @@ func (mock *UserRepositoryMock) Login(email string, password string) (string, er
 //
 //	len(mockedUserRepository.LoginCalls())
 func (mock *UserRepositoryMock) LoginCalls() []struct {
+	Ctx      context.Context
 	Email    string
 	Password string
 } {
 	var calls []struct {
+		Ctx      context.Context
 		Email    string
 		Password string
 	}
@@ -296,22 +902,206 @@ func (mock *UserRepositoryMock) LoginCalls() []struct {
 	return calls
 }
 
+// ReplacePasswordResetToken calls ReplacePasswordResetTokenFunc.
+func (mock *UserRepositoryMock) ReplacePasswordResetToken(ctx context.Context, actor model.Actor, email string, resetToken string, notIssuedSince time.Time) (*model.User, error) {
+	if mock.ReplacePasswordResetTokenFunc == nil {
+		panic("UserRepositoryMock.ReplacePasswordResetTokenFunc: method is nil but UserRepository.ReplacePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Actor          model.Actor
+		Email          string
+		ResetToken     string
+		NotIssuedSince time.Time
+	}{
+		Ctx:            ctx,
+		Actor:          actor,
+		Email:          email,
+		ResetToken:     resetToken,
+		NotIssuedSince: notIssuedSince,
+	}
+	mock.lockReplacePasswordResetToken.Lock()
+	mock.calls.ReplacePasswordResetToken = append(mock.calls.ReplacePasswordResetToken, callInfo)
+	mock.lockReplacePasswordResetToken.Unlock()
+	return mock.ReplacePasswordResetTokenFunc(ctx, actor, email, resetToken, notIssuedSince)
+}
+
+// ReplacePasswordResetTokenCalls gets all the calls that were made to ReplacePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedUserRepository.ReplacePasswordResetTokenCalls())
+func (mock *UserRepositoryMock) ReplacePasswordResetTokenCalls() []struct {
+	Ctx            context.Context
+	Actor          model.Actor
+	Email          string
+	ResetToken     string
+	NotIssuedSince time.Time
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Actor          model.Actor
+		Email          string
+		ResetToken     string
+		NotIssuedSince time.Time
+	}
+	mock.lockReplacePasswordResetToken.RLock()
+	calls = mock.calls.ReplacePasswordResetToken
+	mock.lockReplacePasswordResetToken.RUnlock()
+	return calls
+}
+
+// ReplaceVerificationToken calls ReplaceVerificationTokenFunc.
+func (mock *UserRepositoryMock) ReplaceVerificationToken(ctx context.Context, actor model.Actor, email string, emailToken string, notIssuedSince time.Time) (*model.User, error) {
+	if mock.ReplaceVerificationTokenFunc == nil {
+		panic("UserRepositoryMock.ReplaceVerificationTokenFunc: method is nil but UserRepository.ReplaceVerificationToken was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Actor          model.Actor
+		Email          string
+		EmailToken     string
+		NotIssuedSince time.Time
+	}{
+		Ctx:            ctx,
+		Actor:          actor,
+		Email:          email,
+		EmailToken:     emailToken,
+		NotIssuedSince: notIssuedSince,
+	}
+	mock.lockReplaceVerificationToken.Lock()
+	mock.calls.ReplaceVerificationToken = append(mock.calls.ReplaceVerificationToken, callInfo)
+	mock.lockReplaceVerificationToken.Unlock()
+	return mock.ReplaceVerificationTokenFunc(ctx, actor, email, emailToken, notIssuedSince)
+}
+
+// ReplaceVerificationTokenCalls gets all the calls that were made to ReplaceVerificationToken.
+// Check the length with:
+//
+//	len(mockedUserRepository.ReplaceVerificationTokenCalls())
+func (mock *UserRepositoryMock) ReplaceVerificationTokenCalls() []struct {
+	Ctx            context.Context
+	Actor          model.Actor
+	Email          string
+	EmailToken     string
+	NotIssuedSince time.Time
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Actor          model.Actor
+		Email          string
+		EmailToken     string
+		NotIssuedSince time.Time
+	}
+	mock.lockReplaceVerificationToken.RLock()
+	calls = mock.calls.ReplaceVerificationToken
+	mock.lockReplaceVerificationToken.RUnlock()
+	return calls
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *UserRepositoryMock) ResetPassword(ctx context.Context, actor model.Actor, resetToken string, hash []byte, loginKey string) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("UserRepositoryMock.ResetPasswordFunc: method is nil but UserRepository.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
+		ResetToken string
+		Hash       []byte
+		LoginKey   string
+	}{
+		Ctx:        ctx,
+		Actor:      actor,
+		ResetToken: resetToken,
+		Hash:       hash,
+		LoginKey:   loginKey,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx, actor, resetToken, hash, loginKey)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedUserRepository.ResetPasswordCalls())
+func (mock *UserRepositoryMock) ResetPasswordCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
+	ResetToken string
+	Hash       []byte
+	LoginKey   string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
+		ResetToken string
+		Hash       []byte
+		LoginKey   string
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
+
+// SearchUsers calls SearchUsersFunc.
+func (mock *UserRepositoryMock) SearchUsers(ctx context.Context, search model.UserSearch) ([]model.User, error) {
+	if mock.SearchUsersFunc == nil {
+		panic("UserRepositoryMock.SearchUsersFunc: method is nil but UserRepository.SearchUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Search model.UserSearch
+	}{
+		Ctx:    ctx,
+		Search: search,
+	}
+	mock.lockSearchUsers.Lock()
+	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
+	mock.lockSearchUsers.Unlock()
+	return mock.SearchUsersFunc(ctx, search)
+}
+
+// SearchUsersCalls gets all the calls that were made to SearchUsers.
+// Check the length with:
+//
+//	len(mockedUserRepository.SearchUsersCalls())
+func (mock *UserRepositoryMock) SearchUsersCalls() []struct {
+	Ctx    context.Context
+	Search model.UserSearch
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Search model.UserSearch
+	}
+	mock.lockSearchUsers.RLock()
+	calls = mock.calls.SearchUsers
+	mock.lockSearchUsers.RUnlock()
+	return calls
+}
+
 // SetPassword calls SetPasswordFunc.
-func (mock *UserRepositoryMock) SetPassword(user *model.User, hash []byte) error {
+func (mock *UserRepositoryMock) SetPassword(ctx context.Context, actor model.Actor, user *model.User, hash []byte) error {
 	if mock.SetPasswordFunc == nil {
 		panic("UserRepositoryMock.SetPasswordFunc: method is nil but UserRepository.SetPassword was just called")
 	}
 	callInfo := struct {
-		User *model.User
-		Hash []byte
+		Ctx   context.Context
+		Actor model.Actor
+		User  *model.User
+		Hash  []byte
 	}{
-		User: user,
-		Hash: hash,
+		Ctx:   ctx,
+		Actor: actor,
+		User:  user,
+		Hash:  hash,
 	}
 	mock.lockSetPassword.Lock()
 	mock.calls.SetPassword = append(mock.calls.SetPassword, callInfo)
 	mock.lockSetPassword.Unlock()
-	return mock.SetPasswordFunc(user, hash)
+	return mock.SetPasswordFunc(ctx, actor, user, hash)
 }
 
 // SetPasswordCalls gets all the calls that were made to SetPassword.
@@ -319,12 +1109,16 @@ func (mock *UserRepositoryMock) SetPassword(user *model.User, hash []byte) error
 //
 //	len(mockedUserRepository.SetPasswordCalls())
 func (mock *UserRepositoryMock) SetPasswordCalls() []struct {
-	User *model.User
-	Hash []byte
+	Ctx   context.Context
+	Actor model.Actor
+	User  *model.User
+	Hash  []byte
 } {
 	var calls []struct {
-		User *model.User
-		Hash []byte
+		Ctx   context.Context
+		Actor model.Actor
+		User  *model.User
+		Hash  []byte
 	}
 	mock.lockSetPassword.RLock()
 	calls = mock.calls.SetPassword
@@ -332,20 +1126,64 @@ func (mock *UserRepositoryMock) SetPasswordCalls() []struct {
 	return calls
 }
 
+// UnlockUser calls UnlockUserFunc.
+func (mock *UserRepositoryMock) UnlockUser(ctx context.Context, actor model.Actor, userID string) error {
+	if mock.UnlockUserFunc == nil {
+		panic("UserRepositoryMock.UnlockUserFunc: method is nil but UserRepository.UnlockUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+	}
+	mock.lockUnlockUser.Lock()
+	mock.calls.UnlockUser = append(mock.calls.UnlockUser, callInfo)
+	mock.lockUnlockUser.Unlock()
+	return mock.UnlockUserFunc(ctx, actor, userID)
+}
+
+// UnlockUserCalls gets all the calls that were made to UnlockUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.UnlockUserCalls())
+func (mock *UserRepositoryMock) UnlockUserCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}
+	mock.lockUnlockUser.RLock()
+	calls = mock.calls.UnlockUser
+	mock.lockUnlockUser.RUnlock()
+	return calls
+}
+
 // UpdateUser calls UpdateUserFunc.
-func (mock *UserRepositoryMock) UpdateUser(user *model.User) (*model.User, error) {
+func (mock *UserRepositoryMock) UpdateUser(ctx context.Context, actor model.Actor, user *model.User) (*model.User, error) {
 	if mock.UpdateUserFunc == nil {
 		panic("UserRepositoryMock.UpdateUserFunc: method is nil but UserRepository.UpdateUser was just called")
 	}
 	callInfo := struct {
-		User *model.User
+		Ctx   context.Context
+		Actor model.Actor
+		User  *model.User
 	}{
-		User: user,
+		Ctx:   ctx,
+		Actor: actor,
+		User:  user,
 	}
 	mock.lockUpdateUser.Lock()
 	mock.calls.UpdateUser = append(mock.calls.UpdateUser, callInfo)
 	mock.lockUpdateUser.Unlock()
-	return mock.UpdateUserFunc(user)
+	return mock.UpdateUserFunc(ctx, actor, user)
 }
 
 // UpdateUserCalls gets all the calls that were made to UpdateUser.
@@ -353,10 +1191,14 @@ func (mock *UserRepositoryMock) UpdateUser(user *model.User) (*model.User, error
 //
 //	len(mockedUserRepository.UpdateUserCalls())
 func (mock *UserRepositoryMock) UpdateUserCalls() []struct {
-	User *model.User
+	Ctx   context.Context
+	Actor model.Actor
+	User  *model.User
 } {
 	var calls []struct {
-		User *model.User
+		Ctx   context.Context
+		Actor model.Actor
+		User  *model.User
 	}
 	mock.lockUpdateUser.RLock()
 	calls = mock.calls.UpdateUser
@@ -365,19 +1207,23 @@ func (mock *UserRepositoryMock) UpdateUserCalls() []struct {
 }
 
 // VerifyEmail calls VerifyEmailFunc.
-func (mock *UserRepositoryMock) VerifyEmail(emailToken string) error {
+func (mock *UserRepositoryMock) VerifyEmail(ctx context.Context, actor model.Actor, emailToken string) error {
 	if mock.VerifyEmailFunc == nil {
 		panic("UserRepositoryMock.VerifyEmailFunc: method is nil but UserRepository.VerifyEmail was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		EmailToken string
 	}{
+		Ctx:        ctx,
+		Actor:      actor,
 		EmailToken: emailToken,
 	}
 	mock.lockVerifyEmail.Lock()
 	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
 	mock.lockVerifyEmail.Unlock()
-	return mock.VerifyEmailFunc(emailToken)
+	return mock.VerifyEmailFunc(ctx, actor, emailToken)
 }
 
 // VerifyEmailCalls gets all the calls that were made to VerifyEmail.
@@ -385,9 +1231,13 @@ func (mock *UserRepositoryMock) VerifyEmail(emailToken string) error {
 //
 //	len(mockedUserRepository.VerifyEmailCalls())
 func (mock *UserRepositoryMock) VerifyEmailCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
 	EmailToken string
 } {
 	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		EmailToken string
 	}
 	mock.lockVerifyEmail.RLock()
@@ -4,6 +4,7 @@
 package mocks
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
 	"sync"
@@ -19,22 +20,46 @@ var _ port.UserService = &UserServiceMock{}
 //
 //		// make and configure a mocked port.UserService
 //		mockedUserService := &UserServiceMock{
-//			CreateUserFunc: func(user *model.NewUser) (*model.User, error) {
+//			ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+//				panic("mock out the ChangePassword method")
+//			},
+//			CreateUserFunc: func(ctx context.Context, actor model.Actor, user *model.NewUser) (*model.User, error) {
 //				panic("mock out the CreateUser method")
 //			},
-//			GetUserByEmailVerificationTokenFunc: func(emailToken string) (*model.User, error) {
+//			DeleteUserFunc: func(ctx context.Context, actor model.Actor, id string, version *int64) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			GetUserByEmailVerificationTokenFunc: func(ctx context.Context, emailToken string) (*model.User, error) {
 //				panic("mock out the GetUserByEmailVerificationToken method")
 //			},
-//			GetUserByIDFunc: func(id string) (*model.User, error) {
+//			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
 //				panic("mock out the GetUserByID method")
 //			},
-//			LoginFunc: func(email string, password string) (*model.User, error) {
+//			LoginFunc: func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
 //				panic("mock out the Login method")
 //			},
-//			SetPasswordFunc: func(user *model.User, password string) error {
+//			ReactivateUserFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+//				panic("mock out the ReactivateUser method")
+//			},
+//			RequestPasswordResetFunc: func(ctx context.Context, actor model.Actor, email string) error {
+//				panic("mock out the RequestPasswordReset method")
+//			},
+//			ResendVerificationEmailFunc: func(ctx context.Context, actor model.Actor, email string) error {
+//				panic("mock out the ResendVerificationEmail method")
+//			},
+//			ResetPasswordFunc: func(ctx context.Context, actor model.Actor, resetToken string, password string) error {
+//				panic("mock out the ResetPassword method")
+//			},
+//			SetPasswordFunc: func(ctx context.Context, actor model.Actor, user *model.User, password string) error {
 //				panic("mock out the SetPassword method")
 //			},
-//			VerifyEmailFunc: func(emailToken string) error {
+//			SuspendUserFunc: func(ctx context.Context, actor model.Actor, userID string) error {
+//				panic("mock out the SuspendUser method")
+//			},
+//			UpdateUserFunc: func(ctx context.Context, actor model.Actor, update *model.UpdateUser) (*model.User, error) {
+//				panic("mock out the UpdateUser method")
+//			},
+//			VerifyEmailFunc: func(ctx context.Context, actor model.Actor, emailToken string) error {
 //				panic("mock out the VerifyEmail method")
 //			},
 //		}
@@ -44,83 +69,269 @@ var _ port.UserService = &UserServiceMock{}
 //
 //	}
 type UserServiceMock struct {
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error
+
 	// CreateUserFunc mocks the CreateUser method.
-	CreateUserFunc func(user *model.NewUser) (*model.User, error)
+	CreateUserFunc func(ctx context.Context, actor model.Actor, user *model.NewUser) (*model.User, error)
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, actor model.Actor, id string, version *int64) error
 
 	// GetUserByEmailVerificationTokenFunc mocks the GetUserByEmailVerificationToken method.
-	GetUserByEmailVerificationTokenFunc func(emailToken string) (*model.User, error)
+	GetUserByEmailVerificationTokenFunc func(ctx context.Context, emailToken string) (*model.User, error)
 
 	// GetUserByIDFunc mocks the GetUserByID method.
-	GetUserByIDFunc func(id string) (*model.User, error)
+	GetUserByIDFunc func(ctx context.Context, id string) (*model.User, error)
 
 	// LoginFunc mocks the Login method.
-	LoginFunc func(email string, password string) (*model.User, error)
+	LoginFunc func(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error)
+
+	// ReactivateUserFunc mocks the ReactivateUser method.
+	ReactivateUserFunc func(ctx context.Context, actor model.Actor, userID string) error
+
+	// RequestPasswordResetFunc mocks the RequestPasswordReset method.
+	RequestPasswordResetFunc func(ctx context.Context, actor model.Actor, email string) error
+
+	// ResendVerificationEmailFunc mocks the ResendVerificationEmail method.
+	ResendVerificationEmailFunc func(ctx context.Context, actor model.Actor, email string) error
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, actor model.Actor, resetToken string, password string) error
 
 	// SetPasswordFunc mocks the SetPassword method.
-	SetPasswordFunc func(user *model.User, password string) error
+	SetPasswordFunc func(ctx context.Context, actor model.Actor, user *model.User, password string) error
+
+	// SuspendUserFunc mocks the SuspendUser method.
+	SuspendUserFunc func(ctx context.Context, actor model.Actor, userID string) error
+
+	// UpdateUserFunc mocks the UpdateUser method.
+	UpdateUserFunc func(ctx context.Context, actor model.Actor, update *model.UpdateUser) (*model.User, error)
 
 	// VerifyEmailFunc mocks the VerifyEmail method.
-	VerifyEmailFunc func(emailToken string) error
+	VerifyEmailFunc func(ctx context.Context, actor model.Actor, emailToken string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+			// CurrentPassword is the currentPassword argument value.
+			CurrentPassword string
+			// NewPassword is the newPassword argument value.
+			NewPassword string
+		}
 		// CreateUser holds details about calls to the CreateUser method.
 		CreateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// User is the user argument value.
 			User *model.NewUser
 		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// ID is the id argument value.
+			ID string
+			// Version is the version argument value.
+			Version *int64
+		}
 		// GetUserByEmailVerificationToken holds details about calls to the GetUserByEmailVerificationToken method.
 		GetUserByEmailVerificationToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// EmailToken is the emailToken argument value.
 			EmailToken string
 		}
 		// GetUserByID holds details about calls to the GetUserByID method.
 		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the id argument value.
 			ID string
 		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Email is the email argument value.
+			Email string
+			// Password is the password argument value.
+			Password string
+			// IP is the ip argument value.
+			IP string
+		}
+		// ReactivateUser holds details about calls to the ReactivateUser method.
+		ReactivateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// RequestPasswordReset holds details about calls to the RequestPasswordReset method.
+		RequestPasswordReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Email is the email argument value.
+			Email string
+		}
+		// ResendVerificationEmail holds details about calls to the ResendVerificationEmail method.
+		ResendVerificationEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// Email is the email argument value.
 			Email string
+		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// ResetToken is the resetToken argument value.
+			ResetToken string
 			// Password is the password argument value.
 			Password string
 		}
 		// SetPassword holds details about calls to the SetPassword method.
 		SetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// User is the user argument value.
 			User *model.User
 			// Password is the password argument value.
 			Password string
 		}
+		// SuspendUser holds details about calls to the SuspendUser method.
+		SuspendUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// UpdateUser holds details about calls to the UpdateUser method.
+		UpdateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
+			// Update is the update argument value.
+			Update *model.UpdateUser
+		}
 		// VerifyEmail holds details about calls to the VerifyEmail method.
 		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Actor is the actor argument value.
+			Actor model.Actor
 			// EmailToken is the emailToken argument value.
 			EmailToken string
 		}
 	}
+	lockChangePassword                  sync.RWMutex
 	lockCreateUser                      sync.RWMutex
+	lockDeleteUser                      sync.RWMutex
 	lockGetUserByEmailVerificationToken sync.RWMutex
 	lockGetUserByID                     sync.RWMutex
 	lockLogin                           sync.RWMutex
+	lockReactivateUser                  sync.RWMutex
+	lockRequestPasswordReset            sync.RWMutex
+	lockResendVerificationEmail         sync.RWMutex
+	lockResetPassword                   sync.RWMutex
 	lockSetPassword                     sync.RWMutex
+	lockSuspendUser                     sync.RWMutex
+	lockUpdateUser                      sync.RWMutex
 	lockVerifyEmail                     sync.RWMutex
 }
 
+// ChangePassword calls ChangePasswordFunc.
+func (mock *UserServiceMock) ChangePassword(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+	if mock.ChangePasswordFunc == nil {
+		panic("UserServiceMock.ChangePasswordFunc: method is nil but UserService.ChangePassword was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		Actor           model.Actor
+		UserID          string
+		CurrentPassword string
+		NewPassword     string
+	}{
+		Ctx:             ctx,
+		Actor:           actor,
+		UserID:          userID,
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	return mock.ChangePasswordFunc(ctx, actor, userID, currentPassword, newPassword)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedUserService.ChangePasswordCalls())
+func (mock *UserServiceMock) ChangePasswordCalls() []struct {
+	Ctx             context.Context
+	Actor           model.Actor
+	UserID          string
+	CurrentPassword string
+	NewPassword     string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Actor           model.Actor
+		UserID          string
+		CurrentPassword string
+		NewPassword     string
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
 // CreateUser calls CreateUserFunc.
-func (mock *UserServiceMock) CreateUser(user *model.NewUser) (*model.User, error) {
+func (mock *UserServiceMock) CreateUser(ctx context.Context, actor model.Actor, user *model.NewUser) (*model.User, error) {
 	if mock.CreateUserFunc == nil {
 		panic("UserServiceMock.CreateUserFunc: method is nil but UserService.CreateUser was just called")
 	}
 	callInfo := struct {
-		User *model.NewUser
+		Ctx   context.Context
+		Actor model.Actor
+		User  *model.NewUser
 	}{
-		User: user,
+		Ctx:   ctx,
+		Actor: actor,
+		User:  user,
 	}
 	mock.lockCreateUser.Lock()
 	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
 	mock.lockCreateUser.Unlock()
-	return mock.CreateUserFunc(user)
+	return mock.CreateUserFunc(ctx, actor, user)
 }
 
 // CreateUserCalls gets all the calls that were made to CreateUser.
@@ -128,10 +339,14 @@ func (mock *UserServiceMock) CreateUser(user *model.NewUser) (*model.User, error
 //
 //	len(mockedUserService.CreateUserCalls())
 func (mock *UserServiceMock) CreateUserCalls() []struct {
-	User *model.NewUser
+	Ctx   context.Context
+	Actor model.Actor
+	User  *model.NewUser
 } {
 	var calls []struct {
-		User *model.NewUser
+		Ctx   context.Context
+		Actor model.Actor
+		User  *model.NewUser
 	}
 	mock.lockCreateUser.RLock()
 	calls = mock.calls.CreateUser
@@ -139,20 +354,66 @@ func (mock *UserServiceMock) CreateUserCalls() []struct {
 	return calls
 }
 
+// DeleteUser calls DeleteUserFunc.
+func (mock *UserServiceMock) DeleteUser(ctx context.Context, actor model.Actor, id string, version *int64) error {
+	if mock.DeleteUserFunc == nil {
+		panic("UserServiceMock.DeleteUserFunc: method is nil but UserService.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Actor   model.Actor
+		ID      string
+		Version *int64
+	}{
+		Ctx:     ctx,
+		Actor:   actor,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, actor, id, version)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedUserService.DeleteUserCalls())
+func (mock *UserServiceMock) DeleteUserCalls() []struct {
+	Ctx     context.Context
+	Actor   model.Actor
+	ID      string
+	Version *int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Actor   model.Actor
+		ID      string
+		Version *int64
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
 // GetUserByEmailVerificationToken calls GetUserByEmailVerificationTokenFunc.
-func (mock *UserServiceMock) GetUserByEmailVerificationToken(emailToken string) (*model.User, error) {
+func (mock *UserServiceMock) GetUserByEmailVerificationToken(ctx context.Context, emailToken string) (*model.User, error) {
 	if mock.GetUserByEmailVerificationTokenFunc == nil {
 		panic("UserServiceMock.GetUserByEmailVerificationTokenFunc: method is nil but UserService.GetUserByEmailVerificationToken was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
 		EmailToken string
 	}{
+		Ctx:        ctx,
 		EmailToken: emailToken,
 	}
 	mock.lockGetUserByEmailVerificationToken.Lock()
 	mock.calls.GetUserByEmailVerificationToken = append(mock.calls.GetUserByEmailVerificationToken, callInfo)
 	mock.lockGetUserByEmailVerificationToken.Unlock()
-	return mock.GetUserByEmailVerificationTokenFunc(emailToken)
+	return mock.GetUserByEmailVerificationTokenFunc(ctx, emailToken)
 }
 
 // GetUserByEmailVerificationTokenCalls gets all the calls that were made to GetUserByEmailVerificationToken.
@@ -160,9 +421,11 @@ func (mock *UserServiceMock) GetUserByEmailVerificationToken(emailToken string)
 //
 //	len(mockedUserService.GetUserByEmailVerificationTokenCalls())
 func (mock *UserServiceMock) GetUserByEmailVerificationTokenCalls() []struct {
+	Ctx        context.Context
 	EmailToken string
 } {
 	var calls []struct {
+		Ctx        context.Context
 		EmailToken string
 	}
 	mock.lockGetUserByEmailVerificationToken.RLock()
@@ -172,19 +435,21 @@ func (mock *UserServiceMock) GetUserByEmailVerificationTokenCalls() []struct {
 }
 
 // GetUserByID calls GetUserByIDFunc.
-func (mock *UserServiceMock) GetUserByID(id string) (*model.User, error) {
+func (mock *UserServiceMock) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	if mock.GetUserByIDFunc == nil {
 		panic("UserServiceMock.GetUserByIDFunc: method is nil but UserService.GetUserByID was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}{
-		ID: id,
+		Ctx: ctx,
+		ID:  id,
 	}
 	mock.lockGetUserByID.Lock()
 	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
 	mock.lockGetUserByID.Unlock()
-	return mock.GetUserByIDFunc(id)
+	return mock.GetUserByIDFunc(ctx, id)
 }
 
 // GetUserByIDCalls gets all the calls that were made to GetUserByID.
@@ -192,10 +457,12 @@ func (mock *UserServiceMock) GetUserByID(id string) (*model.User, error) {
 //
 //	len(mockedUserService.GetUserByIDCalls())
 func (mock *UserServiceMock) GetUserByIDCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	mock.lockGetUserByID.RLock()
 	calls = mock.calls.GetUserByID
@@ -204,21 +471,27 @@ func (mock *UserServiceMock) GetUserByIDCalls() []struct {
 }
 
 // Login calls LoginFunc.
-func (mock *UserServiceMock) Login(email string, password string) (*model.User, error) {
+func (mock *UserServiceMock) Login(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
 	if mock.LoginFunc == nil {
 		panic("UserServiceMock.LoginFunc: method is nil but UserService.Login was just called")
 	}
 	callInfo := struct {
+		Ctx      context.Context
+		Actor    model.Actor
 		Email    string
 		Password string
+		IP       string
 	}{
+		Ctx:      ctx,
+		Actor:    actor,
 		Email:    email,
 		Password: password,
+		IP:       ip,
 	}
 	mock.lockLogin.Lock()
 	mock.calls.Login = append(mock.calls.Login, callInfo)
 	mock.lockLogin.Unlock()
-	return mock.LoginFunc(email, password)
+	return mock.LoginFunc(ctx, actor, email, password, ip)
 }
 
 // LoginCalls gets all the calls that were made to Login.
@@ -226,12 +499,18 @@ func (mock *UserServiceMock) Login(email string, password string) (*model.User,
 //
 //	len(mockedUserService.LoginCalls())
 func (mock *UserServiceMock) LoginCalls() []struct {
+	Ctx      context.Context
+	Actor    model.Actor
 	Email    string
 	Password string
+	IP       string
 } {
 	var calls []struct {
+		Ctx      context.Context
+		Actor    model.Actor
 		Email    string
 		Password string
+		IP       string
 	}
 	mock.lockLogin.RLock()
 	calls = mock.calls.Login
@@ -239,22 +518,190 @@ func (mock *UserServiceMock) LoginCalls() []struct {
 	return calls
 }
 
+// ReactivateUser calls ReactivateUserFunc.
+func (mock *UserServiceMock) ReactivateUser(ctx context.Context, actor model.Actor, userID string) error {
+	if mock.ReactivateUserFunc == nil {
+		panic("UserServiceMock.ReactivateUserFunc: method is nil but UserService.ReactivateUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+	}
+	mock.lockReactivateUser.Lock()
+	mock.calls.ReactivateUser = append(mock.calls.ReactivateUser, callInfo)
+	mock.lockReactivateUser.Unlock()
+	return mock.ReactivateUserFunc(ctx, actor, userID)
+}
+
+// ReactivateUserCalls gets all the calls that were made to ReactivateUser.
+// Check the length with:
+//
+//	len(mockedUserService.ReactivateUserCalls())
+func (mock *UserServiceMock) ReactivateUserCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}
+	mock.lockReactivateUser.RLock()
+	calls = mock.calls.ReactivateUser
+	mock.lockReactivateUser.RUnlock()
+	return calls
+}
+
+// RequestPasswordReset calls RequestPasswordResetFunc.
+func (mock *UserServiceMock) RequestPasswordReset(ctx context.Context, actor model.Actor, email string) error {
+	if mock.RequestPasswordResetFunc == nil {
+		panic("UserServiceMock.RequestPasswordResetFunc: method is nil but UserService.RequestPasswordReset was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Actor model.Actor
+		Email string
+	}{
+		Ctx:   ctx,
+		Actor: actor,
+		Email: email,
+	}
+	mock.lockRequestPasswordReset.Lock()
+	mock.calls.RequestPasswordReset = append(mock.calls.RequestPasswordReset, callInfo)
+	mock.lockRequestPasswordReset.Unlock()
+	return mock.RequestPasswordResetFunc(ctx, actor, email)
+}
+
+// RequestPasswordResetCalls gets all the calls that were made to RequestPasswordReset.
+// Check the length with:
+//
+//	len(mockedUserService.RequestPasswordResetCalls())
+func (mock *UserServiceMock) RequestPasswordResetCalls() []struct {
+	Ctx   context.Context
+	Actor model.Actor
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Actor model.Actor
+		Email string
+	}
+	mock.lockRequestPasswordReset.RLock()
+	calls = mock.calls.RequestPasswordReset
+	mock.lockRequestPasswordReset.RUnlock()
+	return calls
+}
+
+// ResendVerificationEmail calls ResendVerificationEmailFunc.
+func (mock *UserServiceMock) ResendVerificationEmail(ctx context.Context, actor model.Actor, email string) error {
+	if mock.ResendVerificationEmailFunc == nil {
+		panic("UserServiceMock.ResendVerificationEmailFunc: method is nil but UserService.ResendVerificationEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Actor model.Actor
+		Email string
+	}{
+		Ctx:   ctx,
+		Actor: actor,
+		Email: email,
+	}
+	mock.lockResendVerificationEmail.Lock()
+	mock.calls.ResendVerificationEmail = append(mock.calls.ResendVerificationEmail, callInfo)
+	mock.lockResendVerificationEmail.Unlock()
+	return mock.ResendVerificationEmailFunc(ctx, actor, email)
+}
+
+// ResendVerificationEmailCalls gets all the calls that were made to ResendVerificationEmail.
+// Check the length with:
+//
+//	len(mockedUserService.ResendVerificationEmailCalls())
+func (mock *UserServiceMock) ResendVerificationEmailCalls() []struct {
+	Ctx   context.Context
+	Actor model.Actor
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Actor model.Actor
+		Email string
+	}
+	mock.lockResendVerificationEmail.RLock()
+	calls = mock.calls.ResendVerificationEmail
+	mock.lockResendVerificationEmail.RUnlock()
+	return calls
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *UserServiceMock) ResetPassword(ctx context.Context, actor model.Actor, resetToken string, password string) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("UserServiceMock.ResetPasswordFunc: method is nil but UserService.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
+		ResetToken string
+		Password   string
+	}{
+		Ctx:        ctx,
+		Actor:      actor,
+		ResetToken: resetToken,
+		Password:   password,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx, actor, resetToken, password)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedUserService.ResetPasswordCalls())
+func (mock *UserServiceMock) ResetPasswordCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
+	ResetToken string
+	Password   string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
+		ResetToken string
+		Password   string
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
+
 // SetPassword calls SetPasswordFunc.
-func (mock *UserServiceMock) SetPassword(user *model.User, password string) error {
+func (mock *UserServiceMock) SetPassword(ctx context.Context, actor model.Actor, user *model.User, password string) error {
 	if mock.SetPasswordFunc == nil {
 		panic("UserServiceMock.SetPasswordFunc: method is nil but UserService.SetPassword was just called")
 	}
 	callInfo := struct {
+		Ctx      context.Context
+		Actor    model.Actor
 		User     *model.User
 		Password string
 	}{
+		Ctx:      ctx,
+		Actor:    actor,
 		User:     user,
 		Password: password,
 	}
 	mock.lockSetPassword.Lock()
 	mock.calls.SetPassword = append(mock.calls.SetPassword, callInfo)
 	mock.lockSetPassword.Unlock()
-	return mock.SetPasswordFunc(user, password)
+	return mock.SetPasswordFunc(ctx, actor, user, password)
 }
 
 // SetPasswordCalls gets all the calls that were made to SetPassword.
@@ -262,10 +709,14 @@ func (mock *UserServiceMock) SetPassword(user *model.User, password string) erro
 //
 //	len(mockedUserService.SetPasswordCalls())
 func (mock *UserServiceMock) SetPasswordCalls() []struct {
+	Ctx      context.Context
+	Actor    model.Actor
 	User     *model.User
 	Password string
 } {
 	var calls []struct {
+		Ctx      context.Context
+		Actor    model.Actor
 		User     *model.User
 		Password string
 	}
@@ -275,20 +726,104 @@ func (mock *UserServiceMock) SetPasswordCalls() []struct {
 	return calls
 }
 
+// SuspendUser calls SuspendUserFunc.
+func (mock *UserServiceMock) SuspendUser(ctx context.Context, actor model.Actor, userID string) error {
+	if mock.SuspendUserFunc == nil {
+		panic("UserServiceMock.SuspendUserFunc: method is nil but UserService.SuspendUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		UserID: userID,
+	}
+	mock.lockSuspendUser.Lock()
+	mock.calls.SuspendUser = append(mock.calls.SuspendUser, callInfo)
+	mock.lockSuspendUser.Unlock()
+	return mock.SuspendUserFunc(ctx, actor, userID)
+}
+
+// SuspendUserCalls gets all the calls that were made to SuspendUser.
+// Check the length with:
+//
+//	len(mockedUserService.SuspendUserCalls())
+func (mock *UserServiceMock) SuspendUserCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		UserID string
+	}
+	mock.lockSuspendUser.RLock()
+	calls = mock.calls.SuspendUser
+	mock.lockSuspendUser.RUnlock()
+	return calls
+}
+
+// UpdateUser calls UpdateUserFunc.
+func (mock *UserServiceMock) UpdateUser(ctx context.Context, actor model.Actor, update *model.UpdateUser) (*model.User, error) {
+	if mock.UpdateUserFunc == nil {
+		panic("UserServiceMock.UpdateUserFunc: method is nil but UserService.UpdateUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Update *model.UpdateUser
+	}{
+		Ctx:    ctx,
+		Actor:  actor,
+		Update: update,
+	}
+	mock.lockUpdateUser.Lock()
+	mock.calls.UpdateUser = append(mock.calls.UpdateUser, callInfo)
+	mock.lockUpdateUser.Unlock()
+	return mock.UpdateUserFunc(ctx, actor, update)
+}
+
+// UpdateUserCalls gets all the calls that were made to UpdateUser.
+// Check the length with:
+//
+//	len(mockedUserService.UpdateUserCalls())
+func (mock *UserServiceMock) UpdateUserCalls() []struct {
+	Ctx    context.Context
+	Actor  model.Actor
+	Update *model.UpdateUser
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Actor  model.Actor
+		Update *model.UpdateUser
+	}
+	mock.lockUpdateUser.RLock()
+	calls = mock.calls.UpdateUser
+	mock.lockUpdateUser.RUnlock()
+	return calls
+}
+
 // VerifyEmail calls VerifyEmailFunc.
-func (mock *UserServiceMock) VerifyEmail(emailToken string) error {
+func (mock *UserServiceMock) VerifyEmail(ctx context.Context, actor model.Actor, emailToken string) error {
 	if mock.VerifyEmailFunc == nil {
 		panic("UserServiceMock.VerifyEmailFunc: method is nil but UserService.VerifyEmail was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		EmailToken string
 	}{
+		Ctx:        ctx,
+		Actor:      actor,
 		EmailToken: emailToken,
 	}
 	mock.lockVerifyEmail.Lock()
 	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
 	mock.lockVerifyEmail.Unlock()
-	return mock.VerifyEmailFunc(emailToken)
+	return mock.VerifyEmailFunc(ctx, actor, emailToken)
 }
 
 // VerifyEmailCalls gets all the calls that were made to VerifyEmail.
@@ -296,9 +831,13 @@ func (mock *UserServiceMock) VerifyEmail(emailToken string) error {
 //
 //	len(mockedUserService.VerifyEmailCalls())
 func (mock *UserServiceMock) VerifyEmailCalls() []struct {
+	Ctx        context.Context
+	Actor      model.Actor
 	EmailToken string
 } {
 	var calls []struct {
+		Ctx        context.Context
+		Actor      model.Actor
 		EmailToken string
 	}
 	mock.lockVerifyEmail.RLock()
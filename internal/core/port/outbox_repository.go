@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/outbox_repository.go . OutboxRepository
+
+type OutboxRepository interface {
+	// ClaimEvents leases up to limit unpublished events that are due, so that
+	// other dispatchers skip them until the lease expires.
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error)
+	MarkPublished(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, cause error, nextAttemptAt time.Time) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/publisher.go . Publisher
+
+type Publisher interface {
+	Publish(event model.Event) error
+}
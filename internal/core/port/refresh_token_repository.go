@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/refresh_token_repository.go . RefreshTokenRepository
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	GetRefreshToken(ctx context.Context, id string) (*model.RefreshToken, error)
+	// UseRefreshToken marks an unused, unrevoked token as used, reporting
+	// whether this call was the one to do so.
+	UseRefreshToken(ctx context.Context, id string) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string) error
+}
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/token_store.go . TokenStore
+
+// TokenStore records revoked token IDs (the jti claim) until the tokens
+// would have expired anyway, and a generation per user that every token
+// issued to them carries. RevokeUser moves the user on to a new generation,
+// revoking every token issued to them so far.
+type TokenStore interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+	RevokeUser(ctx context.Context, userID string) error
+	// UserGeneration is zero for a user who has never been revoked
+	UserGeneration(ctx context.Context, userID string) (int64, error)
+}
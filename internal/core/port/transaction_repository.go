@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+//go:generate moq -pkg mocks -out ./mocks/transaction_repository.go . TransactionRepository
+
+type TransactionRepository interface {
+	CreateTransaction(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error)
+	CreateTransfer(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error)
+	ListTransactions(ctx context.Context, accountNumber string) ([]model.Transaction, error)
+	GetTransaction(ctx context.Context, accountNumber string, transactionID string) (*model.Transaction, error)
+}
@@ -1,21 +1,36 @@
 package port
 
 import (
+	"context"
+	"time"
+
 	"eagle-bank.com/internal/adapter/storage/postgres/repository/entity"
 	"eagle-bank.com/internal/core/domain/model"
 )
 
 //go:generate moq -pkg mocks -out ./mocks/user_repository.go . UserRepository
 
+// UserRepository mutations are recorded in the audit log as made by actor.
 type UserRepository interface {
-	CreateUser(newUser *model.NewUser) (*model.User, error)
-	UpdateUser(user *model.User) (*model.User, error)
-	GetUserByID(id string) (*model.User, error)
-	GetUserByEmail(email string) (*entity.UserDAO, error)
-	GetUserByEmailVerificationToken(emailToken string) (*model.User, error)
-	VerifyEmail(emailToken string) error
-	SetPassword(user *model.User, hash []byte) error
-	Login(email string, password string) (string, error)
-	//Update(user *model.User) error
-	//Delete(id string) error
+	CreateUser(ctx context.Context, actor model.Actor, newUser *model.NewUser) (*model.User, error)
+	UpdateUser(ctx context.Context, actor model.Actor, user *model.User) (*model.User, error)
+	GetUserByID(ctx context.Context, id string) (*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*entity.UserDAO, error)
+	GetUserByEmailVerificationToken(ctx context.Context, emailToken string) (*model.User, error)
+	VerifyEmail(ctx context.Context, actor model.Actor, emailToken string) error
+	ReplaceVerificationToken(ctx context.Context, actor model.Actor, email string, emailToken string, notIssuedSince time.Time) (*model.User, error)
+	ReplacePasswordResetToken(ctx context.Context, actor model.Actor, email string, resetToken string, notIssuedSince time.Time) (*model.User, error)
+	ResetPassword(ctx context.Context, actor model.Actor, resetToken string, hash []byte, loginKey string) error
+	GetUserByPasswordResetToken(ctx context.Context, resetToken string) (*model.User, error)
+	GetPasswordHashes(ctx context.Context, userID string, limit int) ([]string, error)
+	ChangePassword(ctx context.Context, actor model.Actor, userID string, hash []byte) error
+	SetPassword(ctx context.Context, actor model.Actor, user *model.User, hash []byte) error
+	ChangeUserStatus(ctx context.Context, actor model.Actor, userID string, status string) error
+	ForcePasswordReset(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error)
+	SearchUsers(ctx context.Context, search model.UserSearch) ([]model.User, error)
+	Login(ctx context.Context, email string, password string) (string, error)
+	LockUser(ctx context.Context, actor model.Actor, email string, until time.Time) error
+	GetUserLockoutByEmail(ctx context.Context, email string) (*model.UserLockout, error)
+	UnlockUser(ctx context.Context, actor model.Actor, userID string) error
+	DeleteUser(ctx context.Context, actor model.Actor, id string, version *int64) error
 }
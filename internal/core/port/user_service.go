@@ -1,16 +1,25 @@
 package port
 
 import (
+	"context"
 	"eagle-bank.com/internal/core/domain/model"
 )
 
 //go:generate moq -pkg mocks -out ./mocks/user_service.go . UserService
 
 type UserService interface {
-	CreateUser(user *model.NewUser) (*model.User, error)
-	GetUserByID(id string) (*model.User, error)
-	GetUserByEmailVerificationToken(emailToken string) (*model.User, error)
-	VerifyEmail(emailToken string) error
-	SetPassword(user *model.User, password string) error
-	Login(email string, password string) (*model.User, error)
+	CreateUser(ctx context.Context, actor model.Actor, user *model.NewUser) (*model.User, error)
+	GetUserByID(ctx context.Context, id string) (*model.User, error)
+	UpdateUser(ctx context.Context, actor model.Actor, update *model.UpdateUser) (*model.User, error)
+	DeleteUser(ctx context.Context, actor model.Actor, id string, version *int64) error
+	GetUserByEmailVerificationToken(ctx context.Context, emailToken string) (*model.User, error)
+	VerifyEmail(ctx context.Context, actor model.Actor, emailToken string) error
+	ResendVerificationEmail(ctx context.Context, actor model.Actor, email string) error
+	SetPassword(ctx context.Context, actor model.Actor, user *model.User, password string) error
+	ChangePassword(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error
+	RequestPasswordReset(ctx context.Context, actor model.Actor, email string) error
+	ResetPassword(ctx context.Context, actor model.Actor, resetToken string, password string) error
+	Login(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error)
+	SuspendUser(ctx context.Context, actor model.Actor, userID string) error
+	ReactivateUser(ctx context.Context, actor model.Actor, userID string) error
 }
@@ -1,30 +1,86 @@
 package service
 
 import (
-	"fmt"
-	"math/rand/v2"
+	"context"
+	"strings"
 
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
+	"github.com/pkg/errors"
 )
 
 func NewAccountService(
-	repo port.AccountRepository) *AccountService {
+	repo port.AccountRepository,
+	accountNumbers port.AccountNumberAllocator) *AccountService {
 	return &AccountService{
-		repo: repo,
+		repo:           repo,
+		accountNumbers: accountNumbers,
 	}
 }
 
 type AccountService struct {
-	repo port.AccountRepository
+	repo           port.AccountRepository
+	accountNumbers port.AccountNumberAllocator
 }
 
-func (s AccountService) CreateAccount(newAccount *model.NewAccount) (*model.UserAccount, error) {
-	newAccount.AccountNumber = GenerateAccountNumber()
-	return s.repo.CreateAccount(newAccount)
+func (s AccountService) CreateAccount(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
+	accountNumber, err := s.accountNumbers.AllocateAccountNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newAccount.AccountNumber = accountNumber
+	return s.repo.CreateAccount(ctx, actor, newAccount)
+}
+
+func (s AccountService) ListAccounts(ctx context.Context, userID string) ([]model.Account, error) {
+	return s.repo.ListAccountsByUserID(ctx, userID)
+}
+
+// GetAccount returns the account if it exists and is owned by the given user
+func (s AccountService) GetAccount(ctx context.Context, accountNumber string, userID string) (*model.Account, error) {
+	account, err := s.repo.GetAccount(ctx, accountNumber)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, model.ErrForbidden
+	}
+	return account, nil
 }
 
-// GenerateAccountNumber returns an 8-digit account number as a string
-func GenerateAccountNumber() string {
-	return fmt.Sprintf("%08d", rand.IntN(100_000_000))
+func (s AccountService) UpdateAccount(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+	if update == nil {
+		return nil, errors.New("account update cannot be nil")
+	}
+	if err := ValidateUpdateAccount(update); err != nil {
+		return nil, err
+	}
+	account, err := s.GetAccount(ctx, update.AccountNumber, update.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if update.Version != nil && *update.Version != account.Version {
+		return nil, model.ErrVersionMismatch
+	}
+	return s.repo.UpdateAccount(ctx, actor, update)
+}
+
+func (s AccountService) CloseAccount(ctx context.Context, actor model.Actor, accountNumber string, userID string, version *int64) error {
+	if _, err := s.GetAccount(ctx, accountNumber, userID); err != nil {
+		return err
+	}
+	return s.repo.CloseAccount(ctx, actor, accountNumber, version)
+}
+
+func ValidateUpdateAccount(u *model.UpdateAccount) error {
+	if u.Name == nil && u.Type == nil {
+		return errors.Wrap(model.ErrInvalidAccount, "name or accountType must be supplied")
+	}
+	if u.Name != nil && strings.TrimSpace(*u.Name) == "" {
+		return errors.Wrap(model.ErrInvalidAccount, "name cannot be empty")
+	}
+	if u.Type != nil && *u.Type != model.AccountPersonalType && *u.Type != model.AccountBusinessType {
+		return errors.Wrap(model.ErrInvalidAccount, "accountType must be personal or business")
+	}
+	return nil
 }
@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountService_CreateAccount(t *testing.T) {
+
+	userID := uuid.NewString()
+	allocator := &mocks.AccountNumberAllocatorMock{
+		AllocateAccountNumberFunc: func(ctx context.Context) (string, error) {
+			return "01123454", nil
+		},
+	}
+
+	t.Run("account is opened with the allocated number", func(t *testing.T) {
+		repo := &mocks.AccountRepositoryMock{
+			CreateAccountFunc: func(ctx context.Context, actor model.Actor, newAccount *model.NewAccount) (*model.UserAccount, error) {
+				return &model.UserAccount{UserID: newAccount.UserID, AccountNumber: newAccount.AccountNumber}, nil
+			},
+		}
+		account, err := service.NewAccountService(repo, allocator).CreateAccount(context.Background(), model.UserActor(userID, ""), &model.NewAccount{
+			UserID: userID,
+			Name:   "Holiday fund",
+			Type:   model.AccountPersonalType,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "01123454", account.AccountNumber)
+	})
+
+	t.Run("no account is opened when allocation fails", func(t *testing.T) {
+		repo := &mocks.AccountRepositoryMock{}
+		exhausted := &mocks.AccountNumberAllocatorMock{
+			AllocateAccountNumberFunc: func(ctx context.Context) (string, error) {
+				return "", model.ErrAccountNumbersExhausted
+			},
+		}
+		_, err := service.NewAccountService(repo, exhausted).CreateAccount(context.Background(), model.UserActor(userID, ""), &model.NewAccount{
+			UserID: userID,
+			Name:   "Holiday fund",
+			Type:   model.AccountPersonalType,
+		})
+		require.ErrorIs(t, err, model.ErrAccountNumbersExhausted)
+		assert.Empty(t, repo.CreateAccountCalls())
+	})
+}
+
+func TestAccountService_UpdateAccount(t *testing.T) {
+
+	userID := uuid.NewString()
+	newRepo := func() *mocks.AccountRepositoryMock {
+		return &mocks.AccountRepositoryMock{
+			GetAccountFunc: func(ctx context.Context, accountNumber string) (*model.Account, error) {
+				// closed accounts are not found
+				if accountNumber == "01000009" {
+					return nil, model.ErrAccountNotFound
+				}
+				return &model.Account{AccountNumber: accountNumber, UserID: userID, Version: 2}, nil
+			},
+			UpdateAccountFunc: func(ctx context.Context, actor model.Actor, update *model.UpdateAccount) (*model.Account, error) {
+				return &model.Account{AccountNumber: update.AccountNumber, UserID: userID, Version: 3}, nil
+			},
+		}
+	}
+	name := "Holiday fund"
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		repo := newRepo()
+		stale := int64(1)
+		_, err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).UpdateAccount(context.Background(), model.UserActor(userID, ""), &model.UpdateAccount{
+			AccountNumber: "01234567",
+			UserID:        userID,
+			Name:          &name,
+			Version:       &stale,
+		})
+		require.ErrorIs(t, err, model.ErrVersionMismatch)
+		assert.Empty(t, repo.UpdateAccountCalls())
+	})
+
+	t.Run("current version is passed on to the repository", func(t *testing.T) {
+		repo := newRepo()
+		current := int64(2)
+		account, err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).UpdateAccount(context.Background(), model.UserActor(userID, ""), &model.UpdateAccount{
+			AccountNumber: "01234567",
+			UserID:        userID,
+			Name:          &name,
+			Version:       &current,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), account.Version)
+		calls := repo.UpdateAccountCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, &current, calls[0].Update.Version)
+	})
+
+	t.Run("account owned by another user is not updated", func(t *testing.T) {
+		repo := newRepo()
+		otherUserID := uuid.NewString()
+		_, err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).UpdateAccount(context.Background(), model.UserActor(otherUserID, ""), &model.UpdateAccount{
+			AccountNumber: "01234567",
+			UserID:        otherUserID,
+			Name:          &name,
+		})
+		require.ErrorIs(t, err, model.ErrForbidden)
+		assert.Empty(t, repo.UpdateAccountCalls())
+	})
+
+	t.Run("closed account is not updated", func(t *testing.T) {
+		repo := newRepo()
+		_, err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).UpdateAccount(context.Background(), model.UserActor(userID, ""), &model.UpdateAccount{
+			AccountNumber: "01000009",
+			UserID:        userID,
+			Name:          &name,
+		})
+		require.ErrorIs(t, err, model.ErrAccountNotFound)
+		assert.Empty(t, repo.UpdateAccountCalls())
+	})
+}
+
+func TestAccountService_CloseAccount(t *testing.T) {
+
+	userID := uuid.NewString()
+	newRepo := func(closeErr error) *mocks.AccountRepositoryMock {
+		return &mocks.AccountRepositoryMock{
+			GetAccountFunc: func(ctx context.Context, accountNumber string) (*model.Account, error) {
+				// closed accounts are not found
+				if accountNumber == "01000009" {
+					return nil, model.ErrAccountNotFound
+				}
+				return &model.Account{AccountNumber: accountNumber, UserID: userID, Version: 2}, nil
+			},
+			CloseAccountFunc: func(ctx context.Context, actor model.Actor, accountNumber string, version *int64) error {
+				return closeErr
+			},
+		}
+	}
+
+	t.Run("owner's empty account is closed at the given version", func(t *testing.T) {
+		repo := newRepo(nil)
+		version := int64(2)
+		err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).CloseAccount(context.Background(), model.UserActor(userID, ""), "01234567", userID, &version)
+		require.NoError(t, err)
+		calls := repo.CloseAccountCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, "01234567", calls[0].AccountNumber)
+		assert.Equal(t, &version, calls[0].Version)
+	})
+
+	t.Run("account owned by another user is not closed", func(t *testing.T) {
+		repo := newRepo(nil)
+		otherUserID := uuid.NewString()
+		err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).CloseAccount(context.Background(), model.UserActor(otherUserID, ""), "01234567", otherUserID, nil)
+		require.ErrorIs(t, err, model.ErrForbidden)
+		assert.Empty(t, repo.CloseAccountCalls())
+	})
+
+	t.Run("account already closed is not found", func(t *testing.T) {
+		repo := newRepo(nil)
+		err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).CloseAccount(context.Background(), model.UserActor(userID, ""), "01000009", userID, nil)
+		require.ErrorIs(t, err, model.ErrAccountNotFound)
+		assert.Empty(t, repo.CloseAccountCalls())
+	})
+
+	t.Run("account with a balance is refused", func(t *testing.T) {
+		repo := newRepo(model.ErrAccountNotEmpty)
+		err := service.NewAccountService(repo, &mocks.AccountNumberAllocatorMock{}).CloseAccount(context.Background(), model.UserActor(userID, ""), "01234567", userID, nil)
+		require.ErrorIs(t, err, model.ErrAccountNotEmpty)
+	})
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultUserSearchLimit applies when a search does not set a limit
+	defaultUserSearchLimit = 50
+	// maxUserSearchLimit caps how many users one search returns
+	maxUserSearchLimit = 200
+	// defaultAuditRecordLimit applies when an audit query does not set a limit
+	defaultAuditRecordLimit = 100
+	// maxAuditRecordLimit caps how many audit records one query returns
+	maxAuditRecordLimit = 1000
+)
+
+func NewAdminService(
+	repo port.AdminRepository,
+	userRepo port.UserRepository,
+	accountRepo port.AccountRepository,
+	transactionRepo port.TransactionRepository,
+	auditRepo port.AuditRepository,
+	loginAttempts port.LoginAttemptRepository,
+	mailer port.Mailer,
+	emailConfig EmailConfig,
+	loginProtection LoginProtectionConfig) *AdminService {
+	return &AdminService{
+		repo:            repo,
+		userRepo:        userRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		auditRepo:       auditRepo,
+		loginAttempts:   loginAttempts,
+		mailer:          mailer,
+		emailConfig:     emailConfig,
+		loginProtection: loginProtection,
+	}
+}
+
+// AdminService carries out operations staff requests. Every request that
+// reads or changes customer data is recorded in the audit log.
+type AdminService struct {
+	repo            port.AdminRepository
+	userRepo        port.UserRepository
+	accountRepo     port.AccountRepository
+	transactionRepo port.TransactionRepository
+	auditRepo       port.AuditRepository
+	loginAttempts   port.LoginAttemptRepository
+	mailer          port.Mailer
+	emailConfig     EmailConfig
+	loginProtection LoginProtectionConfig
+}
+
+// Login checks an administrator's credentials. Repeated failures are delayed
+// in the same way as customer logins, but never lock the administrator out.
+func (s AdminService) Login(ctx context.Context, email string, password string, ip string) (*model.Admin, error) {
+	now := time.Now().UTC()
+	since := now.Add(-s.loginProtection.FailureWindow)
+	key := loginKey(email)
+
+	for _, limit := range []struct {
+		scope        string
+		key          string
+		freeAttempts int
+	}{
+		{model.LoginScopeAdmin, key, s.loginProtection.EmailFreeAttempts},
+		{model.LoginScopeIP, ip, s.loginProtection.IPFreeAttempts},
+	} {
+		failed, err := s.loginAttempts.GetFailedLogins(ctx, limit.scope, limit.key, since)
+		if err != nil {
+			return nil, err
+		}
+		if wait := s.loginProtection.loginDelay(failed, limit.freeAttempts, now); wait > 0 {
+			return nil, &model.RetryAfterError{Err: model.ErrLoginThrottled, RetryAfter: wait}
+		}
+	}
+
+	adminID, err := s.repo.Login(ctx, email, password)
+	if errors.Is(err, model.ErrInvalidCredentials) {
+		for _, failure := range []struct{ scope, key string }{
+			{model.LoginScopeIP, ip},
+			{model.LoginScopeAdmin, key},
+		} {
+			if _, recordErr := s.loginAttempts.RecordFailedLogin(ctx, failure.scope, failure.key, since); recordErr != nil {
+				return nil, recordErr
+			}
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loginAttempts.ResetFailedLogins(ctx, model.LoginScopeAdmin, key); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAdminByID(ctx, adminID)
+}
+
+func (s AdminService) GetAdmin(ctx context.Context, adminID string) (*model.Admin, error) {
+	return s.repo.GetAdminByID(ctx, adminID)
+}
+
+func (s AdminService) SearchUsers(ctx context.Context, actor model.Actor, search model.UserSearch) ([]model.User, error) {
+	if search.Status != "" && !model.IsUserStatus(search.Status) {
+		return nil, errors.Wrap(model.ErrInvalidUser, "unknown status")
+	}
+	if search.Limit <= 0 {
+		search.Limit = defaultUserSearchLimit
+	}
+	search.Limit = min(search.Limit, maxUserSearchLimit)
+	search.Offset = max(search.Offset, 0)
+
+	criteria, _ := json.Marshal(map[string]interface{}{
+		"query":  search.Query,
+		"status": search.Status,
+		"limit":  search.Limit,
+		"offset": search.Offset,
+	})
+	err := s.auditRead(ctx, actor, model.AuditActionUsersSearched, model.AuditEntityUser, "", criteria)
+	if err != nil {
+		return nil, err
+	}
+	return s.userRepo.SearchUsers(ctx, search)
+}
+
+func (s AdminService) ListUserAccounts(ctx context.Context, actor model.Actor, userID string) ([]model.Account, error) {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.auditRead(ctx, actor, model.AuditActionAccountsViewed, model.AuditEntityUser, userID, nil); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.ListAccountsByUserID(ctx, userID)
+}
+
+func (s AdminService) ListAccountTransactions(ctx context.Context, actor model.Actor, accountNumber string) ([]model.Transaction, error) {
+	if _, err := s.accountRepo.GetAccount(ctx, accountNumber); err != nil {
+		return nil, err
+	}
+	if err := s.auditRead(ctx, actor, model.AuditActionLedgerViewed, model.AuditEntityAccount, accountNumber, nil); err != nil {
+		return nil, err
+	}
+	return s.transactionRepo.ListTransactions(ctx, accountNumber)
+}
+
+// FreezeAccount stops money moving in or out of an account until it is
+// unfrozen. The customer can still see the account.
+func (s AdminService) FreezeAccount(ctx context.Context, actor model.Actor, accountNumber string) error {
+	return s.accountRepo.SetAccountStatus(ctx, actor, accountNumber, model.AccountFrozenStatus)
+}
+
+func (s AdminService) UnfreezeAccount(ctx context.Context, actor model.Actor, accountNumber string) error {
+	return s.accountRepo.SetAccountStatus(ctx, actor, accountNumber, model.AccountOpenStatus)
+}
+
+// ForcePasswordReset requires the user to reset their password and emails them
+// a reset link. The reset is committed before the email is sent, so a failed
+// send returns ErrResetEmailNotSent rather than failing the whole request.
+func (s AdminService) ForcePasswordReset(ctx context.Context, actor model.Actor, userID string) error {
+	resetToken := uuid.NewString()
+	user, err := s.userRepo.ForcePasswordReset(ctx, actor, userID, resetToken)
+	if err != nil {
+		return err
+	}
+
+	message, err := newPasswordResetEmail(s.emailConfig, user.Name, user.Email, resetToken)
+	if err == nil {
+		err = s.mailer.Send(message)
+	}
+	if err != nil {
+		return errors.WithMessage(model.ErrResetEmailNotSent, err.Error())
+	}
+	return nil
+}
+
+// ListAuditRecords returns audit records for compliance review, oldest first.
+// Reviewing the audit log is itself recorded.
+func (s AdminService) ListAuditRecords(ctx context.Context, actor model.Actor, filter model.AuditFilter) ([]model.AuditRecord, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, errors.Wrap(model.ErrInvalidAuditFilter, "from must be before to")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditRecordLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditRecordLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	criteria, _ := json.Marshal(filter)
+	err := s.auditRead(ctx, actor, model.AuditActionAuditLogViewed, model.AuditEntityAuditLog, "", criteria)
+	if err != nil {
+		return nil, err
+	}
+	return s.auditRepo.ListAuditRecords(ctx, filter)
+}
+
+// auditRead records an administrator viewing customer data before it is
+// returned, so that nothing is shown without a record.
+func (s AdminService) auditRead(ctx context.Context, actor model.Actor, action string, entityType string, entityID string, detail json.RawMessage) error {
+	var requestID *string
+	if actor.RequestID != "" {
+		requestID = &actor.RequestID
+	}
+	return s.auditRepo.RecordAudit(ctx, model.AuditRecord{
+		ActorID:    actor.ID,
+		ActorType:  actor.Type,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		After:      detail,
+		RequestID:  requestID,
+	})
+}
@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminService_Login(t *testing.T) {
+
+	const ip = "203.0.113.7"
+	adminID := uuid.NewString()
+	email := "ops@eagle-bank.com"
+
+	t.Run("failures are counted apart from customer logins", func(t *testing.T) {
+		failures := map[string]int{}
+		repo := &mocks.AdminRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return "", model.ErrInvalidCredentials
+			},
+		}
+		adminService := service.NewAdminService(repo, nil, nil, nil, nil, newLoginAttemptRepository(failures), nil, service.EmailConfig{}, testLoginProtection)
+
+		_, err := adminService.Login(context.Background(), "Ops@Eagle-Bank.com", "wrong", ip)
+		require.ErrorIs(t, err, model.ErrInvalidCredentials)
+		assert.Equal(t, map[string]int{"admin:" + email: 1, "ip:" + ip: 1}, failures)
+	})
+
+	t.Run("repeated failures are delayed", func(t *testing.T) {
+		repo := &mocks.AdminRepositoryMock{}
+		attempts := newLoginAttemptRepository(map[string]int{"admin:" + email: 5})
+		adminService := service.NewAdminService(repo, nil, nil, nil, nil, attempts, nil, service.EmailConfig{}, testLoginProtection)
+
+		_, err := adminService.Login(context.Background(), email, "Admin-passw0rd", ip)
+		require.ErrorIs(t, err, model.ErrLoginThrottled)
+		assert.Empty(t, repo.LoginCalls())
+	})
+
+	t.Run("success resets the failure count", func(t *testing.T) {
+		failures := map[string]int{"admin:" + email: 2}
+		repo := &mocks.AdminRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return adminID, nil
+			},
+			GetAdminByIDFunc: func(ctx context.Context, id string) (*model.Admin, error) {
+				return &model.Admin{ID: id, Email: email}, nil
+			},
+		}
+		adminService := service.NewAdminService(repo, nil, nil, nil, nil, newLoginAttemptRepository(failures), nil, service.EmailConfig{}, testLoginProtection)
+
+		admin, err := adminService.Login(context.Background(), email, "Admin-passw0rd", ip)
+		require.NoError(t, err)
+		assert.Equal(t, adminID, admin.ID)
+		assert.Empty(t, failures)
+	})
+}
+
+func TestAdminService_SearchUsers(t *testing.T) {
+
+	adminID := uuid.NewString()
+
+	newAdminService := func(userRepo *mocks.UserRepositoryMock, auditRepo *mocks.AuditRepositoryMock) *service.AdminService {
+		return service.NewAdminService(nil, userRepo, nil, nil, auditRepo, nil, nil, service.EmailConfig{}, testLoginProtection)
+	}
+
+	t.Run("search is audited and the limit capped", func(t *testing.T) {
+		userRepo := &mocks.UserRepositoryMock{
+			SearchUsersFunc: func(ctx context.Context, search model.UserSearch) ([]model.User, error) {
+				return []model.User{{ID: uuid.NewString()}}, nil
+			},
+		}
+		auditRepo := &mocks.AuditRepositoryMock{
+			RecordAuditFunc: func(ctx context.Context, record model.AuditRecord) error {
+				return nil
+			},
+		}
+
+		users, err := newAdminService(userRepo, auditRepo).SearchUsers(context.Background(), model.AdminActor(adminID, ""), model.UserSearch{Query: "smith", Limit: 10000})
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+
+		require.Len(t, userRepo.SearchUsersCalls(), 1)
+		assert.Equal(t, 200, userRepo.SearchUsersCalls()[0].Search.Limit)
+
+		require.Len(t, auditRepo.RecordAuditCalls(), 1)
+		record := auditRepo.RecordAuditCalls()[0].Record
+		assert.Equal(t, adminID, record.ActorID)
+		assert.Equal(t, model.AuditActorAdmin, record.ActorType)
+		assert.Equal(t, model.AuditActionUsersSearched, record.Action)
+		assert.JSONEq(t, `{"query":"smith","status":"","limit":200,"offset":0}`, string(record.After))
+	})
+
+	t.Run("nothing is returned if the audit cannot be recorded", func(t *testing.T) {
+		userRepo := &mocks.UserRepositoryMock{}
+		auditRepo := &mocks.AuditRepositoryMock{
+			RecordAuditFunc: func(ctx context.Context, record model.AuditRecord) error {
+				return assert.AnError
+			},
+		}
+
+		_, err := newAdminService(userRepo, auditRepo).SearchUsers(context.Background(), model.AdminActor(adminID, ""), model.UserSearch{})
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, userRepo.SearchUsersCalls())
+	})
+
+	t.Run("unknown status is rejected", func(t *testing.T) {
+		_, err := newAdminService(&mocks.UserRepositoryMock{}, &mocks.AuditRepositoryMock{}).SearchUsers(context.Background(), model.AdminActor(adminID, ""), model.UserSearch{Status: "deleted"})
+		require.ErrorIs(t, err, model.ErrInvalidUser)
+	})
+}
+
+func TestAdminService_ForcePasswordReset(t *testing.T) {
+
+	adminID := uuid.NewString()
+	userID := uuid.NewString()
+
+	t.Run("failed email is reported apart from the committed reset", func(t *testing.T) {
+		userRepo := &mocks.UserRepositoryMock{
+			ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error) {
+				return &model.User{ID: userID, Name: "Test User", Email: "test@example.com"}, nil
+			},
+		}
+		mailer := &mocks.MailerMock{
+			SendFunc: func(message *model.EmailMessage) error {
+				return errors.New("mail queue is full")
+			},
+		}
+		adminService := service.NewAdminService(nil, userRepo, nil, nil, nil, nil, mailer, service.EmailConfig{}, testLoginProtection)
+
+		err := adminService.ForcePasswordReset(context.Background(), model.AdminActor(adminID, ""), userID)
+		require.ErrorIs(t, err, model.ErrResetEmailNotSent)
+		assert.Contains(t, err.Error(), "mail queue is full")
+		assert.Len(t, mailer.SendCalls(), 1)
+	})
+
+	t.Run("user not found sends nothing", func(t *testing.T) {
+		userRepo := &mocks.UserRepositoryMock{
+			ForcePasswordResetFunc: func(ctx context.Context, actor model.Actor, userID string, resetToken string) (*model.User, error) {
+				return nil, model.ErrUserNotFound
+			},
+		}
+		mailer := &mocks.MailerMock{}
+		adminService := service.NewAdminService(nil, userRepo, nil, nil, nil, nil, mailer, service.EmailConfig{}, testLoginProtection)
+
+		err := adminService.ForcePasswordReset(context.Background(), model.AdminActor(adminID, ""), userID)
+		require.ErrorIs(t, err, model.ErrUserNotFound)
+		assert.Empty(t, mailer.SendCalls())
+	})
+}
+
+func TestAdminService_ListAuditRecords(t *testing.T) {
+
+	actor := model.AdminActor(uuid.NewString(), "req-1")
+
+	newAdminService := func(auditRepo *mocks.AuditRepositoryMock) *service.AdminService {
+		return service.NewAdminService(nil, nil, nil, nil, auditRepo, nil, nil, service.EmailConfig{}, testLoginProtection)
+	}
+
+	t.Run("viewing the log is itself audited", func(t *testing.T) {
+		auditRepo := &mocks.AuditRepositoryMock{
+			RecordAuditFunc: func(ctx context.Context, record model.AuditRecord) error {
+				return nil
+			},
+			ListAuditRecordsFunc: func(ctx context.Context, filter model.AuditFilter) ([]model.AuditRecord, error) {
+				return []model.AuditRecord{{Action: model.AuditActionUserUpdated}}, nil
+			},
+		}
+
+		records, err := newAdminService(auditRepo).ListAuditRecords(context.Background(), actor, model.AuditFilter{EntityType: model.AuditEntityUser, Limit: 5000})
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+
+		require.Len(t, auditRepo.ListAuditRecordsCalls(), 1)
+		assert.Equal(t, 1000, auditRepo.ListAuditRecordsCalls()[0].Filter.Limit)
+
+		require.Len(t, auditRepo.RecordAuditCalls(), 1)
+		record := auditRepo.RecordAuditCalls()[0].Record
+		assert.Equal(t, actor.ID, record.ActorID)
+		assert.Equal(t, model.AuditActionAuditLogViewed, record.Action)
+		require.NotNil(t, record.RequestID)
+		assert.Equal(t, "req-1", *record.RequestID)
+	})
+
+	t.Run("from must be before to", func(t *testing.T) {
+		auditRepo := &mocks.AuditRepositoryMock{}
+		from := time.Now()
+		to := from.Add(-time.Hour)
+
+		_, err := newAdminService(auditRepo).ListAuditRecords(context.Background(), actor, model.AuditFilter{From: &from, To: &to})
+		require.ErrorIs(t, err, model.ErrInvalidAuditFilter)
+		assert.Empty(t, auditRepo.ListAuditRecordsCalls())
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	htmlTemplate "html/template"
+	"net/url"
+	textTemplate "text/template"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+type EmailConfig struct {
+	// VerificationURL is the page that receives the token as a query parameter
+	VerificationURL string `env:"VERIFICATION_URL, default=http://localhost:8080/verify-email"`
+	// PasswordResetURL is the page that receives the token as a query parameter
+	PasswordResetURL string `env:"PASSWORD_RESET_URL, default=http://localhost:8080/reset-password"`
+}
+
+//go:embed templates
+var emailTemplates embed.FS
+
+var (
+	verificationTextTemplate  = textTemplate.Must(textTemplate.ParseFS(emailTemplates, "templates/verification_email.txt.tmpl"))
+	verificationHTMLTemplate  = htmlTemplate.Must(htmlTemplate.ParseFS(emailTemplates, "templates/verification_email.html.tmpl"))
+	passwordResetTextTemplate = textTemplate.Must(textTemplate.ParseFS(emailTemplates, "templates/password_reset_email.txt.tmpl"))
+	passwordResetHTMLTemplate = htmlTemplate.Must(htmlTemplate.ParseFS(emailTemplates, "templates/password_reset_email.html.tmpl"))
+)
+
+type linkEmailData struct {
+	Name string
+	Link string
+}
+
+// newVerificationEmail renders the email sent to a new user with their
+// verification link.
+func newVerificationEmail(config EmailConfig, name string, email string, token string) (*model.EmailMessage, error) {
+	return newLinkEmail(config.VerificationURL, token, name, email,
+		"Verify your Eagle Bank email address", verificationTextTemplate, verificationHTMLTemplate)
+}
+
+// newPasswordResetEmail renders the email carrying a password reset link
+func newPasswordResetEmail(config EmailConfig, name string, email string, token string) (*model.EmailMessage, error) {
+	return newLinkEmail(config.PasswordResetURL, token, name, email,
+		"Reset your Eagle Bank password", passwordResetTextTemplate, passwordResetHTMLTemplate)
+}
+
+func newLinkEmail(
+	baseURL string,
+	token string,
+	name string,
+	email string,
+	subject string,
+	textTmpl *textTemplate.Template,
+	htmlTmpl *htmlTemplate.Template) (*model.EmailMessage, error) {
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid email link url")
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	data := linkEmailData{
+		Name: name,
+		Link: link.String(),
+	}
+	var text, html bytes.Buffer
+	if err := textTmpl.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := htmlTmpl.Execute(&html, data); err != nil {
+		return nil, err
+	}
+	return &model.EmailMessage{
+		To:       email,
+		Subject:  subject,
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+)
+
+type LoginProtectionConfig struct {
+	// FailureWindow is how long a failed login is remembered
+	FailureWindow time.Duration `env:"LOGIN_FAILURE_WINDOW, default=1h"`
+	// EmailFreeAttempts and IPFreeAttempts are the failures allowed before
+	// logins are delayed
+	EmailFreeAttempts int `env:"LOGIN_EMAIL_FREE_ATTEMPTS, default=3"`
+	IPFreeAttempts    int `env:"LOGIN_IP_FREE_ATTEMPTS, default=20"`
+	// the delay starts at DelayBase and doubles with each further failure
+	DelayBase time.Duration `env:"LOGIN_DELAY_BASE, default=1s"`
+	DelayMax  time.Duration `env:"LOGIN_DELAY_MAX, default=5m"`
+	// LockoutThreshold is the failures for one email that suspend the user
+	LockoutThreshold int           `env:"LOGIN_LOCKOUT_THRESHOLD, default=10"`
+	LockoutDuration  time.Duration `env:"LOGIN_LOCKOUT_DURATION, default=30m"`
+}
+
+// loginDelay returns how much longer a caller with the given failures must
+// wait before trying again, or zero if they may try now.
+func (c LoginProtectionConfig) loginDelay(failed *model.FailedLogins, freeAttempts int, now time.Time) time.Duration {
+	if failed.Failures < freeAttempts {
+		return 0
+	}
+	delay := c.DelayBase
+	for i := freeAttempts; i < failed.Failures && delay < c.DelayMax; i++ {
+		delay *= 2
+	}
+	if delay > c.DelayMax {
+		delay = c.DelayMax
+	}
+	return failed.LastFailureAt.Add(delay).Sub(now)
+}
+
+// loginKey normalises an email address so that changes in case do not
+// reset its failure count.
+func loginKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
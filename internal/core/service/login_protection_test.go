@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLoginProtection = service.LoginProtectionConfig{
+	FailureWindow:     time.Hour,
+	EmailFreeAttempts: 3,
+	IPFreeAttempts:    20,
+	DelayBase:         time.Second,
+	DelayMax:          time.Minute,
+	LockoutThreshold:  10,
+	LockoutDuration:   30 * time.Minute,
+}
+
+// newLoginAttemptRepository returns a map-backed mock, seeded with failures
+// recorded a moment ago.
+func newLoginAttemptRepository(failures map[string]int) *mocks.LoginAttemptRepositoryMock {
+	lastFailureAt := time.Now().UTC()
+	return &mocks.LoginAttemptRepositoryMock{
+		GetFailedLoginsFunc: func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+			return &model.FailedLogins{Failures: failures[scope+":"+key], LastFailureAt: lastFailureAt}, nil
+		},
+		RecordFailedLoginFunc: func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+			failures[scope+":"+key]++
+			return &model.FailedLogins{Failures: failures[scope+":"+key], LastFailureAt: time.Now().UTC()}, nil
+		},
+		ResetFailedLoginsFunc: func(ctx context.Context, scope string, key string) error {
+			delete(failures, scope+":"+key)
+			return nil
+		},
+	}
+}
+
+func TestUserService_Login(t *testing.T) {
+
+	const ip = "203.0.113.7"
+	userID := uuid.NewString()
+	email := "jane@example.com"
+
+	t.Run("repeated failures are delayed", func(t *testing.T) {
+		attempts := newLoginAttemptRepository(map[string]int{"email:" + email: 5})
+		repo := &mocks.UserRepositoryMock{}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		_, err := userService.Login(context.Background(), model.AnonymousActor(""), "Jane@Example.com", "passw0rd", ip)
+		require.ErrorIs(t, err, model.ErrLoginThrottled)
+		var retryErr *model.RetryAfterError
+		require.True(t, errors.As(err, &retryErr))
+		// 1s doubled for each failure beyond the three free attempts
+		assert.InDelta(t, (4 * time.Second).Seconds(), retryErr.RetryAfter.Seconds(), 1)
+		assert.Empty(t, repo.LoginCalls())
+	})
+
+	t.Run("reaching the threshold locks the user", func(t *testing.T) {
+		failures := map[string]int{"email:" + email: 9}
+		attempts := newLoginAttemptRepository(failures)
+		// the last failure was long enough ago for the delay to have passed
+		attempts.GetFailedLoginsFunc = func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+			return &model.FailedLogins{Failures: failures[scope+":"+key], LastFailureAt: time.Now().Add(-time.Hour)}, nil
+		}
+		repo := &mocks.UserRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return "", model.ErrInvalidCredentials
+			},
+			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+				return nil, nil
+			},
+			LockUserFunc: func(ctx context.Context, actor model.Actor, email string, until time.Time) error {
+				return nil
+			},
+		}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		_, err := userService.Login(context.Background(), model.AnonymousActor(""), email, "wrong", ip)
+		require.ErrorIs(t, err, model.ErrInvalidCredentials)
+		require.Len(t, repo.LockUserCalls(), 1)
+		assert.Equal(t, email, repo.LockUserCalls()[0].Email)
+		assert.Equal(t, model.AuditActorSystem, repo.LockUserCalls()[0].Actor.Type)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), repo.LockUserCalls()[0].Until, time.Minute)
+		assert.Equal(t, 1, failures["ip:"+ip])
+		assert.NotContains(t, failures, "email:"+email)
+	})
+
+	t.Run("user who cannot be locked still gets invalid credentials", func(t *testing.T) {
+		attempts := newLoginAttemptRepository(map[string]int{"email:" + email: 9})
+		attempts.GetFailedLoginsFunc = func(ctx context.Context, scope string, key string, since time.Time) (*model.FailedLogins, error) {
+			return &model.FailedLogins{LastFailureAt: time.Now().Add(-time.Hour)}, nil
+		}
+		repo := &mocks.UserRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return "", model.ErrInvalidCredentials
+			},
+			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+				return nil, nil
+			},
+			LockUserFunc: func(ctx context.Context, actor model.Actor, email string, until time.Time) error {
+				return errors.Wrap(model.ErrInvalidUserTransition, "cannot move from email_verified to suspended")
+			},
+		}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		_, err := userService.Login(context.Background(), model.AnonymousActor(""), email, "wrong", ip)
+		require.ErrorIs(t, err, model.ErrInvalidCredentials)
+		require.Len(t, repo.LockUserCalls(), 1)
+	})
+
+	t.Run("locked user gets the same answer for any password", func(t *testing.T) {
+		failures := map[string]int{}
+		attempts := newLoginAttemptRepository(failures)
+		repo := &mocks.UserRepositoryMock{
+			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+				return &model.UserLockout{UserID: userID, LockedUntil: time.Now().Add(10 * time.Minute)}, nil
+			},
+		}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		_, rightErr := userService.Login(context.Background(), model.AnonymousActor(""), email, "passw0rd", ip)
+		_, wrongErr := userService.Login(context.Background(), model.AnonymousActor(""), email, "wrong", ip)
+		require.ErrorIs(t, rightErr, model.ErrUserLocked)
+		require.ErrorIs(t, wrongErr, model.ErrUserLocked)
+		// the password is not even compared while the lock lasts
+		assert.Empty(t, repo.LoginCalls())
+		assert.Empty(t, failures)
+	})
+
+	t.Run("expired lock is lifted on the next successful login", func(t *testing.T) {
+		attempts := newLoginAttemptRepository(map[string]int{})
+		status := model.UserStatusSuspended
+		repo := &mocks.UserRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return userID, nil
+			},
+			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
+				return &model.User{ID: userID, Email: email, Status: status}, nil
+			},
+			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+				return &model.UserLockout{UserID: userID, PreviousStatus: "active", LockedUntil: time.Now().Add(-time.Minute)}, nil
+			},
+			UnlockUserFunc: func(ctx context.Context, actor model.Actor, id string) error {
+				status = "active"
+				return nil
+			},
+		}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		user, err := userService.Login(context.Background(), model.AnonymousActor(""), email, "passw0rd", ip)
+		require.NoError(t, err)
+		assert.Equal(t, "active", user.Status)
+		assert.Len(t, repo.UnlockUserCalls(), 1)
+	})
+
+	t.Run("suspended user without a lockout is refused", func(t *testing.T) {
+		attempts := newLoginAttemptRepository(map[string]int{})
+		repo := &mocks.UserRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return userID, nil
+			},
+			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
+				return &model.User{ID: userID, Email: email, Status: model.UserStatusSuspended}, nil
+			},
+			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+				return nil, nil
+			},
+		}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		_, err := userService.Login(context.Background(), model.AnonymousActor(""), email, "passw0rd", ip)
+		require.ErrorIs(t, err, model.ErrUserSuspended)
+	})
+
+	t.Run("user asked to reset their password is refused", func(t *testing.T) {
+		attempts := newLoginAttemptRepository(map[string]int{})
+		repo := &mocks.UserRepositoryMock{
+			LoginFunc: func(ctx context.Context, email string, password string) (string, error) {
+				return userID, nil
+			},
+			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
+				return &model.User{ID: userID, Email: email, Status: model.UserStatusActive, PasswordResetRequired: true}, nil
+			},
+			GetUserLockoutByEmailFunc: func(ctx context.Context, email string) (*model.UserLockout, error) {
+				return nil, nil
+			},
+		}
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		_, err := userService.Login(context.Background(), model.AnonymousActor(""), email, "passw0rd", ip)
+		require.ErrorIs(t, err, model.ErrPasswordResetRequired)
+	})
+}
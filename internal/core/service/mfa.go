@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/pkg/errors"
+)
+
+const (
+	recoveryCodeCount = 10
+	// recoveryCodeSize is in random bytes, giving 16 base32 characters
+	recoveryCodeSize = 10
+	// minEncryptionKeyLength rules out short passphrases, as the key is only
+	// hashed and not stretched
+	minEncryptionKeyLength = 32
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type MFAConfig struct {
+	// Issuer names the bank in authenticator apps
+	Issuer string `env:"TOTP_ISSUER, default=Eagle Bank"`
+	// EncryptionKey encrypts TOTP secrets at rest. There is no default, as
+	// anyone who knew it could read every secret from a copy of the database.
+	EncryptionKey string `env:"TOTP_ENCRYPTION_KEY, required"`
+}
+
+func NewMFAService(
+	repo port.MFARepository,
+	userRepo port.UserRepository,
+	loginAttempts port.LoginAttemptRepository,
+	config MFAConfig,
+	loginProtection LoginProtectionConfig) (*MFAService, error) {
+	if len(config.EncryptionKey) < minEncryptionKeyLength {
+		return nil, errors.Errorf("TOTP encryption key must be at least %d characters", minEncryptionKeyLength)
+	}
+	key := sha256.Sum256([]byte(config.EncryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create TOTP secret cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create TOTP secret cipher")
+	}
+	return &MFAService{
+		repo:            repo,
+		userRepo:        userRepo,
+		loginAttempts:   loginAttempts,
+		issuer:          config.Issuer,
+		aead:            aead,
+		loginProtection: loginProtection,
+	}, nil
+}
+
+type MFAService struct {
+	repo            port.MFARepository
+	userRepo        port.UserRepository
+	loginAttempts   port.LoginAttemptRepository
+	issuer          string
+	aead            cipher.AEAD
+	loginProtection LoginProtectionConfig
+}
+
+// EnrolTOTP generates a new secret for the user. It is not used for login
+// until ActivateTOTP has seen a valid code from it.
+func (s MFAService) EnrolTOTP(ctx context.Context, userID string) (*model.TOTPEnrolment, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate TOTP secret")
+	}
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveTOTPSecret(ctx, userID, encrypted); err != nil {
+		return nil, err
+	}
+	return &model.TOTPEnrolment{
+		Secret: totpEncoding.EncodeToString(secret),
+		URI:    totpURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+func (s MFAService) ActivateTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	totp, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if totp.EnabledAt != nil {
+		return nil, model.ErrTOTPAlreadyEnabled
+	}
+	secret, err := s.decrypt(totp.Secret)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := matchTOTP(secret, code, time.Now())
+	if !ok {
+		return nil, model.ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = generateRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	if err := s.repo.ActivateTOTP(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s MFAService) IsTOTPEnabled(ctx context.Context, userID string) (bool, error) {
+	totp, err := s.repo.GetTOTP(ctx, userID)
+	if errors.Is(err, model.ErrTOTPNotEnrolled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return totp.EnabledAt != nil, nil
+}
+
+// VerifySecondFactor checks a TOTP code or recovery code. Each code is only
+// accepted once, and repeated failures are delayed like failed logins.
+func (s MFAService) VerifySecondFactor(ctx context.Context, userID string, code string, recoveryCode string) error {
+	now := time.Now().UTC()
+	since := now.Add(-s.loginProtection.FailureWindow)
+	failed, err := s.loginAttempts.GetFailedLogins(ctx, model.LoginScopeMFA, userID, since)
+	if err != nil {
+		return err
+	}
+	if wait := s.loginProtection.loginDelay(failed, s.loginProtection.EmailFreeAttempts, now); wait > 0 {
+		return &model.RetryAfterError{Err: model.ErrLoginThrottled, RetryAfter: wait}
+	}
+
+	ok, err := s.verifySecondFactor(ctx, userID, code, recoveryCode, now)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if _, err := s.loginAttempts.RecordFailedLogin(ctx, model.LoginScopeMFA, userID, since); err != nil {
+			return err
+		}
+		return model.ErrInvalidTOTPCode
+	}
+	return s.loginAttempts.ResetFailedLogins(ctx, model.LoginScopeMFA, userID)
+}
+
+func (s MFAService) verifySecondFactor(ctx context.Context, userID string, code string, recoveryCode string, now time.Time) (bool, error) {
+	if recoveryCode != "" {
+		return s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(recoveryCode))
+	}
+
+	totp, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if totp.EnabledAt == nil {
+		return false, model.ErrTOTPNotEnrolled
+	}
+	secret, err := s.decrypt(totp.Secret)
+	if err != nil {
+		return false, err
+	}
+	step, ok := matchTOTP(secret, code, now)
+	if !ok {
+		return false, nil
+	}
+	// a code seen by an onlooker must not work a second time
+	return s.repo.UseTOTPStep(ctx, userID, step)
+}
+
+func (s MFAService) encrypt(secret []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt TOTP secret")
+	}
+	return s.aead.Seal(nonce, nonce, secret, nil), nil
+}
+
+func (s MFAService) decrypt(encrypted []byte) ([]byte, error) {
+	if len(encrypted) < s.aead.NonceSize() {
+		return nil, errors.New("failed to decrypt TOTP secret")
+	}
+	nonce, sealed := encrypted[:s.aead.NonceSize()], encrypted[s.aead.NonceSize():]
+	secret, err := s.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt TOTP secret")
+	}
+	return secret, nil
+}
+
+// generateRecoveryCode returns a random code formatted as xxxx-xxxx-xxxx-xxxx.
+func generateRecoveryCode() (string, error) {
+	raw := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.Wrap(err, "failed to generate recovery code")
+	}
+	encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// hashRecoveryCode ignores case and separators, so codes can be typed loosely.
+// The codes are random enough that a fast hash is safe.
+func hashRecoveryCode(code string) string {
+	normalised := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalised))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Code is an independent implementation of a six digit SHA1 TOTP, so
+// that the service is checked against the standard rather than itself.
+func rfc6238Code(secret []byte, at time.Time) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[19] & 0xf
+	value := (uint32(sum[offset])&0x7f)<<24 | uint32(sum[offset+1])<<16 | uint32(sum[offset+2])<<8 | uint32(sum[offset+3])
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+const testEncryptionKey = "test-totp-encryption-key-32-chars"
+
+// newMFAService returns a service over a mock repository holding a single
+// enrolment, started by calling EnrolTOTP.
+func newMFAService(t *testing.T, userID string) (*service.MFAService, *mocks.MFARepositoryMock, *mocks.LoginAttemptRepositoryMock) {
+	var totp *model.TOTP
+	repo := &mocks.MFARepositoryMock{
+		SaveTOTPSecretFunc: func(ctx context.Context, id string, secret []byte) error {
+			totp = &model.TOTP{UserID: id, Secret: secret}
+			return nil
+		},
+		GetTOTPFunc: func(ctx context.Context, id string) (*model.TOTP, error) {
+			if totp == nil {
+				return nil, model.ErrTOTPNotEnrolled
+			}
+			return totp, nil
+		},
+		ActivateTOTPFunc: func(ctx context.Context, id string, step int64, recoveryCodeHashes []string) error {
+			now := time.Now()
+			totp.EnabledAt = &now
+			totp.LastUsedStep = &step
+			return nil
+		},
+		UseTOTPStepFunc: func(ctx context.Context, id string, step int64) (bool, error) {
+			if *totp.LastUsedStep >= step {
+				return false, nil
+			}
+			totp.LastUsedStep = &step
+			return true, nil
+		},
+	}
+	userRepo := &mocks.UserRepositoryMock{
+		GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
+			return &model.User{ID: id, Email: "jane@example.com"}, nil
+		},
+	}
+	attempts := newLoginAttemptRepository(map[string]int{})
+
+	mfaService, err := service.NewMFAService(repo, userRepo, attempts,
+		service.MFAConfig{Issuer: "Eagle Bank", EncryptionKey: testEncryptionKey}, testLoginProtection)
+	require.NoError(t, err)
+	return mfaService, repo, attempts
+}
+
+func TestNewMFAService(t *testing.T) {
+
+	t.Run("short encryption key is refused", func(t *testing.T) {
+		_, err := service.NewMFAService(nil, nil, nil, service.MFAConfig{EncryptionKey: "eagle-bank-totp-key"}, testLoginProtection)
+		require.Error(t, err)
+	})
+}
+
+func TestMFAService_TOTP(t *testing.T) {
+
+	// RFC 6238 appendix B, truncated to six digits
+	require.Equal(t, "287082", rfc6238Code([]byte("12345678901234567890"), time.Unix(59, 0)))
+
+	userID := uuid.NewString()
+	mfaService, repo, attempts := newMFAService(t, userID)
+
+	enrolment, err := mfaService.EnrolTOTP(context.Background(), userID)
+	require.NoError(t, err)
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(enrolment.Secret)
+	require.NoError(t, err)
+	assert.Len(t, secret, 20)
+	assert.NotEqual(t, secret, repo.SaveTOTPSecretCalls()[0].Secret, "secret must be stored encrypted")
+
+	uri, err := url.Parse(enrolment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "/Eagle Bank:jane@example.com", uri.Path)
+	assert.Equal(t, enrolment.Secret, uri.Query().Get("secret"))
+
+	enabled, err := mfaService.IsTOTPEnabled(context.Background(), userID)
+	require.NoError(t, err)
+	assert.False(t, enabled, "enrolment is not enabled until a code is entered")
+
+	_, err = mfaService.ActivateTOTP(context.Background(), userID, rfc6238Code(secret, time.Now().Add(-5*time.Minute)))
+	require.ErrorIs(t, err, model.ErrInvalidTOTPCode, "codes outside the allowed drift are rejected")
+
+	// the previous step's code is accepted to allow for clock drift
+	recoveryCodes, err := mfaService.ActivateTOTP(context.Background(), userID, rfc6238Code(secret, time.Now().Add(-30*time.Second)))
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, 10)
+	require.Len(t, repo.ActivateTOTPCalls(), 1)
+	assert.Len(t, repo.ActivateTOTPCalls()[0].RecoveryCodeHashes, 10)
+	assert.NotContains(t, repo.ActivateTOTPCalls()[0].RecoveryCodeHashes, recoveryCodes[0])
+
+	enabled, err = mfaService.IsTOTPEnabled(context.Background(), userID)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	code := rfc6238Code(secret, time.Now())
+	require.NoError(t, mfaService.VerifySecondFactor(context.Background(), userID, code, ""))
+	assert.ErrorIs(t, mfaService.VerifySecondFactor(context.Background(), userID, code, ""), model.ErrInvalidTOTPCode, "a code may only be used once")
+	assert.Len(t, attempts.RecordFailedLoginCalls(), 1)
+}
+
+func TestMFAService_VerifySecondFactor_RecoveryCode(t *testing.T) {
+
+	userID := uuid.NewString()
+	mfaService, repo, _ := newMFAService(t, userID)
+
+	var hashes []string
+	repo.UseRecoveryCodeFunc = func(ctx context.Context, id string, codeHash string) (bool, error) {
+		hashes = append(hashes, codeHash)
+		return true, nil
+	}
+
+	require.NoError(t, mfaService.VerifySecondFactor(context.Background(), userID, "", "abcd-efgh-ijkl-mnop"))
+	require.NoError(t, mfaService.VerifySecondFactor(context.Background(), userID, "", "ABCD EFGH IJKL MNOP"))
+	require.Len(t, hashes, 2)
+	assert.Equal(t, hashes[0], hashes[1], "recovery codes ignore case and separators")
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"eagle-bank.com/internal/core/port"
+	"go.uber.org/zap"
+)
+
+const (
+	outboxBatchSize    = 50
+	outboxPollInterval = time.Second
+	// outboxLease is how long a claimed event is hidden from other
+	// dispatchers, and must be longer than a publish can take
+	outboxLease      = time.Minute
+	outboxMaxBackoff = time.Hour
+)
+
+func NewOutboxDispatcher(
+	logger *zap.SugaredLogger,
+	repo port.OutboxRepository,
+	publisher port.Publisher) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		logger:    logger,
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+// OutboxDispatcher publishes events recorded in the outbox. An event is only
+// marked published once the publisher accepts it, so delivery is at least
+// once; failed events are retried with exponential backoff.
+type OutboxDispatcher struct {
+	logger    *zap.SugaredLogger
+	repo      port.OutboxRepository
+	publisher port.Publisher
+}
+
+// Run dispatches events until ctx is cancelled
+func (d OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	for {
+		// a full batch suggests a backlog, so carry on without waiting
+		if d.DispatchBatch(ctx) == outboxBatchSize && ctx.Err() == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch publishes one batch of due events, returning how many were claimed
+func (d OutboxDispatcher) DispatchBatch(ctx context.Context) int {
+	events, err := d.repo.ClaimEvents(ctx, outboxBatchSize, outboxLease)
+	if err != nil {
+		d.logger.Errorw("failed to claim outbox events", "error", err)
+		return 0
+	}
+	for _, event := range events {
+		if err := d.publisher.Publish(event); err != nil {
+			d.logger.Warnw("failed to publish event", "id", event.ID, "type", event.Type, "attempts", event.Attempts, "error", err)
+			if err := d.repo.MarkFailed(ctx, event.ID, err, time.Now().Add(outboxBackoff(event.Attempts))); err != nil {
+				d.logger.Errorw("failed to reschedule event", "id", event.ID, "error", err)
+			}
+			continue
+		}
+		if err := d.repo.MarkPublished(ctx, event.ID); err != nil {
+			// the lease will expire and the event will be published again
+			d.logger.Errorw("failed to mark event published", "id", event.ID, "error", err)
+		}
+	}
+	return len(events)
+}
+
+// outboxBackoff doubles the delay after each failed attempt, starting at a second
+func outboxBackoff(attempts int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestOutboxDispatcher_DispatchBatch(t *testing.T) {
+
+	logger := zaptest.NewLogger(t).Sugar()
+
+	newRepo := func(events ...model.Event) *mocks.OutboxRepositoryMock {
+		return &mocks.OutboxRepositoryMock{
+			ClaimEventsFunc: func(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+				return events, nil
+			},
+			MarkPublishedFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+			MarkFailedFunc: func(ctx context.Context, id string, cause error, nextAttemptAt time.Time) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("published events are marked published", func(t *testing.T) {
+		event := model.Event{ID: uuid.NewString(), Type: model.EventUserCreated, Attempts: 1}
+		repo := newRepo(event)
+		publisher := &mocks.PublisherMock{
+			PublishFunc: func(event model.Event) error {
+				return nil
+			},
+		}
+
+		claimed := service.NewOutboxDispatcher(logger, repo, publisher).DispatchBatch(context.Background())
+		assert.Equal(t, 1, claimed)
+		require.Len(t, repo.MarkPublishedCalls(), 1)
+		assert.Equal(t, event.ID, repo.MarkPublishedCalls()[0].ID)
+		assert.Empty(t, repo.MarkFailedCalls())
+	})
+
+	t.Run("failed events are retried with exponential backoff", func(t *testing.T) {
+		tests := []struct {
+			attempts        int
+			expectedBackoff time.Duration
+		}{
+			{attempts: 1, expectedBackoff: time.Second},
+			{attempts: 2, expectedBackoff: 2 * time.Second},
+			{attempts: 5, expectedBackoff: 16 * time.Second},
+			// capped at an hour
+			{attempts: 13, expectedBackoff: time.Hour},
+			{attempts: 100, expectedBackoff: time.Hour},
+		}
+		for _, tt := range tests {
+			event := model.Event{ID: uuid.NewString(), Type: model.EventUserCreated, Attempts: tt.attempts}
+			repo := newRepo(event)
+			publisher := &mocks.PublisherMock{
+				PublishFunc: func(event model.Event) error {
+					return errors.New("broker unavailable")
+				},
+			}
+
+			service.NewOutboxDispatcher(logger, repo, publisher).DispatchBatch(context.Background())
+			require.Len(t, repo.MarkFailedCalls(), 1, tt.attempts)
+			call := repo.MarkFailedCalls()[0]
+			assert.Equal(t, event.ID, call.ID)
+			assert.EqualError(t, call.Cause, "broker unavailable")
+			assert.WithinDuration(t, time.Now().Add(tt.expectedBackoff), call.NextAttemptAt, time.Second, tt.attempts)
+			assert.Empty(t, repo.MarkPublishedCalls())
+		}
+	})
+
+	t.Run("one failure does not hold up the rest of the batch", func(t *testing.T) {
+		failing := model.Event{ID: uuid.NewString(), Attempts: 1}
+		healthy := model.Event{ID: uuid.NewString(), Attempts: 1}
+		repo := newRepo(failing, healthy)
+		publisher := &mocks.PublisherMock{
+			PublishFunc: func(event model.Event) error {
+				if event.ID == failing.ID {
+					return errors.New("broker unavailable")
+				}
+				return nil
+			},
+		}
+
+		service.NewOutboxDispatcher(logger, repo, publisher).DispatchBatch(context.Background())
+		require.Len(t, repo.MarkFailedCalls(), 1)
+		assert.Equal(t, failing.ID, repo.MarkFailedCalls()[0].ID)
+		require.Len(t, repo.MarkPublishedCalls(), 1)
+		assert.Equal(t, healthy.ID, repo.MarkPublishedCalls()[0].ID)
+	})
+
+	t.Run("nothing is published when claiming fails", func(t *testing.T) {
+		repo := &mocks.OutboxRepositoryMock{
+			ClaimEventsFunc: func(ctx context.Context, limit int, lease time.Duration) ([]model.Event, error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		publisher := &mocks.PublisherMock{}
+
+		claimed := service.NewOutboxDispatcher(logger, repo, publisher).DispatchBatch(context.Background())
+		assert.Zero(t, claimed)
+		assert.Empty(t, publisher.PublishCalls())
+	})
+}
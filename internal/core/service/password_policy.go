@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"github.com/pkg/errors"
+)
+
+// bcryptMaxLength is the number of bytes bcrypt hashes; anything longer is
+// rejected by bcrypt.GenerateFromPassword.
+const bcryptMaxLength = 72
+
+// minIdentityLength is the shortest part of a user's name or email that a
+// password may not contain, so that short names do not ban common letters.
+const minIdentityLength = 3
+
+type PasswordPolicyConfig struct {
+	MinLength int `env:"PASSWORD_MIN_LENGTH, default=8"`
+	// MaxLength is in bytes and may not exceed bcrypt's 72-byte limit
+	MaxLength        int  `env:"PASSWORD_MAX_LENGTH, default=72"`
+	RequireUppercase bool `env:"PASSWORD_REQUIRE_UPPERCASE, default=false"`
+	RequireLowercase bool `env:"PASSWORD_REQUIRE_LOWERCASE, default=false"`
+	RequireDigit     bool `env:"PASSWORD_REQUIRE_DIGIT, default=true"`
+	RequireSymbol    bool `env:"PASSWORD_REQUIRE_SYMBOL, default=false"`
+	// BannedListFile lists one banned password per line; blank lines and lines
+	// starting with # are ignored
+	BannedListFile string `env:"PASSWORD_BANNED_LIST_FILE"`
+}
+
+// PasswordPolicy checks new passwords against the configured rules.
+type PasswordPolicy struct {
+	config PasswordPolicyConfig
+	banned map[string]struct{}
+}
+
+func NewPasswordPolicy(config PasswordPolicyConfig) (*PasswordPolicy, error) {
+	if config.MinLength < 1 {
+		return nil, errors.New("password min length must be at least 1")
+	}
+	if config.MaxLength > bcryptMaxLength {
+		return nil, fmt.Errorf("password max length must not exceed %d bytes", bcryptMaxLength)
+	}
+	if config.MinLength > config.MaxLength {
+		return nil, errors.New("password min length must not exceed max length")
+	}
+
+	banned := map[string]struct{}{}
+	if config.BannedListFile != "" {
+		var err error
+		banned, err = loadBannedPasswords(config.BannedListFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &PasswordPolicy{config: config, banned: banned}, nil
+}
+
+func loadBannedPasswords(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open banned password list")
+	}
+	defer file.Close()
+
+	banned := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		banned[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read banned password list")
+	}
+	return banned, nil
+}
+
+// Validate returns a *model.PasswordPolicyError listing every rule password
+// breaks, or nil if it meets the policy. user supplies the name and email
+// the password may not contain.
+func (p *PasswordPolicy) Validate(password string, user *model.User) error {
+	var violations []string
+
+	if len([]rune(password)) < p.config.MinLength {
+		violations = append(violations, fmt.Sprintf("password must be at least %d characters", p.config.MinLength))
+	}
+	if len(password) > p.config.MaxLength {
+		violations = append(violations, fmt.Sprintf("password must be at most %d bytes", p.config.MaxLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	if p.config.RequireUppercase && !upper {
+		violations = append(violations, "password must contain an uppercase letter")
+	}
+	if p.config.RequireLowercase && !lower {
+		violations = append(violations, "password must contain a lowercase letter")
+	}
+	if p.config.RequireDigit && !digit {
+		violations = append(violations, "password must contain a number")
+	}
+	if p.config.RequireSymbol && !symbol {
+		violations = append(violations, "password must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	if _, ok := p.banned[lowered]; ok {
+		violations = append(violations, "password is too common")
+	}
+	if user != nil {
+		if containsAny(lowered, emailParts(user.Email)) {
+			violations = append(violations, "password must not contain your email address")
+		}
+		if containsAny(lowered, strings.Fields(strings.ToLower(user.Name))) {
+			violations = append(violations, "password must not contain your name")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &model.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// emailParts returns the lower-cased address and its local part.
+func emailParts(email string) []string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil
+	}
+	local, _, _ := strings.Cut(email, "@")
+	return []string{email, local}
+}
+
+func containsAny(password string, parts []string) bool {
+	for _, part := range parts {
+		if len([]rune(part)) >= minIdentityLength && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
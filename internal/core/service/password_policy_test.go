@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/service"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+
+	bannedList := filepath.Join(t.TempDir(), "banned.txt")
+	require.NoError(t, os.WriteFile(bannedList, []byte("# common passwords\nPassw0rd!\n\nletmein1\n"), 0o600))
+
+	policy, err := service.NewPasswordPolicy(service.PasswordPolicyConfig{
+		MinLength:        8,
+		MaxLength:        72,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		BannedListFile:   bannedList,
+	})
+	require.NoError(t, err)
+
+	user := &model.User{Name: "Jane Smith", Email: "jsm@example.com"}
+
+	tests := []struct {
+		desc     string
+		password string
+
+		expectedViolations []string
+	}{
+		{
+			desc:     "meets the policy",
+			password: "Correct-Horse-7",
+		},
+		{
+			desc:     "every failing rule is reported",
+			password: "short",
+
+			expectedViolations: []string{
+				"password must be at least 8 characters",
+				"password must contain an uppercase letter",
+				"password must contain a number",
+				"password must contain a symbol",
+			},
+		},
+		{
+			desc:     "longer than bcrypt accepts",
+			password: "Aa1!" + strings.Repeat("x", 69),
+
+			expectedViolations: []string{"password must be at most 72 bytes"},
+		},
+		{
+			desc:     "banned regardless of case",
+			password: "PASSW0RD!",
+
+			expectedViolations: []string{
+				"password must contain a lowercase letter",
+				"password is too common",
+			},
+		},
+		{
+			desc:     "contains the email local part",
+			password: "Xjsm-20245",
+
+			expectedViolations: []string{"password must not contain your email address"},
+		},
+		{
+			desc:     "contains part of the name",
+			password: "Smithy-2024",
+
+			expectedViolations: []string{"password must not contain your name"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			err := policy.Validate(tt.password, user)
+			if tt.expectedViolations == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, model.ErrInvalidPassword)
+			var policyErr *model.PasswordPolicyError
+			require.True(t, errors.As(err, &policyErr))
+			assert.Equal(t, tt.expectedViolations, policyErr.Violations)
+		})
+	}
+}
+
+func TestNewPasswordPolicy_RejectsMaxLengthBeyondBcrypt(t *testing.T) {
+	_, err := service.NewPasswordPolicy(service.PasswordPolicyConfig{MinLength: 8, MaxLength: 100})
+	require.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const transactionCurrency = "GBP"
+
+var maxTransactionAmount = decimal.NewFromInt(10_000)
+
+func NewTransactionService(
+	repo port.TransactionRepository,
+	accountRepo port.AccountRepository) *TransactionService {
+	return &TransactionService{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+type TransactionService struct {
+	repo        port.TransactionRepository
+	accountRepo port.AccountRepository
+}
+
+func (s TransactionService) CreateTransaction(ctx context.Context, newTransaction *model.NewTransaction) (*model.Transaction, error) {
+	if newTransaction == nil {
+		return nil, errors.New("new transaction cannot be nil")
+	}
+	if err := ValidateNewTransaction(newTransaction); err != nil {
+		return nil, err
+	}
+	if err := s.authorise(ctx, newTransaction.AccountNumber, newTransaction.UserID); err != nil {
+		return nil, err
+	}
+
+	newTransaction.TransactionID = GenerateTransactionID()
+	return s.repo.CreateTransaction(ctx, newTransaction)
+}
+
+func (s TransactionService) CreateTransfer(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error) {
+	if newTransfer == nil {
+		return nil, errors.New("new transfer cannot be nil")
+	}
+	if err := ValidateNewTransfer(newTransfer); err != nil {
+		return nil, err
+	}
+	if err := s.authorise(ctx, newTransfer.FromAccountNumber, newTransfer.UserID); err != nil {
+		return nil, err
+	}
+	// the credit leg is posted to the ledger of the payee's owner
+	payee, err := s.accountRepo.GetAccountByNumber(ctx, newTransfer.ToAccountNumber)
+	if err != nil {
+		if errors.Is(err, model.ErrAccountNotFound) {
+			return nil, errors.Wrap(err, "payee")
+		}
+		return nil, err
+	}
+	newTransfer.PayeeUserID = payee.UserID
+
+	newTransfer.TransferID = GenerateTransferID()
+	newTransfer.DebitTransactionID = GenerateTransactionID()
+	newTransfer.CreditTransactionID = GenerateTransactionID()
+	return s.repo.CreateTransfer(ctx, newTransfer)
+}
+
+func (s TransactionService) ListTransactions(ctx context.Context, accountNumber string, userID string) ([]model.Transaction, error) {
+	if err := s.authorise(ctx, accountNumber, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTransactions(ctx, accountNumber)
+}
+
+func (s TransactionService) GetTransaction(ctx context.Context, accountNumber string, transactionID string, userID string) (*model.Transaction, error) {
+	if err := s.authorise(ctx, accountNumber, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTransaction(ctx, accountNumber, transactionID)
+}
+
+// authorise ensures the account exists and is owned by the given user
+func (s TransactionService) authorise(ctx context.Context, accountNumber string, userID string) error {
+	userAccount, err := s.accountRepo.GetAccountByNumber(ctx, accountNumber)
+	if err != nil {
+		return err
+	}
+	if userAccount.UserID != userID {
+		return model.ErrForbidden
+	}
+	return nil
+}
+
+func ValidateNewTransaction(t *model.NewTransaction) error {
+	if t.Type != model.TransactionDepositType && t.Type != model.TransactionWithdrawalType {
+		return errors.Wrap(model.ErrInvalidTransaction, "type must be deposit or withdrawal")
+	}
+	return validateAmount(model.ErrInvalidTransaction, t.Amount, t.Currency)
+}
+
+func ValidateNewTransfer(t *model.NewTransfer) error {
+	if t.ToSortCode != model.EagleSortCode {
+		return errors.Wrap(model.ErrInvalidTransfer, "transfers are only supported to sort code 10-10-10")
+	}
+	if t.ToAccountNumber == "" {
+		return errors.Wrap(model.ErrInvalidTransfer, "accountNumber is required")
+	}
+	if t.ToAccountNumber == t.FromAccountNumber {
+		return errors.Wrap(model.ErrInvalidTransfer, "cannot transfer to the same account")
+	}
+	return validateAmount(model.ErrInvalidTransfer, t.Amount, t.Currency)
+}
+
+// validateAmount checks an amount against the limits in openapi.yaml,
+// reporting failures as the given kind of domain error.
+func validateAmount(kind error, amount decimal.Decimal, currency string) error {
+	if currency != transactionCurrency {
+		return errors.Wrap(kind, "currency must be GBP")
+	}
+	if !amount.IsPositive() || amount.GreaterThan(maxTransactionAmount) {
+		return errors.Wrap(kind, "amount must be greater than 0.00 and no more than 10000.00")
+	}
+	if !amount.Equal(amount.Round(2)) {
+		return errors.Wrap(kind, "amount must have no more than two decimal places")
+	}
+	return nil
+}
+
+// GenerateTransactionID returns an identifier in the tan-<alphanumeric> format
+func GenerateTransactionID() string {
+	return "tan-" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+// GenerateTransferID returns an identifier in the tfr-<alphanumeric> format
+func GenerateTransferID() string {
+	return "tfr-" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
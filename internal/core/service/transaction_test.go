@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionService_CreateTransfer(t *testing.T) {
+
+	payerID := uuid.NewString()
+	payeeID := uuid.NewString()
+	owners := map[string]string{"01000001": payerID, "01000002": payeeID}
+	accountRepo := &mocks.AccountRepositoryMock{
+		GetAccountByNumberFunc: func(ctx context.Context, accountNumber string) (*model.UserAccount, error) {
+			owner, ok := owners[accountNumber]
+			if !ok {
+				return nil, model.ErrAccountNotFound
+			}
+			return &model.UserAccount{UserID: owner, AccountNumber: accountNumber}, nil
+		},
+	}
+	newTransfer := func(toAccountNumber string) *model.NewTransfer {
+		return &model.NewTransfer{
+			FromAccountNumber: "01000001",
+			ToSortCode:        model.EagleSortCode,
+			ToAccountNumber:   toAccountNumber,
+			UserID:            payerID,
+			Amount:            decimal.NewFromInt(25),
+			Currency:          "GBP",
+		}
+	}
+
+	t.Run("debit leg belongs to the payer and credit leg to the payee", func(t *testing.T) {
+		repo := &mocks.TransactionRepositoryMock{
+			CreateTransferFunc: func(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error) {
+				return &model.Transfer{ID: newTransfer.TransferID}, nil
+			},
+		}
+		_, err := service.NewTransactionService(repo, accountRepo).CreateTransfer(context.Background(), newTransfer("01000002"))
+		require.NoError(t, err)
+
+		require.Len(t, repo.CreateTransferCalls(), 1)
+		transfer := repo.CreateTransferCalls()[0].NewTransfer
+		assert.Equal(t, payerID, transfer.UserID)
+		assert.Equal(t, payeeID, transfer.PayeeUserID)
+		assert.NotEmpty(t, transfer.DebitTransactionID)
+		assert.NotEmpty(t, transfer.CreditTransactionID)
+		assert.NotEqual(t, transfer.DebitTransactionID, transfer.CreditTransactionID)
+	})
+
+	t.Run("unknown payee moves nothing", func(t *testing.T) {
+		repo := &mocks.TransactionRepositoryMock{}
+		_, err := service.NewTransactionService(repo, accountRepo).CreateTransfer(context.Background(), newTransfer("01999999"))
+		require.ErrorIs(t, err, model.ErrAccountNotFound)
+		assert.Empty(t, repo.CreateTransferCalls())
+	})
+
+	t.Run("payer's account must be their own", func(t *testing.T) {
+		repo := &mocks.TransactionRepositoryMock{}
+		transfer := newTransfer("01000001")
+		transfer.FromAccountNumber = "01000002"
+		_, err := service.NewTransactionService(repo, accountRepo).CreateTransfer(context.Background(), transfer)
+		require.ErrorIs(t, err, model.ErrForbidden)
+		assert.Empty(t, repo.CreateTransferCalls())
+	})
+
+	t.Run("insufficient funds is returned", func(t *testing.T) {
+		repo := &mocks.TransactionRepositoryMock{
+			CreateTransferFunc: func(ctx context.Context, newTransfer *model.NewTransfer) (*model.Transfer, error) {
+				return nil, model.ErrInsufficientFunds
+			},
+		}
+		_, err := service.NewTransactionService(repo, accountRepo).CreateTransfer(context.Background(), newTransfer("01000002"))
+		require.ErrorIs(t, err, model.ErrInsufficientFunds)
+	})
+}
@@ -1,52 +1,158 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"net/mail"
 	"regexp"
-	"strings"
+	"time"
 
 	"eagle-bank.com/internal/core/domain/model"
 	"eagle-bank.com/internal/core/port"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/nyaruka/phonenumbers"
 	"github.com/pkg/errors"
 )
 
+const (
+	// verificationResendInterval is the minimum time between verification
+	// emails to the same address
+	verificationResendInterval = time.Minute
+	// passwordResetInterval is the minimum time between password reset emails
+	// to the same address
+	passwordResetInterval = time.Minute
+)
+
 func NewUserService(
-	repo port.UserRepository) *UserService {
+	repo port.UserRepository,
+	loginAttempts port.LoginAttemptRepository,
+	emailSends port.EmailSendRepository,
+	mailer port.Mailer,
+	emailConfig EmailConfig,
+	passwordPolicy *PasswordPolicy,
+	loginProtection LoginProtectionConfig) *UserService {
 	return &UserService{
-		repo: repo,
+		repo:            repo,
+		loginAttempts:   loginAttempts,
+		emailSends:      emailSends,
+		mailer:          mailer,
+		emailConfig:     emailConfig,
+		passwordPolicy:  passwordPolicy,
+		loginProtection: loginProtection,
 	}
 }
 
 type UserService struct {
-	repo port.UserRepository
+	repo            port.UserRepository
+	loginAttempts   port.LoginAttemptRepository
+	emailSends      port.EmailSendRepository
+	mailer          port.Mailer
+	emailConfig     EmailConfig
+	passwordPolicy  *PasswordPolicy
+	loginProtection LoginProtectionConfig
 }
 
-func (s UserService) Login(email string, password string) (*model.User, error) {
-	userID, err := s.repo.Login(email, password)
+// Login checks a user's credentials. Repeated failures for the same email or
+// from the same IP are delayed, and enough failures for one email lock the
+// user until LockoutDuration has passed or they reset their password. Users an
+// administrator has asked to reset their password cannot log in until they do.
+func (s UserService) Login(ctx context.Context, actor model.Actor, email string, password string, ip string) (*model.User, error) {
+	now := time.Now().UTC()
+	since := now.Add(-s.loginProtection.FailureWindow)
+	key := loginKey(email)
+
+	for _, limit := range []struct {
+		scope        string
+		key          string
+		freeAttempts int
+	}{
+		{model.LoginScopeEmail, key, s.loginProtection.EmailFreeAttempts},
+		{model.LoginScopeIP, ip, s.loginProtection.IPFreeAttempts},
+	} {
+		failed, err := s.loginAttempts.GetFailedLogins(ctx, limit.scope, limit.key, since)
+		if err != nil {
+			return nil, err
+		}
+		if wait := s.loginProtection.loginDelay(failed, limit.freeAttempts, now); wait > 0 {
+			return nil, &model.RetryAfterError{Err: model.ErrLoginThrottled, RetryAfter: wait}
+		}
+	}
+
+	// a locked user gets the same answer whatever the password, so the lock
+	// neither confirms a correct guess nor lets the guessing carry on
+	lockout, err := s.repo.GetUserLockoutByEmail(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if lockout != nil {
+		if wait := lockout.LockedUntil.Sub(now); wait > 0 {
+			return nil, &model.RetryAfterError{Err: model.ErrUserLocked, RetryAfter: wait}
+		}
+	}
+
+	userID, err := s.repo.Login(ctx, email, password)
+	if errors.Is(err, model.ErrInvalidCredentials) {
+		if recordErr := s.recordFailedLogin(ctx, actor, key, ip, since, now); recordErr != nil {
+			return nil, recordErr
+		}
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
-	return s.repo.GetUserByID(userID)
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == model.UserStatusSuspended {
+		// the lockout checked above has run out
+		if lockout == nil || lockout.UserID != userID {
+			return nil, model.ErrUserSuspended
+		}
+		if err := s.repo.UnlockUser(ctx, model.SystemActor(actor.RequestID), userID); err != nil {
+			return nil, err
+		}
+		if user, err = s.repo.GetUserByID(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	if user.PasswordResetRequired {
+		return nil, model.ErrPasswordResetRequired
+	}
+
+	if err := s.loginAttempts.ResetFailedLogins(ctx, model.LoginScopeEmail, key); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func ValidPassword(password string) error {
-	// TODO: expand upon example basic validation for password
-	if len(password) < 8 {
-		return errors.New("Password must be at least 8 characters")
+func (s UserService) recordFailedLogin(ctx context.Context, actor model.Actor, key string, ip string, since time.Time, now time.Time) error {
+	if _, err := s.loginAttempts.RecordFailedLogin(ctx, model.LoginScopeIP, ip, since); err != nil {
+		return err
 	}
-	if !strings.ContainsAny(password, "0123456789") {
-		return errors.New("Password must contain a number")
+	failed, err := s.loginAttempts.RecordFailedLogin(ctx, model.LoginScopeEmail, key, since)
+	if err != nil {
+		return err
 	}
-	return nil
+	if failed.Failures < s.loginProtection.LockoutThreshold {
+		return nil
+	}
+
+	// only active users can be locked out
+	err = s.repo.LockUser(ctx, model.SystemActor(actor.RequestID), key, now.Add(s.loginProtection.LockoutDuration))
+	if err != nil && !errors.Is(err, model.ErrUserNotFound) && !errors.Is(err, model.ErrInvalidUserTransition) {
+		return err
+	}
+	// the lock now stops further attempts, so they start afresh once it ends
+	return s.loginAttempts.ResetFailedLogins(ctx, model.LoginScopeEmail, key)
 }
 
-func (s UserService) SetPassword(user *model.User, password string) error {
-	if err := ValidPassword(password); err != nil {
-		return errors.Wrap(err, "invalid password received")
+func (s UserService) SetPassword(ctx context.Context, actor model.Actor, user *model.User, password string) error {
+	if err := s.passwordPolicy.Validate(password, user); err != nil {
+		return err
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -54,18 +160,124 @@ func (s UserService) SetPassword(user *model.User, password string) error {
 		return errors.New("failed to encrypt password")
 	}
 
-	return s.repo.SetPassword(user, hash)
+	return s.repo.SetPassword(ctx, actor, user, hash)
+}
+
+// ChangePassword replaces the password of a logged in user after checking
+// their current one, and revokes all of their sessions. Recent passwords may
+// not be reused, and repeated wrong current passwords are delayed like failed
+// logins, so that a stolen session cannot be used to guess the password.
+func (s UserService) ChangePassword(ctx context.Context, actor model.Actor, userID string, currentPassword string, newPassword string) error {
+	now := time.Now().UTC()
+	since := now.Add(-s.loginProtection.FailureWindow)
+	failed, err := s.loginAttempts.GetFailedLogins(ctx, model.LoginScopePassword, userID, since)
+	if err != nil {
+		return err
+	}
+	if wait := s.loginProtection.loginDelay(failed, s.loginProtection.EmailFreeAttempts, now); wait > 0 {
+		return &model.RetryAfterError{Err: model.ErrLoginThrottled, RetryAfter: wait}
+	}
+
+	hashes, err := s.repo.GetPasswordHashes(ctx, userID, model.PasswordHistoryDepth)
+	if err != nil {
+		return err
+	}
+	if len(hashes) == 0 || bcrypt.CompareHashAndPassword([]byte(hashes[0]), []byte(currentPassword)) != nil {
+		if _, err := s.loginAttempts.RecordFailedLogin(ctx, model.LoginScopePassword, userID, since); err != nil {
+			return err
+		}
+		return model.ErrIncorrectPassword
+	}
+	if err := s.loginAttempts.ResetFailedLogins(ctx, model.LoginScopePassword, userID); err != nil {
+		return err
+	}
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.passwordPolicy.Validate(newPassword, user); err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(newPassword)) == nil {
+			return model.ErrPasswordReused
+		}
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to encrypt password")
+	}
+	return s.repo.ChangePassword(ctx, actor, userID, hash)
+}
+
+// SuspendUser stops a user from logging in until they are reactivated.
+func (s UserService) SuspendUser(ctx context.Context, actor model.Actor, userID string) error {
+	return s.repo.ChangeUserStatus(ctx, actor, userID, model.UserStatusSuspended)
+}
+
+// ReactivateUser lifts a suspension, including one from a login lockout. The
+// repository clears the failed logins behind the lockout along with it.
+func (s UserService) ReactivateUser(ctx context.Context, actor model.Actor, userID string) error {
+	return s.repo.ChangeUserStatus(ctx, actor, userID, model.UserStatusActive)
 }
 
-func (s UserService) GetUserByID(id string) (*model.User, error) {
-	user, err := s.repo.GetUserByID(id)
+// RequestPasswordReset emails a password reset link. Unknown addresses are
+// ignored so that the response does not reveal which are registered.
+func (s UserService) RequestPasswordReset(ctx context.Context, actor model.Actor, email string) error {
+	err := s.throttleEmailRequest(ctx, model.EmailKindPasswordReset, email, passwordResetInterval, model.ErrPasswordResetTooSoon)
+	if err != nil {
+		return err
+	}
+
+	resetToken := uuid.NewString()
+	user, err := s.repo.ReplacePasswordResetToken(ctx, actor, email, resetToken, time.Now().Add(-passwordResetInterval))
+	// a token issued recently by another route is only known to registered
+	// addresses, so it is passed over as silently as an unknown address
+	if errors.Is(err, model.ErrUserNotFound) || errors.Is(err, model.ErrPasswordResetTooSoon) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	message, err := newPasswordResetEmail(s.emailConfig, user.Name, user.Email, resetToken)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(message)
+}
+
+// ResetPassword sets a new password using a reset token. The repository
+// lifts any lockout and revokes the user's existing sessions along with it.
+func (s UserService) ResetPassword(ctx context.Context, actor model.Actor, resetToken string, password string) error {
+	if _, err := uuid.Parse(resetToken); err != nil {
+		return model.ErrInvalidPasswordResetToken
+	}
+	user, err := s.repo.GetUserByPasswordResetToken(ctx, resetToken)
+	if err != nil {
+		return err
+	}
+	if err := s.passwordPolicy.Validate(password, user); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to encrypt password")
+	}
+	return s.repo.ResetPassword(ctx, actor, resetToken, hash, loginKey(user.Email))
+}
+
+func (s UserService) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	user, err := s.repo.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
-func (s UserService) CreateUser(newUser *model.NewUser) (*model.User, error) {
+func (s UserService) CreateUser(ctx context.Context, actor model.Actor, newUser *model.NewUser) (*model.User, error) {
 
 	if newUser == nil {
 		return nil, errors.New("new user cannot be nil")
@@ -77,21 +289,149 @@ func (s UserService) CreateUser(newUser *model.NewUser) (*model.User, error) {
 		return nil, err
 	}
 
-	return s.repo.CreateUser(newUser)
+	newUser.VerificationToken = uuid.NewString()
+	user, err := s.repo.CreateUser(ctx, actor, newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// the user exists either way, so a failed send must not fail the request
+	message, err := newVerificationEmail(s.emailConfig, user.Name, user.Email, newUser.VerificationToken)
+	if err == nil {
+		_ = s.mailer.Send(message)
+	}
+	return user, nil
+}
+
+// UpdateUser applies a partial update to the user's details, re-running the
+// validation applied to new users against the merged result.
+func (s UserService) UpdateUser(ctx context.Context, actor model.Actor, update *model.UpdateUser) (*model.User, error) {
+	if update == nil {
+		return nil, errors.New("user update cannot be nil")
+	}
+	user, err := s.repo.GetUserByID(ctx, update.ID)
+	if err != nil {
+		return nil, err
+	}
+	if update.Version != nil && *update.Version != user.Version {
+		return nil, model.ErrVersionMismatch
+	}
+
+	applyUserUpdate(user, update)
+
+	merged := &model.NewUser{
+		Name:        user.Name,
+		Email:       user.Email,
+		PhoneNumber: user.PhoneNumber,
+		Line1:       user.Line1,
+		Line2:       user.Line2,
+		Line3:       user.Line3,
+		Town:        user.Town,
+		County:      user.County,
+		Postcode:    user.Postcode,
+	}
+	if valid, err := merged.Valid(); !valid || err != nil {
+		return nil, errors.Wrap(model.ErrInvalidUser, "missing required user details")
+	}
+	if err := ValidateNewUser(merged); err != nil {
+		return nil, errors.Wrap(model.ErrInvalidUser, err.Error())
+	}
+	if err := ValidateNewUserAddress(merged); err != nil {
+		return nil, errors.Wrap(model.ErrInvalidUser, err.Error())
+	}
+
+	return s.repo.UpdateUser(ctx, actor, user)
 }
 
-func (s UserService) GetUserByEmailVerificationToken(emailToken string) (*model.User, error) {
-	if emailToken == "" {
-		return nil, errors.New("token cannot be empty")
+func (s UserService) DeleteUser(ctx context.Context, actor model.Actor, id string, version *int64) error {
+	if id == "" {
+		return errors.New("id cannot be empty")
 	}
-	return s.repo.GetUserByEmailVerificationToken(emailToken)
+	return s.repo.DeleteUser(ctx, actor, id, version)
 }
 
-func (s UserService) VerifyEmail(emailToken string) error {
-	if emailToken == "" {
-		return errors.New("token cannot be empty")
+func applyUserUpdate(user *model.User, update *model.UpdateUser) {
+	if update.Name != nil {
+		user.Name = *update.Name
 	}
-	return s.repo.VerifyEmail(emailToken)
+	if update.PhoneNumber != nil {
+		user.PhoneNumber = *update.PhoneNumber
+	}
+	if update.Line1 != nil {
+		user.Line1 = *update.Line1
+	}
+	if update.Line2 != nil {
+		user.Line2 = update.Line2
+	}
+	if update.Line3 != nil {
+		user.Line3 = update.Line3
+	}
+	if update.Town != nil {
+		user.Town = *update.Town
+	}
+	if update.County != nil {
+		user.County = update.County
+	}
+	if update.Postcode != nil {
+		user.Postcode = *update.Postcode
+	}
+}
+
+func (s UserService) GetUserByEmailVerificationToken(ctx context.Context, emailToken string) (*model.User, error) {
+	if _, err := uuid.Parse(emailToken); err != nil {
+		return nil, model.ErrVerificationTokenNotFound
+	}
+	return s.repo.GetUserByEmailVerificationToken(ctx, emailToken)
+}
+
+func (s UserService) VerifyEmail(ctx context.Context, actor model.Actor, emailToken string) error {
+	if _, err := uuid.Parse(emailToken); err != nil {
+		return model.ErrVerificationTokenNotFound
+	}
+	return s.repo.VerifyEmail(ctx, actor, emailToken)
+}
+
+// ResendVerificationEmail sends a new verification link, replacing any link
+// sent before. Unknown and already verified addresses are ignored so that the
+// response does not reveal which email addresses are registered.
+func (s UserService) ResendVerificationEmail(ctx context.Context, actor model.Actor, email string) error {
+	err := s.throttleEmailRequest(ctx, model.EmailKindVerification, email, verificationResendInterval, model.ErrVerificationResendTooSoon)
+	if err != nil {
+		return err
+	}
+
+	emailToken := uuid.NewString()
+	user, err := s.repo.ReplaceVerificationToken(ctx, actor, email, emailToken, time.Now().Add(-verificationResendInterval))
+	// the token sent on sign up also counts, but only registered addresses
+	// have one, so it is passed over as silently as an unknown address
+	if errors.Is(err, model.ErrUserNotFound) ||
+		errors.Is(err, model.ErrUserAlreadyVerified) ||
+		errors.Is(err, model.ErrVerificationResendTooSoon) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	message, err := newVerificationEmail(s.emailConfig, user.Name, user.Email, emailToken)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(message)
+}
+
+// throttleEmailRequest refuses a request for an email to address made within
+// interval of the last one. Requests are counted whether or not the address
+// is registered, so that being refused reveals nothing about it.
+func (s UserService) throttleEmailRequest(ctx context.Context, kind string, address string, interval time.Duration, tooSoon error) error {
+	last, recorded, err := s.emailSends.RecordEmailSend(ctx, kind, loginKey(address), time.Now().Add(-interval))
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		return &model.RetryAfterError{Err: tooSoon, RetryAfter: time.Until(last.SentAt.Add(interval))}
+	}
+	return nil
 }
 
 func ValidateNewUser(p *model.NewUser) error {
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"eagle-bank.com/internal/core/domain/model"
+	"eagle-bank.com/internal/core/port/mocks"
+	"eagle-bank.com/internal/core/service"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newEmailSendRepository returns an email send store that keeps sends in memory.
+func newEmailSendRepository() *mocks.EmailSendRepositoryMock {
+	sends := map[string]time.Time{}
+	return &mocks.EmailSendRepositoryMock{
+		RecordEmailSendFunc: func(ctx context.Context, kind string, address string, since time.Time) (*model.EmailSend, bool, error) {
+			last, ok := sends[kind+":"+address]
+			if ok && !last.Before(since) {
+				return &model.EmailSend{Kind: kind, Address: address, SentAt: last}, false, nil
+			}
+			sends[kind+":"+address] = time.Now()
+			return &model.EmailSend{Kind: kind, Address: address, SentAt: sends[kind+":"+address]}, true, nil
+		},
+	}
+}
+
+func TestUserService_RequestPasswordReset(t *testing.T) {
+
+	registered := "jane@example.com"
+	unknown := "nobody@example.com"
+
+	emailSends := newEmailSendRepository()
+	repo := &mocks.UserRepositoryMock{
+		ReplacePasswordResetTokenFunc: func(ctx context.Context, actor model.Actor, email string, resetToken string, notIssuedSince time.Time) (*model.User, error) {
+			if email != registered {
+				return nil, model.ErrUserNotFound
+			}
+			return &model.User{ID: uuid.NewString(), Name: "Jane", Email: email}, nil
+		},
+	}
+	mailer := &mocks.MailerMock{
+		SendFunc: func(message *model.EmailMessage) error {
+			return nil
+		},
+	}
+	userService := service.NewUserService(repo, &mocks.LoginAttemptRepositoryMock{}, emailSends, mailer, service.EmailConfig{}, nil, testLoginProtection)
+
+	// a second request is refused in the same way whether or not the address is registered
+	for _, email := range []string{registered, unknown} {
+		require.NoError(t, userService.RequestPasswordReset(context.Background(), model.AnonymousActor(""), email))
+
+		err := userService.RequestPasswordReset(context.Background(), model.AnonymousActor(""), strings.ToUpper(email))
+		require.ErrorIs(t, err, model.ErrPasswordResetTooSoon)
+		var retryErr *model.RetryAfterError
+		require.True(t, errors.As(err, &retryErr), email)
+		assert.InDelta(t, time.Minute.Seconds(), retryErr.RetryAfter.Seconds(), 1)
+	}
+	assert.Len(t, repo.ReplacePasswordResetTokenCalls(), 2)
+	assert.Len(t, mailer.SendCalls(), 1)
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+
+	userID := uuid.NewString()
+	currentHash, err := bcrypt.GenerateFromPassword([]byte("old-passw0rd"), bcrypt.MinCost)
+	require.NoError(t, err)
+	policy, err := service.NewPasswordPolicy(service.PasswordPolicyConfig{MinLength: 8, MaxLength: 72})
+	require.NoError(t, err)
+
+	newRepo := func() *mocks.UserRepositoryMock {
+		return &mocks.UserRepositoryMock{
+			GetPasswordHashesFunc: func(ctx context.Context, userID string, limit int) ([]string, error) {
+				return []string{string(currentHash)}, nil
+			},
+			GetUserByIDFunc: func(ctx context.Context, id string) (*model.User, error) {
+				return &model.User{ID: id, Name: "Jane", Email: "jane@example.com"}, nil
+			},
+			ChangePasswordFunc: func(ctx context.Context, actor model.Actor, userID string, hash []byte) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("incorrect current password is counted", func(t *testing.T) {
+		failures := map[string]int{}
+		repo := newRepo()
+		userService := service.NewUserService(repo, newLoginAttemptRepository(failures), nil, nil, service.EmailConfig{}, policy, testLoginProtection)
+
+		err := userService.ChangePassword(context.Background(), model.UserActor(userID, ""), userID, "wrong-passw0rd", "new-passw0rd")
+		require.ErrorIs(t, err, model.ErrIncorrectPassword)
+		assert.Equal(t, 1, failures[model.LoginScopePassword+":"+userID])
+		assert.Empty(t, repo.ChangePasswordCalls())
+	})
+
+	t.Run("repeated incorrect current passwords are delayed", func(t *testing.T) {
+		repo := newRepo()
+		attempts := newLoginAttemptRepository(map[string]int{model.LoginScopePassword + ":" + userID: 3})
+		userService := service.NewUserService(repo, attempts, nil, nil, service.EmailConfig{}, policy, testLoginProtection)
+
+		err := userService.ChangePassword(context.Background(), model.UserActor(userID, ""), userID, "old-passw0rd", "new-passw0rd")
+		require.ErrorIs(t, err, model.ErrLoginThrottled)
+		var retryErr *model.RetryAfterError
+		require.True(t, errors.As(err, &retryErr))
+		assert.Empty(t, repo.GetPasswordHashesCalls())
+		assert.Empty(t, repo.ChangePasswordCalls())
+	})
+
+	t.Run("correct current password changes it and clears failures", func(t *testing.T) {
+		failures := map[string]int{model.LoginScopePassword + ":" + userID: 2}
+		repo := newRepo()
+		userService := service.NewUserService(repo, newLoginAttemptRepository(failures), nil, nil, service.EmailConfig{}, policy, testLoginProtection)
+
+		err := userService.ChangePassword(context.Background(), model.UserActor(userID, ""), userID, "old-passw0rd", "new-passw0rd")
+		require.NoError(t, err)
+		assert.NotContains(t, failures, model.LoginScopePassword+":"+userID)
+		require.Len(t, repo.ChangePasswordCalls(), 1)
+		require.Len(t, repo.GetPasswordHashesCalls(), 1)
+		assert.Equal(t, model.PasswordHistoryDepth, repo.GetPasswordHashesCalls()[0].Limit)
+	})
+}
+
+func TestUserService_ReactivateUser(t *testing.T) {
+
+	userID := uuid.NewString()
+
+	t.Run("reactivation is a single repository change", func(t *testing.T) {
+		repo := &mocks.UserRepositoryMock{
+			ChangeUserStatusFunc: func(ctx context.Context, actor model.Actor, userID string, status string) error {
+				return nil
+			},
+		}
+		// failed logins are cleared by the repository in the same transaction,
+		// so the login attempt repository is not touched
+		userService := service.NewUserService(repo, &mocks.LoginAttemptRepositoryMock{}, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		err := userService.ReactivateUser(context.Background(), model.AdminActor(uuid.NewString(), ""), userID)
+		require.NoError(t, err)
+		calls := repo.ChangeUserStatusCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, userID, calls[0].UserID)
+		assert.Equal(t, model.UserStatusActive, calls[0].Status)
+	})
+
+	t.Run("user who cannot be reactivated is reported", func(t *testing.T) {
+		repo := &mocks.UserRepositoryMock{
+			ChangeUserStatusFunc: func(ctx context.Context, actor model.Actor, userID string, status string) error {
+				return model.ErrInvalidUserTransition
+			},
+		}
+		userService := service.NewUserService(repo, &mocks.LoginAttemptRepositoryMock{}, nil, nil, service.EmailConfig{}, nil, testLoginProtection)
+
+		err := userService.ReactivateUser(context.Background(), model.AdminActor(uuid.NewString(), ""), userID)
+		require.ErrorIs(t, err, model.ErrInvalidUserTransition)
+	})
+}
@@ -0,0 +1,63 @@
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"eagle-bank.com/internal/adapter/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/sethvargo/go-envconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresTestConfig turns on the tests that need a database server, which is
+// found through the same POSTGRES_* variables as the server uses.
+type postgresTestConfig struct {
+	Enabled bool `env:"POSTGRES_TEST, default=false"`
+}
+
+// NewPostgres returns a connection to a new, empty database that is dropped
+// when the test ends. The test is skipped unless POSTGRES_TEST is set.
+func NewPostgres(t *testing.T) *postgres.DBContext {
+	t.Helper()
+	ctx := context.Background()
+
+	testCfg := postgresTestConfig{}
+	require.NoError(t, envconfig.Process(ctx, &testCfg))
+	if !testCfg.Enabled {
+		t.Skip("set POSTGRES_TEST=true to run tests against postgres")
+	}
+
+	cfg := postgres.Config{}
+	require.NoError(t, envconfig.Process(ctx, &cfg))
+	server, err := postgres.OpenDB(ctx, cfg)
+	require.NoError(t, err)
+
+	name := fmt.Sprintf("eagle_test_%x", uuid.New().ID())
+	_, err = server.ExecContext(ctx, fmt.Sprintf(`CREATE DATABASE %s`, name))
+	require.NoError(t, err)
+
+	cfg.DatabaseName = name
+	db, err := postgres.NewDBContext(ctx, cfg)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+		_, _ = server.ExecContext(context.Background(), fmt.Sprintf(`DROP DATABASE IF EXISTS %s WITH (FORCE)`, name))
+		_ = server.Close()
+	})
+	return db
+}
+
+// NewMigratedPostgres returns a database as NewPostgres does, with every
+// migration applied.
+func NewMigratedPostgres(t *testing.T) *postgres.DBContext {
+	t.Helper()
+	db := NewPostgres(t)
+	migrations, err := postgres.Migrations()
+	require.NoError(t, err)
+	_, err = postgres.NewMigrator(db, migrations).Up(context.Background())
+	require.NoError(t, err)
+	return db
+}